@@ -8,8 +8,6 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
-	testutils "github.com/a-novel-kit/test-utils"
-
 	"github.com/a-novel-kit/quicklog/messages"
 )
 
@@ -22,6 +20,13 @@ var examples = map[string][]string{
 		messages.NewError(fmt.Errorf("We made a fucky wucky!"), "Oopsie Woopsie!!").RenderTerminal(),
 		messages.NewError(nil, "Oopsie Woopsie!!").RenderTerminal(),
 		messages.NewError(fmt.Errorf("We made a fucky wucky!"), "").RenderTerminal(),
+		messages.NewError(
+			fmt.Errorf("Cleaning up the mess: %w", errors.Join(
+				fmt.Errorf("Mop is broken: %w", errors.New("handle snapped")),
+				errors.New("Bucket is empty"),
+			)),
+			"Oopsie Woopsie!!",
+		).RenderTerminal(),
 	},
 	"Base": {
 		messages.NewBase(
@@ -35,39 +40,96 @@ var examples = map[string][]string{
 			nil,
 		).RenderTerminal(),
 	},
+	"Code": {
+		messages.NewCode(
+			"json",
+			"{\n\t\"name\": \"quicklog\",\n\t\"cozy\": true,\n\t\"stars\": 9001\n}",
+			&messages.CodeConfigDefault,
+		).RenderTerminal(),
+		messages.NewCode(
+			"sql",
+			"SELECT id, name\nFROM users\nWHERE name = 'your mom' -- Classic.\nLIMIT 1;",
+			&messages.CodeConfig{LineNumbers: true, Highlight: []int{3}},
+		).RenderTerminal(),
+	},
+	"Diff": {
+		messages.NewDiff(
+			"replicas: 2\nimage: app:1.0.0\nport: 8080\n",
+			"replicas: 3\nimage: app:1.1.0\nport: 8080\n",
+			&messages.DiffConfig{BeforeLabel: "current", AfterLabel: "planned"},
+		).RenderTerminal(),
+		messages.NewMapDiff(
+			map[string]interface{}{"mood": "sleepy", "coffee": 0},
+			map[string]interface{}{"mood": "cozy", "coffee": 2, "cat": true},
+			&messages.DiffConfigDefault,
+		).RenderTerminal(),
+	},
+	"Tree": {
+		messages.NewTree(messages.TreeNode{
+			Label: "quicklog",
+			Children: []messages.TreeNode{
+				{Label: "cmd", Children: []messages.TreeNode{{Label: "demo"}, {Label: "quicklog"}}},
+				{
+					Label:    "loggers",
+					Detail:   messages.NewBase("Where the logs go to sleep.", nil),
+					Children: []messages.TreeNode{{Label: "terminal.go"}, {Label: "zerolog.go"}},
+				},
+				{Label: "messages"},
+			},
+		}).RenderTerminal(),
+	},
+	"Group": {
+		messages.NewGroup(
+			messages.NewBase("Getting ready for the day"),
+			messages.NewBase("Brewed the coffee"),
+			messages.NewGroup(
+				messages.NewBase("Fed the cats"),
+				messages.NewBase("Luna: ate everything"),
+				messages.NewBase("Miso: judged the food, then ate everything"),
+			),
+		).RenderTerminal(),
+	},
+	"Link": {
+		messages.NewLink("Cozy dashboard", "https://github.com/a-novel-kit/quicklog").RenderTerminal(),
+		messages.NewBase(
+			fmt.Sprintf(
+				"Go %s, you know you want to.",
+				messages.NewLink("star the repo", "https://github.com/a-novel-kit/quicklog"),
+			),
+			nil,
+		).RenderTerminal(),
+	},
+	"Text": {
+		messages.Text().
+			Plain("Deployed ").Bold("quicklog").Plain(" to ").Color("production", "9").Plain(" with ").
+			Code("make deploy").Dim(" (it took 3s, nobody noticed)").
+			RenderTerminal(),
+	},
 	"Loader": {
-		func() string {
-			loader := messages.NewLoader("On my way to do your mom!", &messages.LoaderConfigDefault)
-			defer loader.Close()
-
-			var message string
-			defer testutils.CaptureChan(loader.RunTerminal(true), &message)()
-
-			return message
-		}(),
-		func() string {
-			loader := messages.NewLoader("On my way to do your mom!", &messages.LoaderConfigDefault)
-			defer loader.Close()
-
-			var message string
-			defer testutils.CaptureChan(loader.RunTerminal(true), &message)()
-
+		renderLoader(func(_ messages.Loader) {}),
+		renderLoader(func(loader messages.Loader) {
 			loader.Error(errors.New("Oops met your dad instead!"))
+		}),
+		renderLoader(func(loader messages.Loader) {
+			loader.Success("Looks like you have a new mommy!")
+		}),
+	},
+}
 
-			return message
-		}(),
-		func() string {
-			loader := messages.NewLoader("On my way to do your mom!", &messages.LoaderConfigDefault)
-			defer loader.Close()
+// Run a loader, and return the last state it rendered.
+func renderLoader(run func(loader messages.Loader)) string {
+	loader := messages.NewLoader("On my way to do your mom!", &messages.LoaderConfigDefault)
+	channel := loader.RunTerminal(true)
 
-			var message string
-			defer testutils.CaptureChan(loader.RunTerminal(true), &message)()
+	run(loader)
+	loader.Close()
 
-			loader.Success("Looks like you have a new mommy!")
+	var message string
+	for rendered := range channel {
+		message = rendered
+	}
 
-			return message
-		}(),
-	},
+	return message
 }
 
 func main() {
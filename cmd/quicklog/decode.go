@@ -0,0 +1,37 @@
+package main
+
+func asString(value interface{}) string {
+	output, _ := value.(string)
+	return output
+}
+
+// Collect every string value of a rendering, for filtering.
+func collectText(value interface{}) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case map[string]interface{}:
+		var output []string
+
+		for key, item := range typed {
+			// Skip technical fields.
+			switch key {
+			case "level", "time", "op_id", "type", "trace_id", "span_id", "kind", "header_kind":
+				continue
+			}
+
+			output = append(output, collectText(item)...)
+		}
+
+		return output
+	case []interface{}:
+		var output []string
+		for _, item := range typed {
+			output = append(output, collectText(item)...)
+		}
+
+		return output
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+// Command quicklog pretty-prints JSON log streams, written by loggers.NewZerolog, in the terminal style of quicklog.
+//
+//	quicklog [--level LEVEL] [--grep PATTERN] [--follow] [FILE...]
+//
+// Logs are read from the files given as arguments, or from the standard input if none is provided. Loader updates
+// are grouped by operation, and only their final state is printed.
+//
+// It also replays sessions recorded with loggers.NewRecorder:
+//
+//	quicklog replay [--speed SPEED] [--max-wait DURATION] FILE
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"time"
+
+	"github.com/a-novel-kit/quicklog/loggers"
+)
+
+// Interval between two reads, when following a file that reached its end.
+const followInterval = 250 * time.Millisecond
+
+// Read a stream line by line. If follow is true, the end of the stream is awaited until the context is done.
+func readStream(ctx context.Context, reader io.Reader, follow bool, handle func(line []byte)) error {
+	buffered := bufio.NewReader(reader)
+
+	var partial []byte
+
+	for {
+		chunk, err := buffered.ReadBytes('\n')
+		partial = append(partial, chunk...)
+
+		if err == nil {
+			handle(partial[:len(partial)-1])
+			partial = nil
+
+			continue
+		}
+
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if !follow {
+			handle(partial)
+			return nil
+		}
+
+		// Wait for more content, keeping the incomplete line for later.
+		select {
+		case <-ctx.Done():
+			handle(partial)
+			return nil
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+func runReplay(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("quicklog replay", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	speed := flags.Float64("speed", 1, "playback speed multiplier")
+	maxWait := flags.Duration("max-wait", 0, "maximum delay between two outputs, to skip idle periods")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("replay expects exactly one recording file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("open %s: %w", flags.Arg(0), err)
+	}
+	defer file.Close()
+
+	return loggers.Replay(ctx, file, stdout, &loggers.ReplayConfig{Speed: *speed, MaxWait: *maxWait})
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "replay" {
+		return runReplay(ctx, args[1:], stdout)
+	}
+
+	flags := flag.NewFlagSet("quicklog", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	level := flags.String("level", "", "minimum level of the messages to print (debug, info, warn, error, fatal)")
+	grep := flags.String("grep", "", "only print messages with a field that matches this regular expression")
+	follow := flags.Bool("follow", false, "keep reading the last file once its end is reached, like tail -f")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config := printerConfig{level: *level}
+
+	if *level != "" {
+		if _, ok := levelRanks[*level]; !ok {
+			return fmt.Errorf("unknown level %q", *level)
+		}
+	}
+
+	if *grep != "" {
+		pattern, err := regexp.Compile(*grep)
+		if err != nil {
+			return fmt.Errorf("invalid grep pattern: %w", err)
+		}
+
+		config.grep = pattern
+	}
+
+	output := newPrinter(stdout, config)
+	defer output.flush()
+
+	if flags.NArg() == 0 {
+		return readStream(ctx, stdin, false, output.handleLine)
+	}
+
+	for i, path := range flags.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path, err)
+		}
+
+		// Only the last file can be followed, since the others would never end.
+		err = readStream(ctx, file, *follow && i == flags.NArg()-1, output.handleLine)
+		_ = file.Close()
+
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		cancel()
+
+		if !errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		}
+
+		os.Exit(1) //nolint:gocritic
+	}
+}
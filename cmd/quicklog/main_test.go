@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const sampleLogs = `{"level":"info","content":"Deploying the app","message":"Deploy"}
+{"level":"info","data":{"message":"child"},"message":"Starting"}
+{"level":"info","code":"SELECT 1;","language":"sql"}
+{"level":"info","message":"app","tree":{"label":"app","children":[{"label":"cmd","children":[{"label":"main.go"}]},{"label":"go.mod"}]}}
+{"level":"info","message":"Dashboard","url":"https://example.com"}
+{"level":"info","message":"See logs","links":[{"text":"logs","url":"https://logs.io"}]}
+{"level":"info","message":"Deploying","children":[{"message":"api"},{"message":"worker"}]}
+{"level":"info","message":"Deployed api","spans":[{"text":"Deployed ","style":"plain"},{"text":"api","style":"bold"}]}
+{"level":"info","elapsed":"2.125µs","elapsed_nanos":2125,"message":"Building","op_id":"op-1","status":"running"}
+{"level":"info","elapsed":"1ms","elapsed_nanos":1000000,"message":"Testing","op_id":"op-2","status":"running"}
+{"level":"info","elapsed":"20.198386ms","elapsed_nanos":20198386,"message":"Still building","op_id":"op-1","status":"running"}
+{"level":"info","elapsed":"41.154039ms","elapsed_nanos":41154039,"message":"Built","op_id":"op-1","status":"success"}
+not a json line
+{"level":"error","error":{"chain":[{"message":"deploy: timeout","type":"*fmt.wrapError"},{"message":"timeout","type":"*errors.errorString"}],"type":"*fmt.wrapError"},"message":"Deploy failed"}
+`
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		args []string
+
+		expect string
+	}{
+		{
+			name: "AllMessages",
+
+			expect: "╭────────────────────────────────────────────────────────────────────────────────╮\n" +
+				"│ Deploy                                                                         │\n" +
+				"│ Deploying the app                                                              │\n" +
+				"╰────────────────────────────────────────────────────────────────────────────────╯\n" +
+				"Starting                                                                        \n" +
+				"child                                                                           \n" +
+				"╭────────────────────────────────────────────────────────────────────────────────╮\n" +
+				"│ SELECT 1;                                                                      │\n" +
+				"╰────────────────────────────────────────────────────────────────────────────────╯\n" +
+				"app                                                                             \n" +
+				"├── cmd                                                                         \n" +
+				"│   └── main.go                                                                 \n" +
+				"└── go.mod                                                                      \n" +
+				"Dashboard (https://example.com)                                                 \n" +
+				"See logs (https://logs.io)                                                      \n" +
+				"Deploying                                                                       \n" +
+				"│ api                                                                           \n" +
+				"│ worker                                                                        \n" +
+				"Deployed api                                                                    \n" +
+				"✓ Built                                                                     41ms\n" +
+				"not a json line\n" +
+				"Deploy failed                                                                   \n" +
+				"deploy                                                                          \n" +
+				"└── timeout                                                                     \n" +
+				// Loaders that never completed are printed last, in their latest state.
+				"… Testing                                                                    1ms\n",
+		},
+		{
+			name: "Level",
+
+			args: []string{"--level", "error"},
+
+			expect: "not a json line\n" +
+				"Deploy failed                                                                   \n" +
+				"deploy                                                                          \n" +
+				"└── timeout                                                                     \n",
+		},
+		{
+			name: "Grep",
+
+			args: []string{"--grep", "^(child|Built)$"},
+
+			expect: "Starting                                                                        \n" +
+				"child                                                                           \n" +
+				"✓ Built                                                                     41ms\n" +
+				"not a json line\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+
+			err := run(context.Background(), testCase.args, strings.NewReader(sampleLogs), output)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expect, output.String())
+		})
+	}
+}
+
+func TestRunKind(t *testing.T) {
+	logs := `{"level":"info","kind":"base","schema_version":1,"message":"Dashboard","url":"https://example.com"}
+{"level":"info","kind":"chart","schema_version":1,"message":"Sales"}
+{"level":"info","kind":"base","schema_version":99,"message":"From the future"}
+`
+
+	output := new(bytes.Buffer)
+	require.NoError(t, run(context.Background(), nil, strings.NewReader(logs), output))
+
+	// The kind takes precedence over the keys of the rendering. Renderings that cannot be decoded are printed as JSON.
+	require.Equal(
+		t,
+		"Dashboard                                                                       \n"+
+			`{"kind":"chart","level":"info","message":"Sales","schema_version":1}`+"\n"+
+			`{"kind":"base","level":"info","message":"From the future","schema_version":99}`+"\n",
+		output.String(),
+	)
+}
+
+func TestRunInvalidFlags(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	require.Error(t, run(context.Background(), []string{"--level", "foo"}, strings.NewReader(""), output))
+	require.Error(t, run(context.Background(), []string{"--grep", "("}, strings.NewReader(""), output))
+	require.Error(t, run(context.Background(), []string{"does-not-exist.log"}, strings.NewReader(""), output))
+}
+
+func TestRunFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"level":"info","message":"first"}`+"\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	output := new(safeBuffer)
+	done := make(chan error)
+
+	go func() {
+		done <- run(ctx, []string{"--follow", path}, strings.NewReader(""), output)
+	}()
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "first")
+	}, time.Second, 10*time.Millisecond)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+
+	_, err = file.WriteString(`{"level":"info","message":"second"}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "second")
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// safeBuffer is a bytes.Buffer that can be read while being written by another goroutine.
+type safeBuffer struct {
+	buffer bytes.Buffer
+	mu     sync.Mutex
+}
+
+func (buffer *safeBuffer) Write(p []byte) (int, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.buffer.Write(p)
+}
+
+func (buffer *safeBuffer) String() string {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.buffer.String()
+}
+
+func TestRunReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(
+		path,
+		[]byte(`{"time":0,"output":"first\n"}`+"\n"+`{"time":0.01,"output":"second\n"}`+"\n"),
+		0o600,
+	))
+
+	output := new(bytes.Buffer)
+
+	require.NoError(t, run(context.Background(), []string{"replay", "--speed", "10", path}, nil, output))
+	require.Equal(t, "first\nsecond\n", output.String())
+
+	require.Error(t, run(context.Background(), []string{"replay"}, nil, output))
+}
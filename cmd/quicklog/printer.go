@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Rank of the levels written by zerolog, for filtering.
+var levelRanks = map[string]int{
+	"trace":   0,
+	"debug":   1,
+	"info":    2,
+	"warn":    3,
+	"warning": 3,
+	"error":   4,
+	"fatal":   5,
+	"panic":   6,
+}
+
+type printerConfig struct {
+	// Minimum level of the messages to print.
+	level string
+	// Only print the messages with a field that matches this pattern.
+	grep *regexp.Regexp
+}
+
+// printer converts a stream of JSON log lines back to the terminal rendering of quicklog.
+type printer struct {
+	out    io.Writer
+	config printerConfig
+
+	// Latest state of the loaders that did not reach a final state yet, indexed by op_id.
+	loaders map[string]map[string]interface{}
+	// Order in which loaders were first seen, to flush them in a stable order.
+	loadersOrder []string
+}
+
+func (p *printer) accept(fields map[string]interface{}) bool {
+	if p.config.level != "" {
+		level, ok := levelRanks[asString(fields["level"])]
+		if ok && level < levelRanks[p.config.level] {
+			return false
+		}
+	}
+
+	if p.config.grep == nil {
+		return true
+	}
+
+	return lo.SomeBy(collectText(fields), p.config.grep.MatchString)
+}
+
+// Render a message from its JSON rendering. Renderings that cannot be decoded, such as those of a newer schema
+// version, are printed as JSON.
+func render(fields map[string]interface{}) string {
+	message, err := messages.Decode(fields)
+	if err != nil {
+		raw, _ := json.Marshal(fields)
+		return string(raw) + "\n"
+	}
+
+	return message.RenderTerminal()
+}
+
+func (p *printer) print(rendered string) {
+	if rendered == "" {
+		return
+	}
+
+	_, _ = fmt.Fprint(p.out, rendered)
+}
+
+func (p *printer) printLoader(fields map[string]interface{}) {
+	if p.accept(fields) {
+		p.print(render(fields))
+	}
+}
+
+// Handle a single line of the stream.
+func (p *printer) handleLine(line []byte) {
+	if len(line) == 0 {
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		// Not a JSON log: print it as is.
+		p.print(string(line) + "\n")
+		return
+	}
+
+	// Loader updates are grouped by operation, and only the final state is printed.
+	if opID := asString(fields["op_id"]); opID != "" && fields["status"] != nil {
+		if fields["status"] == "running" {
+			if _, ok := p.loaders[opID]; !ok {
+				p.loadersOrder = append(p.loadersOrder, opID)
+			}
+
+			p.loaders[opID] = fields
+
+			return
+		}
+
+		if _, ok := p.loaders[opID]; ok {
+			delete(p.loaders, opID)
+			p.loadersOrder = lo.Without(p.loadersOrder, opID)
+		}
+
+		p.printLoader(fields)
+
+		return
+	}
+
+	if !p.accept(fields) {
+		return
+	}
+
+	p.print(render(fields))
+}
+
+// Print the last known state of the loaders that never reached a final state.
+func (p *printer) flush() {
+	for _, opID := range p.loadersOrder {
+		p.printLoader(p.loaders[opID])
+	}
+
+	p.loaders = map[string]map[string]interface{}{}
+	p.loadersOrder = nil
+}
+
+func newPrinter(out io.Writer, config printerConfig) *printer {
+	return &printer{
+		out:     out,
+		config:  config,
+		loaders: map[string]map[string]interface{}{},
+	}
+}
@@ -0,0 +1,109 @@
+// Command schema generates the JSON Schema of the JSON renderings of messages, from the types of the messages
+// package. It must run from the root of the module, so the comments of the types can be used as descriptions:
+//
+//	go run ./cmd/schema -o schema/quicklog.schema.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/invopop/jsonschema"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+const (
+	modulePath = "github.com/a-novel-kit/quicklog"
+	schemaID   = "https://github.com/a-novel-kit/quicklog/schema/quicklog.schema.json"
+)
+
+// Renderings of nested messages refer to the root of the schema, which accepts any kind of message. A new schema is
+// returned each time, since the reflector sets the description of fields on it.
+func nestedMessageSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{Ref: "#"}
+}
+
+func mapType(typ reflect.Type) *jsonschema.Schema {
+	switch typ {
+	case reflect.TypeOf(messages.MessageJSON{}):
+		return nestedMessageSchema()
+	case reflect.TypeOf(messages.ChildrenJSON{}):
+		return &jsonschema.Schema{
+			OneOf: []*jsonschema.Schema{
+				nestedMessageSchema(),
+				{Type: "array", Items: nestedMessageSchema()},
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+func generate() ([]byte, error) {
+	reflector := &jsonschema.Reflector{
+		// New keys may be added to renderings without a new schema version.
+		AllowAdditionalProperties: true,
+		Mapper:                    mapType,
+	}
+
+	if err := reflector.AddGoComments(modulePath, "./messages"); err != nil {
+		return nil, fmt.Errorf("read comments: %w", err)
+	}
+
+	schema := &jsonschema.Schema{
+		Version: jsonschema.Version,
+		ID:      schemaID,
+		Title:   fmt.Sprintf("quicklog message (schema version %d)", quicklog.SchemaVersion),
+		Description: "JSON rendering of a quicklog message. The kind key tells which definition applies. " +
+			"Nested messages omit the schema_version key.",
+		Definitions: jsonschema.Definitions{},
+	}
+
+	kinds := lo.Keys(messages.KindTypes)
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		kindSchema := reflector.Reflect(messages.KindTypes[kind])
+
+		for name, definition := range kindSchema.Definitions {
+			schema.Definitions[name] = definition
+		}
+
+		schema.OneOf = append(schema.OneOf, &jsonschema.Schema{Ref: kindSchema.Ref})
+	}
+
+	output, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(output, '\n'), nil
+}
+
+func main() {
+	output := flag.String("o", "", "file to write the schema to, instead of the standard output")
+	flag.Parse()
+
+	schema, err := generate()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		_, _ = os.Stdout.Write(schema)
+		return
+	}
+
+	if err = os.WriteFile(*output, schema, 0o644); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
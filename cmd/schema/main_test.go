@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The published schema must be regenerated whenever the types of the messages package change.
+func TestSchemaUpToDate(t *testing.T) {
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	// The generator reads comments relative to the root of the module.
+	require.NoError(t, os.Chdir("../.."))
+	t.Cleanup(func() { _ = os.Chdir(workDir) })
+
+	schema, err := generate()
+	require.NoError(t, err)
+
+	published, err := os.ReadFile("schema/quicklog.schema.json")
+	require.NoError(t, err)
+
+	require.Equal(t, string(published), string(schema), "run go generate ./messages to update the schema")
+}
@@ -0,0 +1,84 @@
+package quicklog
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// ErrFatal is used to terminate running animated messages in an error state, when a fatal message is logged.
+var ErrFatal = errors.New("interrupted by a fatal error")
+
+// FatalExitCode is the status the program exits with, after a message is logged with LevelFatal.
+var FatalExitCode = 1
+
+// ExitFunc terminates the program. It may be replaced, for example to test fatal paths in-process.
+var ExitFunc = os.Exit
+
+type fatalHook struct {
+	id   uint64
+	hook func()
+}
+
+var (
+	fatalHooks       []fatalHook
+	fatalHooksLastID uint64
+	fatalHooksActive bool
+	fatalHooksMu     sync.Mutex
+)
+
+// OnFatal registers a hook that runs before the program exits, after a fatal message is logged. Deferred functions
+// do not run when the program exits, so this is the place for cleanup that must happen anyway.
+//
+// Hooks run in reverse order of registration, like deferred functions. The returned function unregisters the hook.
+func OnFatal(hook func()) (remove func()) {
+	fatalHooksMu.Lock()
+	defer fatalHooksMu.Unlock()
+
+	fatalHooksLastID++
+	id := fatalHooksLastID
+
+	fatalHooks = append(fatalHooks, fatalHook{id: id, hook: hook})
+
+	return func() {
+		fatalHooksMu.Lock()
+		defer fatalHooksMu.Unlock()
+
+		for i, registered := range fatalHooks {
+			if registered.id == id {
+				fatalHooks = append(fatalHooks[:i], fatalHooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Run the registered hooks. Hooks that log fatal messages themselves do not trigger the hooks again.
+func runFatalHooks() {
+	fatalHooksMu.Lock()
+	if fatalHooksActive {
+		fatalHooksMu.Unlock()
+		return
+	}
+
+	fatalHooksActive = true
+	hooks := append([]fatalHook(nil), fatalHooks...)
+	fatalHooksMu.Unlock()
+
+	defer func() {
+		fatalHooksMu.Lock()
+		fatalHooksActive = false
+		fatalHooksMu.Unlock()
+	}()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].hook()
+	}
+}
+
+// Exit runs the hooks registered with OnFatal, then terminates the program using ExitFunc. Loggers call it after
+// writing a fatal message.
+func Exit(code int) {
+	runFatalHooks()
+	ExitFunc(code)
+}
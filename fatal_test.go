@@ -0,0 +1,73 @@
+package quicklog_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Replace the exit function for the duration of a test.
+func mockExit(t *testing.T) *[]int {
+	t.Helper()
+
+	var codes []int
+
+	originalExitFunc := quicklog.ExitFunc
+	quicklog.ExitFunc = func(code int) {
+		codes = append(codes, code)
+	}
+
+	t.Cleanup(func() {
+		quicklog.ExitFunc = originalExitFunc
+	})
+
+	return &codes
+}
+
+func TestExit(t *testing.T) {
+	codes := mockExit(t)
+
+	var calls []string
+
+	removeFirst := quicklog.OnFatal(func() { calls = append(calls, "first") })
+	defer removeFirst()
+
+	removeSecond := quicklog.OnFatal(func() { calls = append(calls, "second") })
+	defer removeSecond()
+
+	removeThird := quicklog.OnFatal(func() { calls = append(calls, "third") })
+
+	quicklog.Exit(3)
+
+	// Hooks run in reverse order of registration.
+	require.Equal(t, []string{"third", "second", "first"}, calls)
+	require.Equal(t, []int{3}, *codes)
+
+	calls = nil
+
+	removeThird()
+	quicklog.Exit(quicklog.FatalExitCode)
+
+	require.Equal(t, []string{"second", "first"}, calls)
+	require.Equal(t, []int{3, 1}, *codes)
+}
+
+func TestExitReentrantHook(t *testing.T) {
+	codes := mockExit(t)
+
+	var calls int
+
+	remove := quicklog.OnFatal(func() {
+		calls++
+		// Exiting from a hook must not run the hooks again.
+		quicklog.Exit(4)
+	})
+	defer remove()
+
+	quicklog.Exit(3)
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, []int{4, 3}, *codes)
+}
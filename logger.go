@@ -12,7 +12,8 @@ const (
 	// LevelError is used for messages that indicate an error occurred.
 	LevelError Level = "ERROR"
 	// LevelFatal is used for messages that indicate a fatal error occurred. A logger implementation should
-	// automatically exit the program, or trigger a crash, after logging a message with this level.
+	// automatically exit the program, or trigger a crash, after logging a message with this level. Implementations
+	// should exit using Exit, so the hooks registered with OnFatal run.
 	LevelFatal Level = "FATAL"
 )
 
@@ -26,3 +27,13 @@ type Logger interface {
 	// Attempting to log while an animated message is running will cause a panic.
 	LogAnimated(message AnimatedMessage) (cleaner func())
 }
+
+// InterruptibleLogger is implemented by loggers that can terminate their running animated message on demand, for
+// example when the program is about to crash.
+type InterruptibleLogger interface {
+	Logger
+
+	// Interrupt closes the running animated message, if any. If err is not nil, and the message supports it, the
+	// message is terminated in an error state first.
+	Interrupt(err error)
+}
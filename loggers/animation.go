@@ -0,0 +1,91 @@
+package loggers
+
+import (
+	"log"
+	"os"
+	"sync"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// animationState keeps track of the animated message being rendered by a logger.
+type animationState struct {
+	message quicklog.AnimatedMessage
+	cleaner func()
+
+	mu sync.Mutex
+}
+
+// Crash the program if an animated message is running. Prevents concurrent logs that cause management issues.
+//
+// It returns false if the program did not exit, because quicklog.ExitFunc was replaced. The log must be aborted
+// in this case.
+func (state *animationState) checkAnimationLock() bool {
+	state.mu.Lock()
+	running := state.message != nil
+	state.mu.Unlock()
+
+	if running {
+		log.New(os.Stderr, "", 0).Print("cannot log while an animated message is running")
+		quicklog.Exit(quicklog.FatalExitCode)
+
+		return false
+	}
+
+	return true
+}
+
+// Register a new animated message, rendered by the render function until the message is closed. The returned
+// cleaner closes the message, and waits for the rendering to complete. It is safe to call multiple times.
+//
+// Callers must check the animation lock before subscribing to the message.
+func (state *animationState) start(message quicklog.AnimatedMessage, render func()) func() {
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(1)
+
+	var once sync.Once
+
+	cleaner := func() {
+		once.Do(func() {
+			state.mu.Lock()
+			state.message = nil
+			state.cleaner = nil
+			state.mu.Unlock()
+
+			message.Close()
+			waitGroup.Wait()
+		})
+	}
+
+	state.mu.Lock()
+	state.message = message
+	state.cleaner = cleaner
+	state.mu.Unlock()
+
+	go func() {
+		defer waitGroup.Done()
+
+		render()
+	}()
+
+	return cleaner
+}
+
+// Close the running animated message, if any. If err is not nil, and the message supports it, the message is
+// terminated in an error state first.
+func (state *animationState) interrupt(err error) {
+	state.mu.Lock()
+	message, cleaner := state.message, state.cleaner
+	state.mu.Unlock()
+
+	if cleaner == nil {
+		return
+	}
+
+	// Animated messages such as messages.Loader can be terminated in an error state.
+	if failable, ok := message.(interface{ Error(err error) }); ok && err != nil {
+		failable.Error(err)
+	}
+
+	cleaner()
+}
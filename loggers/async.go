@@ -0,0 +1,257 @@
+package loggers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// OverflowPolicy determines how an asynchronous logger behaves when its queue is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock blocks the caller until the queue has room for the new message.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropNewest discards the message being logged.
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowDropOldest discards the oldest message in the queue, to make room for the new one.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+)
+
+// AsyncLogger is a Logger that writes messages in the background.
+type AsyncLogger interface {
+	quicklog.Logger
+
+	// Flush blocks until every message logged before the call has been written, or the context is done.
+	Flush(ctx context.Context) error
+	// Close flushes the pending messages, and stops the background writer. Messages logged after Close are
+	// written synchronously.
+	Close(ctx context.Context) error
+	// Dropped returns the number of messages discarded because the queue was full.
+	Dropped() uint64
+}
+
+type asyncEntry struct {
+	seq     uint64
+	level   quicklog.Level
+	message quicklog.Message
+}
+
+type asyncWaiter struct {
+	seq  uint64
+	done chan struct{}
+}
+
+type asyncLogger struct {
+	inner  quicklog.Logger
+	config AsyncConfig
+
+	queue []asyncEntry
+	// Signals changes in the queue, to both the writer and the blocked callers.
+	cond *sync.Cond
+	mu   sync.Mutex
+
+	// Sequence number of the last message enqueued.
+	lastSeq uint64
+	// Sequence number of the last message processed by the writer, either written or dropped.
+	writtenSeq uint64
+	// Callers of Flush, waiting for the writer to reach a given sequence number.
+	waiters []asyncWaiter
+
+	dropped uint64
+	closed  bool
+	// Closed once the background writer exits.
+	done chan struct{}
+
+	quicklog.Logger
+}
+
+// Release the waiters whose messages have all been processed. Caller must hold the lock.
+func (logger *asyncLogger) notifyWaiters() {
+	remaining := logger.waiters[:0]
+
+	for _, waiter := range logger.waiters {
+		if waiter.seq <= logger.writtenSeq {
+			close(waiter.done)
+			continue
+		}
+
+		remaining = append(remaining, waiter)
+	}
+
+	logger.waiters = remaining
+}
+
+// Insert a new message in the queue, according to the overflow policy. Caller must hold the lock.
+//
+// It returns false if the logger was closed while waiting for room in the queue. In this case, the message was not
+// queued, and must be written by the caller.
+func (logger *asyncLogger) enqueue(level quicklog.Level, message quicklog.Message) bool {
+	if len(logger.queue) >= logger.config.QueueSize {
+		switch logger.config.Overflow {
+		case OverflowDropNewest:
+			logger.dropped++
+			return true
+		case OverflowDropOldest:
+			logger.queue = logger.queue[1:]
+			logger.dropped++
+		default:
+			for len(logger.queue) >= logger.config.QueueSize && !logger.closed {
+				logger.cond.Wait()
+			}
+
+			if logger.closed {
+				return false
+			}
+		}
+	}
+
+	logger.lastSeq++
+	logger.queue = append(logger.queue, asyncEntry{seq: logger.lastSeq, level: level, message: message})
+	logger.cond.Broadcast()
+
+	return true
+}
+
+// Background writer. It runs until the logger is closed and its queue is empty.
+func (logger *asyncLogger) run() {
+	defer close(logger.done)
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	for {
+		for len(logger.queue) == 0 {
+			// Messages dropped from the queue will never be written, so an empty queue means everything
+			// has been processed.
+			logger.writtenSeq = logger.lastSeq
+			logger.notifyWaiters()
+
+			if logger.closed {
+				return
+			}
+
+			logger.cond.Wait()
+		}
+
+		entry := logger.queue[0]
+		logger.queue = logger.queue[1:]
+		logger.cond.Broadcast()
+
+		logger.mu.Unlock()
+		logger.inner.Log(entry.level, entry.message)
+		logger.mu.Lock()
+
+		logger.writtenSeq = entry.seq
+		logger.notifyWaiters()
+	}
+}
+
+func (logger *asyncLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// The background writer cannot find the caller of the message, so it is captured beforehand.
+	queued := withCaller(message, findMessageCaller(message))
+
+	logger.mu.Lock()
+
+	// Fatal messages terminate the program, so every pending message must be written first. Messages logged after
+	// Close have no writer to process them.
+	if level != quicklog.LevelFatal && !logger.closed && logger.enqueue(level, queued) {
+		logger.mu.Unlock()
+		return
+	}
+
+	logger.mu.Unlock()
+
+	_ = logger.Flush(context.Background())
+	logger.inner.Log(level, message)
+}
+
+func (logger *asyncLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	// Animated messages are rendered in real-time, so they must start after the pending messages are written.
+	_ = logger.Flush(context.Background())
+
+	return logger.inner.LogAnimated(message)
+}
+
+func (logger *asyncLogger) Interrupt(err error) {
+	if interruptible, ok := logger.inner.(quicklog.InterruptibleLogger); ok {
+		interruptible.Interrupt(err)
+	}
+}
+
+func (logger *asyncLogger) Flush(ctx context.Context) error {
+	logger.mu.Lock()
+
+	if logger.writtenSeq >= logger.lastSeq {
+		logger.mu.Unlock()
+		return nil
+	}
+
+	waiter := asyncWaiter{seq: logger.lastSeq, done: make(chan struct{})}
+	logger.waiters = append(logger.waiters, waiter)
+	logger.mu.Unlock()
+
+	select {
+	case <-waiter.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (logger *asyncLogger) Close(ctx context.Context) error {
+	logger.mu.Lock()
+	logger.closed = true
+	logger.cond.Broadcast()
+	logger.mu.Unlock()
+
+	select {
+	case <-logger.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (logger *asyncLogger) Dropped() uint64 {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	return logger.dropped
+}
+
+type AsyncConfig struct {
+	// QueueSize is the maximum number of messages waiting to be written.
+	QueueSize int
+	// Overflow determines what happens when a message is logged while the queue is full.
+	Overflow OverflowPolicy
+}
+
+var AsyncConfigDefault = AsyncConfig{
+	QueueSize: 1024,
+	Overflow:  OverflowBlock,
+}
+
+// NewAsync wraps a Logger, so messages are rendered and written by a background goroutine.
+//
+// Messages are rendered after Log returns, so they must not be modified once logged. Fatal messages are always
+// written synchronously, after every pending message. The caller of each message is captured when it is logged, so
+// loggers that print it attribute messages to the code that logged them.
+func NewAsync(inner quicklog.Logger, config *AsyncConfig) AsyncLogger {
+	logger := &asyncLogger{
+		inner:  inner,
+		config: *config,
+		done:   make(chan struct{}),
+	}
+
+	if logger.config.QueueSize <= 0 {
+		logger.config.QueueSize = AsyncConfigDefault.QueueSize
+	}
+
+	logger.cond = sync.NewCond(&logger.mu)
+
+	go logger.run()
+
+	return logger
+}
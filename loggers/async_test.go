@@ -0,0 +1,184 @@
+package loggers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func TestAsyncLog(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewAsync(inner, &loggers.AsyncConfigDefault)
+
+	for i := 0; i < 10; i++ {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(fmt.Sprintf("message %d", i), nil))
+	}
+
+	require.NoError(t, logger.Flush(context.Background()))
+	require.Len(t, inner.messages(), 10)
+
+	for i, message := range inner.messages() {
+		require.Equal(t, fmt.Sprintf("message %d", i), message)
+	}
+
+	require.NoError(t, logger.Close(context.Background()))
+
+	// Messages logged after Close are written synchronously.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("late message", nil))
+	require.Equal(t, "late message", inner.messages()[10])
+}
+
+func TestAsyncCaller(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewAsync(
+				loggers.NewTerminalWithConfig(&loggers.TerminalConfig{Caller: true}), &loggers.AsyncConfigDefault,
+			)
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("This is an info message."))
+			require.NoError(t, logger.Close(context.Background()))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			// Messages are attributed to the code that logged them, not to the background writer.
+			require.Regexp(t, regexp.MustCompile(`^async_test\.go:\d+ +This is an info message\.`), res.STDOut)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		overflow loggers.OverflowPolicy
+
+		expect        []string
+		expectDropped uint64
+	}{
+		{
+			name: "DropNewest",
+
+			overflow: loggers.OverflowDropNewest,
+
+			expect:        []string{"message 0", "message 1", "message 2"},
+			expectDropped: 2,
+		},
+		{
+			name: "DropOldest",
+
+			overflow: loggers.OverflowDropOldest,
+
+			expect:        []string{"message 0", "message 3", "message 4"},
+			expectDropped: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			inner := &fakeLogger{gate: make(chan struct{}), calls: make(chan struct{}, 5)}
+			logger := loggers.NewAsync(inner, &loggers.AsyncConfig{QueueSize: 2, Overflow: testCase.overflow})
+
+			// The first message is picked by the writer, which then waits for the gate, while the queue fills up.
+			logger.Log(quicklog.LevelInfo, messages.NewBase("message 0", nil))
+			<-inner.calls
+
+			for i := 1; i < 5; i++ {
+				logger.Log(quicklog.LevelInfo, messages.NewBase(fmt.Sprintf("message %d", i), nil))
+			}
+
+			require.Equal(t, testCase.expectDropped, logger.Dropped())
+
+			close(inner.gate)
+			require.NoError(t, logger.Close(context.Background()))
+			require.Equal(t, testCase.expect, inner.messages())
+		})
+	}
+}
+
+func TestAsyncOverflowBlock(t *testing.T) {
+	inner := &fakeLogger{gate: make(chan struct{})}
+	logger := loggers.NewAsync(inner, &loggers.AsyncConfig{QueueSize: 1, Overflow: loggers.OverflowBlock})
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("message 0", nil))
+	logger.Log(quicklog.LevelInfo, messages.NewBase("message 1", nil))
+
+	logged := make(chan struct{})
+
+	go func() {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("message 2", nil))
+		close(logged)
+	}()
+
+	// The queue is full, and the writer is stuck on the first message.
+	select {
+	case <-logged:
+		require.Fail(t, "log should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(inner.gate)
+	<-logged
+
+	require.NoError(t, logger.Close(context.Background()))
+	require.Equal(t, []string{"message 0", "message 1", "message 2"}, inner.messages())
+	require.Equal(t, uint64(0), logger.Dropped())
+}
+
+func TestAsyncFlushTimeout(t *testing.T) {
+	inner := &fakeLogger{gate: make(chan struct{})}
+	logger := loggers.NewAsync(inner, &loggers.AsyncConfigDefault)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("message 0", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, logger.Flush(ctx), context.DeadlineExceeded)
+	require.ErrorIs(t, logger.Close(ctx), context.DeadlineExceeded)
+
+	close(inner.gate)
+	require.NoError(t, logger.Close(context.Background()))
+	require.Equal(t, []string{"message 0"}, inner.messages())
+}
+
+func TestAsyncFatal(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewAsync(loggers.NewTerminal(), &loggers.AsyncConfigDefault)
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("This is an info message.", nil))
+			logger.Log(quicklog.LevelError, messages.NewBase("This is an error message.", nil))
+			logger.Log(quicklog.LevelFatal, messages.NewBase("This is a fatal message.", nil))
+
+			// Unreachable code.
+			os.Exit(0)
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			require.Equal(
+				t,
+				"This is an info message.                                                        \n",
+				res.STDOut,
+			)
+			require.Equal(
+				t,
+				"This is an error message.                                                       \n"+
+					"This is a fatal message.                                                        \n",
+				res.STDErr,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
@@ -0,0 +1,105 @@
+package loggers
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Packages of this module, whose frames are skipped when looking for the caller of a logger.
+var quicklogPackages = []string{"github.com/a-novel-kit/quicklog.", "github.com/a-novel-kit/quicklog/loggers."}
+
+// callerFrame is the location of the code that logged a message.
+type callerFrame struct {
+	pc       uintptr
+	file     string
+	line     int
+	function string
+}
+
+// Short location of the caller, made of the base name of its file and its line.
+func (caller *callerFrame) short() string {
+	return filepath.Base(caller.file) + ":" + strconv.Itoa(caller.line)
+}
+
+// Find the first caller outside of this module and of the runtime. Walking the stack, rather than skipping a fixed
+// number of frames, keeps the caller correct when loggers wrap each other.
+//
+// Goroutines started by this module, such as background writers, have no caller: nil is returned.
+func findCaller() *callerFrame {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+
+		internal := strings.HasPrefix(frame.Function, "runtime.") || lo.SomeBy(quicklogPackages, func(pkg string) bool {
+			return strings.HasPrefix(frame.Function, pkg)
+		})
+
+		if !internal && frame.File != "" {
+			return &callerFrame{pc: frame.PC, file: frame.File, line: frame.Line, function: frame.Function}
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+// callerMessage carries the caller of a message, found when the message was logged. Loggers that write messages from
+// a background goroutine wrap them, since the stack of that goroutine does not lead to the code that logged them.
+type callerMessage struct {
+	caller *callerFrame
+
+	quicklog.Message
+}
+
+func (message *callerMessage) loggedFrom() *callerFrame {
+	return message.caller
+}
+
+func (message *callerMessage) RenderTerminalWidth(width int) string {
+	return quicklog.RenderTerminalWidth(message.Message, width)
+}
+
+func (message *callerMessage) RenderMarkdown() string {
+	return quicklog.RenderMarkdown(message.Message)
+}
+
+func (message *callerMessage) RenderHTML() string {
+	return quicklog.RenderHTML(message.Message)
+}
+
+func (message *callerMessage) SectionTitle() string {
+	if sectionMessage, ok := message.Message.(quicklog.SectionMessage); ok {
+		return sectionMessage.SectionTitle()
+	}
+
+	return ""
+}
+
+// Wrap a message with its caller, so it can be written from another goroutine.
+func withCaller(message quicklog.Message, caller *callerFrame) quicklog.Message {
+	if caller == nil {
+		return message
+	}
+
+	return &callerMessage{caller: caller, Message: message}
+}
+
+// Find the caller of a message: the one it carries, or the first caller outside of this module.
+func findMessageCaller(message quicklog.Message) *callerFrame {
+	if carrier, ok := message.(interface{ loggedFrom() *callerFrame }); ok {
+		if caller := carrier.loggedFrom(); caller != nil {
+			return caller
+		}
+	}
+
+	return findCaller()
+}
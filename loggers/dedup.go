@@ -0,0 +1,119 @@
+package loggers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-isatty"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// DedupMode determines how the terminal logger collapses consecutive identical messages.
+type DedupMode string
+
+const (
+	// DedupOff prints every message.
+	DedupOff DedupMode = ""
+	// DedupAuto uses DedupLive when the output is an interactive terminal, and DedupSummary otherwise.
+	DedupAuto DedupMode = "auto"
+	// DedupLive prints repeated messages once, with a counter updated in place: "(×37)". Under GitHub Actions and
+	// GitLab CI, whose logs are not updated in place, DedupSummary is used instead.
+	DedupLive DedupMode = "live"
+	// DedupSummary prints repeated messages once, then a summary of the repetitions when a different message is
+	// logged, or when the logger is flushed. It suits outputs that do not support updates in place, such as CI logs.
+	DedupSummary DedupMode = "summary"
+)
+
+var dedupCounterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+
+// Updates in place require both outputs to be interactive terminals, since messages are printed to either depending
+// on their level.
+func isInteractiveTerminal() bool {
+	return lo.EveryBy([]*os.File{os.Stdout, os.Stderr}, func(file *os.File) bool {
+		return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+	})
+}
+
+// Resolve the mode of a terminal logger.
+func resolveDedupMode(mode DedupMode, ci bool, provider ciProvider) DedupMode {
+	// Messages may be printed between folding or annotation lines, that updates in place would not erase.
+	if mode == DedupLive && provider != ciProviderNone {
+		return DedupSummary
+	}
+
+	if mode != DedupAuto {
+		return mode
+	}
+
+	return lo.Ternary(!ci && isInteractiveTerminal(), DedupLive, DedupSummary)
+}
+
+// Print the repeat counter at the end of the last line of a rendering, in place of its padding. If the line has no
+// room left within width, the counter is printed on a new line, so the rendering does not wrap.
+func withRepeatCounter(rendered string, count int, width int) string {
+	counter := dedupCounterStyle.Render(fmt.Sprintf("(×%d)", count))
+
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	last := lines[len(lines)-1]
+
+	space := width - ansi.StringWidth(counter) - 1
+	if ansi.StringWidth(strings.TrimRight(ansi.Strip(last), " ")) > space {
+		lines = append(lines, counter)
+	} else {
+		// Truncating removes the padding, and keeps the styles of the text.
+		last = ansi.Truncate(last, space, "")
+		lines[len(lines)-1] = last + strings.Repeat(" ", space-ansi.StringWidth(last)) + " " + counter
+	}
+
+	return strings.Join(lines, "\n") + lo.Ternary(strings.HasSuffix(rendered, "\n"), "\n", "")
+}
+
+// Render the summary of the repetitions of the previous message.
+func renderRepeatSummary(count int, width int) string {
+	return dedupCounterStyle.Width(width).Render(
+		fmt.Sprintf("(×%d) Previous message repeated %d more %s.", count, count-1, lo.Ternary(count == 2, "time", "times")),
+	) + "\n"
+}
+
+// dedupState tracks the last message printed by the terminal logger, to detect repetitions.
+type dedupState struct {
+	mode DedupMode
+
+	level    quicklog.Level
+	rendered string
+	count    int
+	// Last occurrence of the message, whose caller is printed with the summary of its repetitions.
+	message quicklog.Message
+	// Number of lines printed for the last message, erased to update its counter.
+	lines int
+
+	mu sync.Mutex
+}
+
+// Whether a message repeats the last one.
+func (state *dedupState) repeats(level quicklog.Level, rendered string) bool {
+	return state.count > 0 && state.level == level && state.rendered == rendered
+}
+
+// Track a new message, printed with the given output.
+func (state *dedupState) track(level quicklog.Level, message quicklog.Message, rendered, output string) {
+	state.level, state.message, state.rendered, state.count = level, message, rendered, 1
+	state.printed(output)
+}
+
+// Remember the number of lines printed for the last message.
+func (state *dedupState) printed(output string) {
+	state.lines = strings.Count(terminalLine(output), "\n")
+}
+
+// Erase the lines of the last message, so it can be printed again.
+func (state *dedupState) erase() string {
+	return strings.Repeat(messages.EraseLineSequence, state.lines)
+}
@@ -0,0 +1,67 @@
+package loggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+)
+
+// Render the terminal output of a message as plain text, for destinations that do not support styles.
+func renderPlainText(rendered string) string {
+	lines := strings.Split(ansi.Strip(rendered), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func flattenJSONValue(prefix string, value interface{}, fields map[string]string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			flattenJSONValue(lo.Ternary(prefix == "", key, prefix+"."+key), child, fields)
+		}
+	case []interface{}:
+		for i, child := range typed {
+			flattenJSONValue(prefix+"."+strconv.Itoa(i), child, fields)
+		}
+	case nil:
+	default:
+		fields[prefix] = fmt.Sprint(typed)
+	}
+}
+
+// Flatten the JSON output of a message into a list of text fields, for destinations that only support flat
+// key-value pairs. Nested keys and array indexes are joined with dots (data.0.message). Null values are omitted.
+func flattenJSON(rendered map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+
+	if len(rendered) == 0 {
+		return fields
+	}
+
+	// Go through a JSON encoding, so any type of value is flattened the same way as in JSON logs.
+	encoded, err := json.Marshal(rendered)
+	if err != nil {
+		return fields
+	}
+
+	var normalized map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&normalized); err != nil {
+		return fields
+	}
+
+	flattenJSONValue("", normalized, fields)
+
+	return fields
+}
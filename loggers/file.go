@@ -0,0 +1,237 @@
+package loggers
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// FileFormat is the rendering of messages written to a log file.
+type FileFormat string
+
+const (
+	// FileFormatJSON writes one JSON object per message, as the zerolog logger does.
+	FileFormatJSON FileFormat = "json"
+	// FileFormatPlain writes the terminal rendering of messages, without styles. Each line is prefixed with the
+	// time and level of the message.
+	FileFormatPlain FileFormat = "plain"
+)
+
+// FileLogger is a Logger that writes to a file, rotated as it grows.
+type FileLogger interface {
+	quicklog.Logger
+
+	// Rotate moves the current file to a backup, and starts a new one.
+	Rotate() error
+	// Reopen closes the file, and opens it again at the same path. External tools such as logrotate move the file
+	// before asking the program to reopen it. This is also triggered by the SIGHUP signal.
+	Reopen() error
+	// Close stops the logger, and closes the file. Messages logged after Close are ignored.
+	Close() error
+}
+
+type fileLogger struct {
+	writer *rotatingFile
+	format FileFormat
+
+	json zerolog.Logger
+
+	animation animationState
+
+	signals chan os.Signal
+	// Closed once the logger stops listening to signals.
+	done      chan struct{}
+	closeOnce sync.Once
+	closed    atomic.Bool
+
+	quicklog.Logger
+}
+
+// Prefix each line of a terminal rendering with the time and level of the message, and remove its styles.
+func renderPlainLines(level quicklog.Level, rendered string) string {
+	// Levels are padded to the longest one, so messages are aligned.
+	prefix := fmt.Sprintf("%s %-*s ", time.Now().Format(time.RFC3339), len(quicklog.LevelWarning), level)
+
+	var output string
+
+	for _, line := range strings.Split(strings.TrimSuffix(ansi.Strip(rendered), "\n"), "\n") {
+		output += strings.TrimRight(prefix+line, " ") + "\n"
+	}
+
+	return output
+}
+
+func (logger *fileLogger) write(level quicklog.Level, message quicklog.Message) {
+	if logger.closed.Load() {
+		return
+	}
+
+	if logger.format == FileFormatPlain {
+		if rendered := message.RenderTerminal(); rendered != "" {
+			_, _ = logger.writer.Write([]byte(renderPlainLines(level, rendered)))
+		}
+
+		return
+	}
+
+	if rendered := message.RenderJSON(); rendered != nil {
+		zerologEvent(logger.json, level).Fields(rendered).Msg("")
+	}
+}
+
+func (logger *fileLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so its final state is logged.
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
+
+	logger.write(level, message)
+
+	if level == quicklog.LevelFatal {
+		_ = logger.Close()
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
+}
+
+func (logger *fileLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
+
+	// Only the meaningful transitions of the message are logged, as in CI environments.
+	if logger.format == FileFormatPlain {
+		output := message.RunTerminal(true)
+
+		return logger.animation.start(message, func() {
+			for frame := range output {
+				if logger.closed.Load() || strings.TrimSpace(ansi.Strip(frame)) == "" {
+					continue
+				}
+
+				_, _ = logger.writer.Write([]byte(renderPlainLines(quicklog.LevelInfo, frame)))
+			}
+		})
+	}
+
+	output := message.RunJSON()
+
+	return logger.animation.start(message, func() {
+		for frame := range output {
+			if frame == nil {
+				continue
+			}
+
+			logger.json.Info().Fields(frame).Msg("")
+		}
+	})
+}
+
+func (logger *fileLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *fileLogger) Rotate() error {
+	return logger.writer.Rotate()
+}
+
+func (logger *fileLogger) Reopen() error {
+	return logger.writer.Reopen()
+}
+
+func (logger *fileLogger) Close() error {
+	logger.animation.interrupt(nil)
+
+	var err error
+
+	logger.closeOnce.Do(func() {
+		logger.closed.Store(true)
+		signal.Stop(logger.signals)
+		close(logger.done)
+
+		err = logger.writer.Close()
+	})
+
+	return err
+}
+
+// Reopen the file each time the program receives SIGHUP, until the logger is closed.
+func (logger *fileLogger) listenSignals() {
+	for {
+		select {
+		case <-logger.done:
+			return
+		case <-logger.signals:
+			_ = logger.writer.Reopen()
+		}
+	}
+}
+
+type FileConfig struct {
+	// Format of the messages in the file. Defaults to FileFormatJSON.
+	Format FileFormat
+	// MaxSize is the size, in bytes, a file may reach before it is rotated. Zero disables rotation by size.
+	MaxSize int64
+	// MaxAge is the time after which a file is rotated, counted from the moment it was opened. Zero disables
+	// rotation by age.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files to keep. Older backups are removed. Zero keeps every backup.
+	MaxBackups int
+	// Compress rotated files with gzip.
+	Compress bool
+	// FileMode is the permission of the created files. Defaults to 0640.
+	FileMode os.FileMode
+}
+
+var FileConfigDefault = FileConfig{
+	Format:   FileFormatJSON,
+	FileMode: 0o640,
+}
+
+// NewFile creates a new Logger that writes to the file at path, created if needed. Writes are safe for concurrent
+// use.
+//
+// Once the file exceeds the configured size or age, it is moved to a backup named after the time of the rotation
+// (app-2006-01-02T15-04-05.000000000.log for app.log), and a new file is started. The file is also reopened when
+// the program receives SIGHUP, for use with external rotation tools.
+func NewFile(path string, config *FileConfig) (FileLogger, error) {
+	writer, err := newRotatingFile(path, FileConfig{
+		Format:     lo.CoalesceOrEmpty(config.Format, FileConfigDefault.Format),
+		MaxSize:    config.MaxSize,
+		MaxAge:     config.MaxAge,
+		MaxBackups: config.MaxBackups,
+		Compress:   config.Compress,
+		FileMode:   lo.CoalesceOrEmpty(config.FileMode, FileConfigDefault.FileMode),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger := &fileLogger{
+		writer:  writer,
+		format:  writer.config.Format,
+		json:    zerolog.New(writer).With().Timestamp().Logger(),
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+
+	signal.Notify(logger.signals, syscall.SIGHUP)
+
+	go logger.listenSignals()
+
+	return logger, nil
+}
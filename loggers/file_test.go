@@ -0,0 +1,334 @@
+package loggers_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Read the JSON lines of a log file.
+func readJSONLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	var lines []map[string]interface{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// List the backups of a log file, in the order they were created.
+func listBackups(t *testing.T, dir string) []string {
+	t.Helper()
+
+	matches, err := filepath.Glob(filepath.Join(dir, "app-*"))
+	require.NoError(t, err)
+
+	return matches
+}
+
+func TestFileJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfigDefault)
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Starting"))
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Disk is almost full"))
+	logger.Log(quicklog.LevelError, messages.NewError(nil, "Deploy failed"))
+	// Ignore empty renders.
+	logger.Log(quicklog.LevelInfo, messages.NewBase(""))
+
+	require.NoError(t, logger.Close())
+
+	// Messages logged after Close are ignored.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Closed"))
+
+	lines := readJSONLines(t, path)
+	require.Len(t, lines, 3)
+
+	for i, expect := range []map[string]interface{}{
+		{"level": "info", "kind": "base", "schema_version": float64(1), "message": "Starting"},
+		{"level": "warn", "kind": "base", "schema_version": float64(1), "message": "Disk is almost full"},
+		{"level": "error", "kind": "error", "schema_version": float64(1), "message": "Deploy failed"},
+	} {
+		require.NotEmpty(t, lines[i]["time"])
+		delete(lines[i], "time")
+		require.Equal(t, expect, lines[i])
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+}
+
+func TestFilePlain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfig{Format: loggers.FileFormatPlain})
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewTitle("Deploy", "to production"))
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Disk is almost full"))
+
+	require.NoError(t, logger.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	timestamp := `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})`
+	require.Regexp(t, regexp.MustCompile(
+		`^`+timestamp+` INFO    ╭─+╮\n`+
+			timestamp+` INFO    │ Deploy +│\n`+
+			timestamp+` INFO    │ to production +│\n`+
+			timestamp+` INFO    ╰─+╯\n`+
+			timestamp+` WARNING Disk is almost full\n$`,
+	), string(content))
+}
+
+func TestFileAnimated(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		logger, err := loggers.NewFile(path, &loggers.FileConfigDefault)
+		require.NoError(t, err)
+
+		logChan := make(chan map[string]interface{})
+		animated := &fakeAnimated{outJSON: logChan}
+
+		cleaner := logger.LogAnimated(animated)
+
+		logChan <- messages.NewBase("This is an animated message.").RenderJSON()
+		// Ignore empty renders.
+		logChan <- nil
+		logChan <- messages.NewBase("This is another animated message.").RenderJSON()
+
+		cleaner()
+		require.NoError(t, logger.Close())
+
+		lines := readJSONLines(t, path)
+		require.Len(t, lines, 2)
+		require.Equal(t, "This is an animated message.", lines[0]["message"])
+		require.Equal(t, "This is another animated message.", lines[1]["message"])
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		logger, err := loggers.NewFile(path, &loggers.FileConfig{Format: loggers.FileFormatPlain})
+		require.NoError(t, err)
+
+		loader := messages.NewLoader("Building", &messages.LoaderConfigDefault)
+		cleaner := logger.LogAnimated(loader)
+		// Let the spinner run: intermediate frames must not be logged.
+		time.Sleep(200 * time.Millisecond)
+		loader.Error(errors.New("out of memory"))
+		cleaner()
+
+		require.NoError(t, logger.Close())
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		require.Len(t, lines, 2, string(content))
+		require.Contains(t, lines[0], " INFO    ▱▱▱ Building")
+		require.Contains(t, lines[1], " INFO    ✗ out of memory")
+	})
+}
+
+func TestFileRotateSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfig{MaxSize: 320, MaxBackups: 2})
+	require.NoError(t, err)
+
+	// Each line is about 145 bytes long: the file holds 2 lines before it is rotated.
+	for range 10 {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("This is a log message, long enough to fill the file."))
+	}
+
+	require.NoError(t, logger.Close())
+
+	backups := listBackups(t, dir)
+	require.Len(t, backups, 2)
+
+	for _, file := range append(backups, path) {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(320))
+		require.Len(t, readJSONLines(t, file), 2)
+	}
+}
+
+func TestFileRotateAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfig{MaxAge: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("First"))
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Second"))
+	time.Sleep(100 * time.Millisecond)
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Third"))
+
+	require.NoError(t, logger.Close())
+
+	backups := listBackups(t, dir)
+	require.Len(t, backups, 1)
+	require.Len(t, readJSONLines(t, backups[0]), 2)
+	require.Equal(t, "Third", readJSONLines(t, path)[0]["message"])
+}
+
+func TestFileRotateCompress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfig{Compress: true, MaxBackups: 2})
+	require.NoError(t, err)
+
+	for _, message := range []string{"First", "Second", "Third", "Fourth"} {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(message))
+		require.NoError(t, logger.Rotate())
+	}
+
+	// Rotating an empty file does nothing.
+	require.NoError(t, logger.Rotate())
+	require.NoError(t, logger.Close())
+
+	backups := listBackups(t, dir)
+	require.Len(t, backups, 2)
+
+	for i, expect := range []string{"Third", "Fourth"} {
+		require.True(t, strings.HasSuffix(backups[i], ".log.gz"), backups[i])
+
+		file, err := os.Open(backups[i])
+		require.NoError(t, err)
+
+		reader, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &line))
+		require.Equal(t, expect, line["message"])
+	}
+}
+
+func TestFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfigDefault)
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Before"))
+
+	// Simulate an external rotation.
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Moved"))
+
+	require.NoError(t, logger.Reopen())
+	logger.Log(quicklog.LevelInfo, messages.NewBase("After"))
+
+	require.NoError(t, logger.Close())
+
+	moved := readJSONLines(t, filepath.Join(dir, "app.log.1"))
+	require.Len(t, moved, 2)
+	require.Equal(t, "Moved", moved[1]["message"])
+
+	current := readJSONLines(t, path)
+	require.Len(t, current, 1)
+	require.Equal(t, "After", current[0]["message"])
+}
+
+func TestFileConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfig{MaxSize: 1000})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 10 {
+				logger.Log(quicklog.LevelInfo, messages.NewBase("Concurrent message"))
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.NoError(t, logger.Close())
+
+	// Lines are never interleaved, nor split across files.
+	var total int
+	for _, file := range append(listBackups(t, dir), path) {
+		total += len(readJSONLines(t, file))
+	}
+
+	require.Equal(t, 200, total)
+}
+
+func TestFileFatal(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "quicklog-file-fatal.log")
+
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			_ = os.Remove(path)
+
+			logger, err := loggers.NewFile(path, &loggers.FileConfigDefault)
+			require.NoError(t, err)
+
+			logger.Log(quicklog.LevelFatal, messages.NewBase("Crashed"))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+
+			lines := readJSONLines(t, path)
+			require.Len(t, lines, 1)
+			require.Equal(t, "fatal", lines[0]["level"])
+			require.Equal(t, "Crashed", lines[0]["message"])
+
+			require.NoError(t, os.Remove(path))
+		},
+	})
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package loggers_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func TestFileReopenOnSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := loggers.NewFile(path, &loggers.FileConfigDefault)
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Before"))
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("After"))
+	require.NoError(t, logger.Close())
+
+	require.Equal(t, "After", readJSONLines(t, path)[0]["message"])
+}
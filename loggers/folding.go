@@ -0,0 +1,123 @@
+package loggers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+const (
+	// GitHubActionsEnv is set to "true" by GitHub Actions runners.
+	GitHubActionsEnv = "GITHUB_ACTIONS"
+	// GitLabCIEnv is set to "true" by GitLab CI runners.
+	GitLabCIEnv = "GITLAB_CI"
+)
+
+// ciProvider identifies the CI service running the program, for the features that are specific to its logs.
+type ciProvider string
+
+const (
+	ciProviderNone   ciProvider = ""
+	ciProviderGitHub ciProvider = "github"
+	ciProviderGitLab ciProvider = "gitlab"
+)
+
+func detectCIProvider() ciProvider {
+	switch {
+	case os.Getenv(GitHubActionsEnv) == "true":
+		return ciProviderGitHub
+	case os.Getenv(GitLabCIEnv) == "true":
+		return ciProviderGitLab
+	default:
+		return ciProviderNone
+	}
+}
+
+// GitLab requires each section to have a unique name.
+var ciSectionCounter atomic.Uint64
+
+// ciSection is a foldable section of the CI logs.
+type ciSection struct {
+	provider ciProvider
+	name     string
+}
+
+// Escape the data of a GitHub workflow command, so it fits on a single line.
+// https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts
+var githubCommandEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// Open a section titled after the message, if the message introduces one. It returns nil if the provider does not
+// support folding, or the message is not a section.
+func (provider ciProvider) openSection(message interface{}) (*ciSection, string) {
+	sectionMessage, ok := message.(quicklog.SectionMessage)
+	if !ok || provider == ciProviderNone {
+		return nil, ""
+	}
+
+	title := strings.TrimSpace(sectionMessage.SectionTitle())
+	if title == "" {
+		return nil, ""
+	}
+
+	section := &ciSection{
+		provider: provider,
+		name:     fmt.Sprintf("quicklog_section_%d", ciSectionCounter.Add(1)),
+	}
+
+	// Section titles must fit on a single line.
+	title, _, _ = strings.Cut(title, "\n")
+
+	if provider == ciProviderGitLab {
+		return section, fmt.Sprintf(
+			"\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s", time.Now().Unix(), section.name, title,
+		)
+	}
+
+	return section, "::group::" + githubCommandEscaper.Replace(title)
+}
+
+// Return the marker that closes the section.
+func (section *ciSection) close() string {
+	if section.provider == ciProviderGitLab {
+		return fmt.Sprintf("\x1b[0Ksection_end:%d:%s\r\x1b[0K", time.Now().Unix(), section.name)
+	}
+
+	return "::endgroup::"
+}
+
+// Return the annotation that surfaces a message in the summary of the job, for levels that support it. Only GitHub
+// Actions supports annotations.
+func (provider ciProvider) annotation(level quicklog.Level, rendered string) string {
+	if provider != ciProviderGitHub {
+		return ""
+	}
+
+	var command string
+
+	switch level {
+	case quicklog.LevelWarning:
+		command = "warning"
+	case quicklog.LevelError, quicklog.LevelFatal:
+		command = "error"
+	default:
+		return ""
+	}
+
+	lines := strings.Split(ansi.Strip(rendered), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+	if text == "" {
+		return ""
+	}
+
+	return "::" + command + "::" + githubCommandEscaper.Replace(text)
+}
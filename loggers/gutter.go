@@ -0,0 +1,215 @@
+package loggers
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// TimestampFormat is the format of the timestamp printed in the gutter of the terminal logger.
+type TimestampFormat string
+
+const (
+	// TimestampNone disables timestamps.
+	TimestampNone TimestampFormat = ""
+	// TimestampRelative prints the time elapsed since the logger was created, in seconds.
+	TimestampRelative TimestampFormat = "relative"
+	// TimestampWallClock prints the local time of the day, with milliseconds.
+	TimestampWallClock TimestampFormat = "wall_clock"
+	// TimestampRFC3339 prints the full local date and time, in RFC 3339 format.
+	TimestampRFC3339 TimestampFormat = "rfc3339"
+)
+
+const (
+	// Width of each prefix in the gutter. Values are padded or truncated, so the gutter has the same width for every
+	// message.
+	gutterRelativeWidth = 10
+	gutterRFC3339Width  = len("2006-01-02T15:04:05-07:00")
+	gutterLevelWidth    = 7
+	gutterCallerWidth   = 20
+	gutterJobWidth      = 12
+)
+
+const gutterWallClockLayout = "15:04:05.000"
+
+var (
+	gutterTimestampStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+	gutterCallerStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Faint(true)
+	gutterJobStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("13"))
+
+	gutterLevelStyle = lipgloss.NewStyle().Width(gutterLevelWidth).Align(lipgloss.Center).Bold(true)
+
+	gutterLevels = map[quicklog.Level]struct {
+		label string
+		style lipgloss.Style
+	}{
+		quicklog.LevelInfo: {
+			label: "INFO",
+			style: gutterLevelStyle.Background(lipgloss.Color("33")).Foreground(lipgloss.Color("15")),
+		},
+		quicklog.LevelWarning: {
+			label: "WARN",
+			style: gutterLevelStyle.Background(lipgloss.Color("11")).Foreground(lipgloss.Color("0")),
+		},
+		quicklog.LevelError: {
+			label: "ERROR",
+			style: gutterLevelStyle.Background(lipgloss.Color("9")).Foreground(lipgloss.Color("15")),
+		},
+		quicklog.LevelFatal: {
+			label: "FATAL",
+			style: gutterLevelStyle.Background(lipgloss.Color("13")).Foreground(lipgloss.Color("15")),
+		},
+	}
+)
+
+// GoroutineJob names the job logging a message after its goroutine, such as "g42". It can be used as the Job of a
+// TerminalConfig.
+func GoroutineJob() string {
+	buffer := make([]byte, 64)
+	// The trace starts with "goroutine 42 [running]:".
+	fields := strings.Fields(string(buffer[:runtime.Stack(buffer, false)]))
+
+	if len(fields) < 2 {
+		return ""
+	}
+
+	return "g" + fields[1]
+}
+
+// Pad a value to the given width, or truncate it with an ellipsis. Callers are truncated on the left, so the file
+// name and line remain visible.
+func fitGutterValue(value string, width int, truncateLeft bool) string {
+	runes := []rune(value)
+
+	if len(runes) > width {
+		if truncateLeft {
+			runes = append([]rune("…"), runes[len(runes)-width+1:]...)
+		} else {
+			runes = append(runes[:width-1], []rune("…")...)
+		}
+	}
+
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
+
+// gutter renders the prefixes of the terminal logger. Prefixes are printed in a left gutter of constant width, so
+// the lines of messages stay aligned.
+type gutter struct {
+	config TerminalConfig
+	start  time.Time
+}
+
+func newGutter(config TerminalConfig) *gutter {
+	if config.Timestamp == TimestampNone && !config.Level && !config.Caller && config.Job == nil {
+		return nil
+	}
+
+	return &gutter{config: config, start: time.Now()}
+}
+
+// Width of the gutter, including the space that separates it from messages.
+func (gutter *gutter) width() int {
+	widths := make([]int, 0, 4)
+
+	switch gutter.config.Timestamp {
+	case TimestampRelative:
+		widths = append(widths, gutterRelativeWidth)
+	case TimestampWallClock:
+		widths = append(widths, len(gutterWallClockLayout))
+	case TimestampRFC3339:
+		widths = append(widths, gutterRFC3339Width)
+	case TimestampNone:
+	}
+
+	if gutter.config.Level {
+		widths = append(widths, gutterLevelWidth)
+	}
+
+	if gutter.config.Caller {
+		widths = append(widths, gutterCallerWidth)
+	}
+
+	if gutter.config.Job != nil {
+		widths = append(widths, gutterJobWidth)
+	}
+
+	output := 0
+	for _, width := range widths {
+		output += width + 1
+	}
+
+	return output
+}
+
+// Render the prefixes of a message, followed by the space that separates them from the message.
+func (gutter *gutter) prefix(level quicklog.Level, now time.Time, caller *callerFrame, job string) string {
+	prefixes := make([]string, 0, 4)
+
+	switch gutter.config.Timestamp {
+	case TimestampRelative:
+		elapsed := fmt.Sprintf("%*.3fs", gutterRelativeWidth-1, now.Sub(gutter.start).Seconds())
+		prefixes = append(prefixes, gutterTimestampStyle.Render(elapsed))
+	case TimestampWallClock:
+		prefixes = append(prefixes, gutterTimestampStyle.Render(now.Format(gutterWallClockLayout)))
+	case TimestampRFC3339:
+		timestamp := fitGutterValue(now.Format(time.RFC3339), gutterRFC3339Width, false)
+		prefixes = append(prefixes, gutterTimestampStyle.Render(timestamp))
+	case TimestampNone:
+	}
+
+	if gutter.config.Level {
+		badge, ok := gutterLevels[level]
+		if !ok {
+			badge = gutterLevels[quicklog.LevelInfo]
+		}
+
+		prefixes = append(prefixes, badge.style.Render(badge.label))
+	}
+
+	if gutter.config.Caller {
+		location := ""
+		if caller != nil {
+			location = caller.short()
+		}
+
+		prefixes = append(prefixes, gutterCallerStyle.Render(fitGutterValue(location, gutterCallerWidth, true)))
+	}
+
+	if gutter.config.Job != nil {
+		prefixes = append(prefixes, gutterJobStyle.Render(fitGutterValue(job, gutterJobWidth, false)))
+	}
+
+	return strings.Join(prefixes, " ") + " "
+}
+
+// Print the prefix before the first line of a rendering, and indent the next lines by the width of the gutter.
+//
+// Frames of animated messages start by erasing the previous frame: the erase sequences are kept before the gutter.
+func (gutter *gutter) apply(prefix, rendered string) string {
+	var erase string
+	for strings.HasPrefix(rendered, messages.EraseLineSequence) {
+		erase += messages.EraseLineSequence
+		rendered = strings.TrimPrefix(rendered, messages.EraseLineSequence)
+	}
+
+	indent := strings.Repeat(" ", gutter.width())
+
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines {
+		// Do not indent the empty line after the last line break.
+		if line == "" && i == len(lines)-1 && i > 0 {
+			continue
+		}
+
+		lines[i] = lo.Ternary(i == 0, prefix, indent) + line
+	}
+
+	return erase + strings.Join(lines, "\n")
+}
@@ -0,0 +1,542 @@
+package loggers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// HTTPFormat is the encoding of the batches sent to the server.
+type HTTPFormat string
+
+const (
+	// HTTPFormatNDJSON sends one JSON object per line (application/x-ndjson).
+	HTTPFormatNDJSON HTTPFormat = "ndjson"
+	// HTTPFormatJSON sends a JSON array of objects (application/json).
+	HTTPFormatJSON HTTPFormat = "json"
+)
+
+// Time given to pending messages to be sent, before a fatal message exits the program.
+const httpFatalFlushTimeout = 5 * time.Second
+
+// HTTPLogger is a Logger that sends messages to an HTTP endpoint, in batches.
+type HTTPLogger interface {
+	quicklog.Logger
+
+	// Flush sends every message logged before the call, and blocks until they are sent, spooled or dropped, or the
+	// context is done.
+	Flush(ctx context.Context) error
+	// Close flushes the pending messages, and stops the background sender. If the context is done first, the
+	// remaining messages are spooled, if enabled. Messages logged after Close are ignored.
+	Close(ctx context.Context) error
+	// Dropped returns the number of messages that were discarded, because the queue was full, or the server could
+	// not receive them.
+	Dropped() uint64
+}
+
+// Error returned by the server, that sending the batch again will not fix.
+var errHTTPRejected = errors.New("batch rejected by the server")
+
+type httpLogger struct {
+	endpoint string
+	config   HTTPConfig
+
+	json      zerolog.Logger
+	animation animationState
+
+	queue   [][]byte
+	dropped uint64
+	closed  bool
+	mu      sync.Mutex
+
+	// Wake the sender when a full batch is available.
+	wake chan struct{}
+	// Requests from Flush, answered once the queue has been processed.
+	flushes chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+
+	// Cancelled when Close gives up on sending: pending requests are cancelled, and remaining batches are spooled.
+	abortCtx context.Context
+	abort    context.CancelFunc
+
+	spoolSeq uint64
+	// Whether the spool directory may hold batches. The directory is only read when it is set, so batches sent
+	// while the server is available do not scan it.
+	spooled bool
+}
+
+// ==============================================================================================================
+// Encoding.
+// ==============================================================================================================
+
+// Encode lines of JSON into the body of a request.
+func (logger *httpLogger) encode(lines [][]byte) ([]byte, error) {
+	var body bytes.Buffer
+
+	var writer io.Writer = &body
+
+	var compressor *gzip.Writer
+	if logger.config.Compress {
+		compressor = gzip.NewWriter(&body)
+		writer = compressor
+	}
+
+	var err error
+
+	if logger.config.Format == HTTPFormatJSON {
+		trimmed := lo.Map(lines, func(line []byte, _ int) []byte {
+			return bytes.TrimSuffix(line, []byte("\n"))
+		})
+		_, err = writer.Write([]byte("[" + string(bytes.Join(trimmed, []byte(","))) + "]"))
+	} else {
+		_, err = writer.Write(bytes.Join(lines, nil))
+	}
+
+	if compressor != nil {
+		err = errors.Join(err, compressor.Close())
+	}
+
+	return body.Bytes(), err
+}
+
+// ==============================================================================================================
+// Sending.
+// ==============================================================================================================
+
+// Send a batch once.
+func (logger *httpLogger) post(lines [][]byte) error {
+	body, err := logger.encode(lines)
+	if err != nil {
+		return fmt.Errorf("%w: encode batch: %w", errHTTPRejected, err)
+	}
+
+	request, err := http.NewRequestWithContext(logger.abortCtx, http.MethodPost, logger.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: create request: %w", errHTTPRejected, err)
+	}
+
+	for key, values := range logger.config.Headers {
+		request.Header[key] = values
+	}
+
+	request.Header.Set("Content-Type", lo.Ternary(
+		logger.config.Format == HTTPFormatJSON, "application/json", "application/x-ndjson",
+	))
+
+	if logger.config.Compress {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+
+	response, err := logger.config.Client.Do(request)
+	if err != nil {
+		return fmt.Errorf("send batch: %w", err)
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	switch {
+	case response.StatusCode < 300:
+		return nil
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500:
+		return fmt.Errorf("send batch: %s", response.Status)
+	default:
+		return fmt.Errorf("%w: %s", errHTTPRejected, response.Status)
+	}
+}
+
+// Send a batch, and retry with an exponential backoff if the server is unavailable. Batches that cannot be sent are
+// spooled, if enabled, or dropped.
+func (logger *httpLogger) send(lines [][]byte) {
+	backoff := logger.config.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		if logger.abortCtx.Err() != nil {
+			logger.spool(lines)
+			return
+		}
+
+		err := logger.post(lines)
+		if err == nil {
+			// The server is back: send the batches that could not be sent before.
+			if logger.spooled {
+				logger.sendSpool()
+			}
+
+			return
+		}
+
+		if errors.Is(err, errHTTPRejected) {
+			logger.drop(len(lines))
+			return
+		}
+
+		if attempt >= logger.config.MaxRetries {
+			logger.spool(lines)
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-logger.abortCtx.Done():
+		}
+
+		backoff = min(backoff*2, logger.config.MaxRetryBackoff)
+	}
+}
+
+// Send the pending messages, in batches. Unless all is set, only full batches are sent.
+func (logger *httpLogger) sendPending(all bool) {
+	for {
+		logger.mu.Lock()
+
+		if len(logger.queue) == 0 || (!all && len(logger.queue) < logger.config.BatchSize) {
+			logger.mu.Unlock()
+			return
+		}
+
+		size := min(len(logger.queue), logger.config.BatchSize)
+		batch := logger.queue[:size]
+		logger.queue = logger.queue[size:]
+
+		logger.mu.Unlock()
+
+		logger.send(batch)
+	}
+}
+
+// Background sender. It runs until the logger is closed, and its queue is empty.
+func (logger *httpLogger) run() {
+	defer close(logger.done)
+
+	ticker := time.NewTicker(logger.config.FlushInterval)
+	defer ticker.Stop()
+
+	// Batches spooled by a previous run are sent first.
+	logger.spooled = true
+	logger.sendSpool()
+
+	for {
+		select {
+		case <-logger.wake:
+			logger.sendPending(false)
+		case <-ticker.C:
+			logger.sendPending(true)
+		case reply := <-logger.flushes:
+			logger.sendPending(true)
+			close(reply)
+		case <-logger.stop:
+			logger.sendPending(true)
+			return
+		}
+	}
+}
+
+func (logger *httpLogger) drop(count int) {
+	logger.mu.Lock()
+	logger.dropped += uint64(count)
+	logger.mu.Unlock()
+}
+
+// ==============================================================================================================
+// Spool.
+// ==============================================================================================================
+
+// Write a batch to the spool directory, so it can be sent once the server is available again. Without a spool
+// directory, the batch is dropped.
+func (logger *httpLogger) spool(lines [][]byte) {
+	if logger.config.SpoolDir == "" {
+		logger.drop(len(lines))
+		return
+	}
+
+	logger.spoolSeq++
+
+	path := filepath.Join(
+		logger.config.SpoolDir,
+		fmt.Sprintf("%s-%06d.ndjson", time.Now().Format(backupTimeLayout), logger.spoolSeq),
+	)
+
+	// Write to a temporary file first, so partial batches are never sent.
+	err := os.MkdirAll(logger.config.SpoolDir, 0o755)
+	if err == nil {
+		err = os.WriteFile(path+".tmp", bytes.Join(lines, nil), 0o600)
+	}
+
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+
+	if err != nil {
+		_ = os.Remove(path + ".tmp")
+		logger.drop(len(lines))
+
+		return
+	}
+
+	logger.spooled = true
+}
+
+// Send the spooled batches, from the oldest to the newest. It stops at the first batch that cannot be sent, so the
+// remaining batches are sent again after the next successful request.
+func (logger *httpLogger) sendSpool() {
+	if logger.config.SpoolDir == "" {
+		return
+	}
+
+	paths, err := filepath.Glob(filepath.Join(logger.config.SpoolDir, "*.ndjson"))
+	if err != nil {
+		return
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if logger.abortCtx.Err() != nil {
+			return
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		lines := lo.FilterMap(strings.SplitAfter(string(content), "\n"), func(line string, _ int) ([]byte, bool) {
+			return []byte(line), strings.TrimSpace(line) != ""
+		})
+
+		err = logger.post(lines)
+		if err != nil && !errors.Is(err, errHTTPRejected) {
+			return
+		}
+
+		if err != nil {
+			logger.drop(len(lines))
+		}
+
+		_ = os.Remove(path)
+	}
+
+	logger.spooled = false
+}
+
+// ==============================================================================================================
+// Logger.
+// ==============================================================================================================
+
+// Write queues a line of JSON, rendered by zerolog. It never blocks: if the queue is full, the oldest line is
+// dropped.
+func (logger *httpLogger) Write(line []byte) (int, error) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.closed {
+		return len(line), nil
+	}
+
+	if len(logger.queue) >= logger.config.QueueSize {
+		logger.queue = logger.queue[1:]
+		logger.dropped++
+	}
+
+	// Zerolog reuses its buffers.
+	logger.queue = append(logger.queue, bytes.Clone(line))
+
+	if len(logger.queue) >= logger.config.BatchSize {
+		select {
+		case logger.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return len(line), nil
+}
+
+func (logger *httpLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so its final state is logged.
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
+
+	if rendered := message.RenderJSON(); rendered != nil {
+		zerologEvent(logger.json, level).Fields(rendered).Msg("")
+	}
+
+	if level == quicklog.LevelFatal {
+		ctx, cancel := context.WithTimeout(context.Background(), httpFatalFlushTimeout)
+		_ = logger.Close(ctx)
+
+		cancel()
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
+}
+
+func (logger *httpLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
+
+	// The JSON output of animated messages only changes on meaningful transitions.
+	output := message.RunJSON()
+
+	return logger.animation.start(message, func() {
+		for frame := range output {
+			if frame == nil {
+				continue
+			}
+
+			logger.json.Info().Fields(frame).Msg("")
+		}
+	})
+}
+
+func (logger *httpLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *httpLogger) Flush(ctx context.Context) error {
+	reply := make(chan struct{})
+
+	select {
+	case logger.flushes <- reply:
+	case <-logger.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-reply:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (logger *httpLogger) Close(ctx context.Context) error {
+	logger.animation.interrupt(nil)
+
+	logger.mu.Lock()
+	if !logger.closed {
+		logger.closed = true
+		close(logger.stop)
+	}
+	logger.mu.Unlock()
+
+	// Release the context of the requests once the sender stops.
+	defer logger.abort()
+
+	select {
+	case <-logger.done:
+		return nil
+	case <-ctx.Done():
+		// Stop trying: the remaining batches are spooled.
+		logger.abort()
+		<-logger.done
+
+		return ctx.Err()
+	}
+}
+
+func (logger *httpLogger) Dropped() uint64 {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	return logger.dropped
+}
+
+type HTTPConfig struct {
+	// Client sends the requests. Defaults to a client with a 10 seconds timeout.
+	Client *http.Client
+	// Headers are added to each request, for example to authenticate with the server.
+	Headers http.Header
+	// Format of the batches. Defaults to HTTPFormatNDJSON.
+	Format HTTPFormat
+	// Compress the batches with gzip.
+	Compress bool
+
+	// BatchSize is the maximum number of messages per request. A batch is sent as soon as it is full.
+	BatchSize int
+	// FlushInterval is the maximum time a message waits before being sent, if its batch is not full.
+	FlushInterval time.Duration
+	// QueueSize is the maximum number of messages waiting to be sent. Once full, the oldest messages are dropped.
+	QueueSize int
+
+	// MaxRetries is the number of times a batch is sent again, when the server is unavailable (network errors, 429
+	// and 5xx responses). Other responses are not retried, and their batch is dropped. Zero disables retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry. It doubles with each attempt, up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	// SpoolDir, if set, stores the batches that could not be sent to disk. They are sent again once the server is
+	// available, including by later runs of the program. Without a spool, those batches are dropped.
+	SpoolDir string
+}
+
+var HTTPConfigDefault = HTTPConfig{
+	Format:          HTTPFormatNDJSON,
+	BatchSize:       100,
+	FlushInterval:   5 * time.Second,
+	QueueSize:       10000,
+	MaxRetries:      5,
+	RetryBackoff:    500 * time.Millisecond,
+	MaxRetryBackoff: 30 * time.Second,
+}
+
+// NewHTTP creates a new Logger that sends messages to an HTTP endpoint, with POST requests. Messages are rendered
+// as JSON, and sent in batches by a background goroutine, so logging never blocks.
+//
+// Close must be called before the program exits, so the pending messages are sent.
+func NewHTTP(endpoint string, config *HTTPConfig) HTTPLogger {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	logger := &httpLogger{
+		endpoint: endpoint,
+		config: HTTPConfig{
+			Client:          lo.Ternary(config.Client != nil, config.Client, &http.Client{Timeout: 10 * time.Second}),
+			Headers:         config.Headers,
+			Format:          lo.CoalesceOrEmpty(config.Format, HTTPConfigDefault.Format),
+			Compress:        config.Compress,
+			BatchSize:       lo.CoalesceOrEmpty(config.BatchSize, HTTPConfigDefault.BatchSize),
+			FlushInterval:   lo.CoalesceOrEmpty(config.FlushInterval, HTTPConfigDefault.FlushInterval),
+			QueueSize:       lo.CoalesceOrEmpty(config.QueueSize, HTTPConfigDefault.QueueSize),
+			MaxRetries:      config.MaxRetries,
+			RetryBackoff:    lo.CoalesceOrEmpty(config.RetryBackoff, HTTPConfigDefault.RetryBackoff),
+			MaxRetryBackoff: lo.CoalesceOrEmpty(config.MaxRetryBackoff, HTTPConfigDefault.MaxRetryBackoff),
+			SpoolDir:        config.SpoolDir,
+		},
+		wake:     make(chan struct{}, 1),
+		flushes:  make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		abortCtx: ctx,
+		abort:    cancel,
+	}
+
+	logger.json = zerolog.New(logger).With().Timestamp().Logger()
+
+	go logger.run()
+
+	return logger
+}
@@ -0,0 +1,452 @@
+package loggers_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+type httpTestRequest struct {
+	header http.Header
+	lines  []map[string]interface{}
+}
+
+// httpTestServer records the batches it receives. Each request is answered with the next status of the list, or
+// 200 once the list is exhausted.
+type httpTestServer struct {
+	statuses []int
+	requests []httpTestRequest
+	mu       sync.Mutex
+
+	*httptest.Server
+}
+
+func (server *httpTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var reader io.Reader = r.Body
+
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		reader = gzipReader
+	}
+
+	request := httpTestRequest{header: r.Header}
+
+	if r.Header.Get("Content-Type") == "application/json" {
+		if err := json.NewDecoder(reader).Decode(&request.lines); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	} else {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			request.lines = append(request.lines, line)
+		}
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	status := http.StatusOK
+	if len(server.statuses) > 0 {
+		status, server.statuses = server.statuses[0], server.statuses[1:]
+	}
+
+	if status == http.StatusOK {
+		server.requests = append(server.requests, request)
+	}
+
+	w.WriteHeader(status)
+}
+
+// Return the messages of each batch received.
+func (server *httpTestServer) batches() [][]string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	output := make([][]string, len(server.requests))
+
+	for i, request := range server.requests {
+		for _, line := range request.lines {
+			output[i] = append(output[i], line["message"].(string))
+		}
+	}
+
+	return output
+}
+
+func newHTTPTestServer(t *testing.T, statuses ...int) *httpTestServer {
+	t.Helper()
+
+	server := &httpTestServer{statuses: statuses}
+	server.Server = httptest.NewServer(server)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func closeHTTPLogger(t *testing.T, logger loggers.HTTPLogger) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, logger.Close(ctx))
+}
+
+func TestHTTPBatching(t *testing.T) {
+	server := newHTTPTestServer(t)
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		Headers:       http.Header{"Authorization": []string{"Bearer token"}},
+	})
+
+	for _, message := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(message))
+	}
+
+	// Ignore empty renders.
+	logger.Log(quicklog.LevelInfo, messages.NewBase(""))
+
+	// Full batches are sent right away.
+	require.Eventually(t, func() bool {
+		return len(server.batches()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The last batch is sent on close.
+	closeHTTPLogger(t, logger)
+
+	// Messages logged after Close are ignored.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("8"))
+
+	require.Equal(t, [][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7"}}, server.batches())
+	require.Zero(t, logger.Dropped())
+
+	request := server.requests[0]
+	require.Equal(t, "application/x-ndjson", request.header.Get("Content-Type"))
+	require.Equal(t, "Bearer token", request.header.Get("Authorization"))
+	require.Equal(t, "info", request.lines[0]["level"])
+	require.NotEmpty(t, request.lines[0]["time"])
+}
+
+func TestHTTPFlushInterval(t *testing.T) {
+	server := newHTTPTestServer(t)
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{FlushInterval: 20 * time.Millisecond})
+	defer closeHTTPLogger(t, logger)
+
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Hello world"))
+
+	require.Eventually(t, func() bool {
+		return len(server.batches()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, "warn", server.requests[0].lines[0]["level"])
+}
+
+func TestHTTPFlush(t *testing.T) {
+	server := newHTTPTestServer(t)
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{FlushInterval: time.Hour})
+	defer closeHTTPLogger(t, logger)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+	require.NoError(t, logger.Flush(context.Background()))
+	require.Equal(t, [][]string{{"Hello world"}}, server.batches())
+}
+
+func TestHTTPFormat(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		format   loggers.HTTPFormat
+		compress bool
+
+		expectContentType string
+	}{
+		{
+			name:              "NDJSON",
+			format:            loggers.HTTPFormatNDJSON,
+			expectContentType: "application/x-ndjson",
+		},
+		{
+			name:              "JSON",
+			format:            loggers.HTTPFormatJSON,
+			expectContentType: "application/json",
+		},
+		{
+			name:              "NDJSONCompressed",
+			format:            loggers.HTTPFormatNDJSON,
+			compress:          true,
+			expectContentType: "application/x-ndjson",
+		},
+		{
+			name:              "JSONCompressed",
+			format:            loggers.HTTPFormatJSON,
+			compress:          true,
+			expectContentType: "application/json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newHTTPTestServer(t)
+
+			logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+				Format:        testCase.format,
+				Compress:      testCase.compress,
+				FlushInterval: time.Hour,
+			})
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("First"))
+			logger.Log(quicklog.LevelError, messages.NewBase("Second"))
+			closeHTTPLogger(t, logger)
+
+			require.Equal(t, [][]string{{"First", "Second"}}, server.batches())
+			require.Equal(t, testCase.expectContentType, server.requests[0].header.Get("Content-Type"))
+
+			if testCase.compress {
+				require.Equal(t, "gzip", server.requests[0].header.Get("Content-Encoding"))
+			} else {
+				require.Empty(t, server.requests[0].header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
+
+func TestHTTPRetry(t *testing.T) {
+	t.Run("Unavailable", func(t *testing.T) {
+		server := newHTTPTestServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+		logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+			FlushInterval: time.Hour,
+			MaxRetries:    2,
+			RetryBackoff:  time.Millisecond,
+		})
+
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+		closeHTTPLogger(t, logger)
+
+		require.Equal(t, [][]string{{"Hello world"}}, server.batches())
+		require.Zero(t, logger.Dropped())
+	})
+
+	t.Run("TooManyFailures", func(t *testing.T) {
+		server := newHTTPTestServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+		logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+			FlushInterval: time.Hour,
+			MaxRetries:    2,
+			RetryBackoff:  time.Millisecond,
+		})
+
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+		closeHTTPLogger(t, logger)
+
+		require.Empty(t, server.batches())
+		require.Equal(t, uint64(1), logger.Dropped())
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		server := newHTTPTestServer(t, http.StatusBadRequest)
+
+		logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+			FlushInterval: time.Hour,
+			MaxRetries:    2,
+			RetryBackoff:  time.Millisecond,
+		})
+
+		logger.Log(quicklog.LevelInfo, messages.NewBase("First"))
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Second"))
+		closeHTTPLogger(t, logger)
+
+		// Rejected batches are not sent again.
+		require.Empty(t, server.batches())
+		require.Equal(t, uint64(2), logger.Dropped())
+	})
+}
+
+func TestHTTPSpool(t *testing.T) {
+	spoolDir := filepath.Join(t.TempDir(), "spool")
+
+	// The server is offline.
+	offline := httptest.NewServer(http.NotFoundHandler())
+	offline.Close()
+
+	logger := loggers.NewHTTP(offline.URL, &loggers.HTTPConfig{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		RetryBackoff:  time.Millisecond,
+		MaxRetries:    1,
+		SpoolDir:      spoolDir,
+	})
+
+	for _, message := range []string{"1", "2", "3"} {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(message))
+	}
+
+	closeHTTPLogger(t, logger)
+	require.Zero(t, logger.Dropped())
+
+	spooled, err := os.ReadDir(spoolDir)
+	require.NoError(t, err)
+	require.Len(t, spooled, 2)
+
+	// The next run sends the spooled batches first.
+	server := newHTTPTestServer(t)
+
+	logger = loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+		FlushInterval: time.Hour,
+		SpoolDir:      spoolDir,
+	})
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("4"))
+	closeHTTPLogger(t, logger)
+
+	require.Equal(t, [][]string{{"1", "2"}, {"3"}, {"4"}}, server.batches())
+
+	spooled, err = os.ReadDir(spoolDir)
+	require.NoError(t, err)
+	require.Empty(t, spooled)
+}
+
+func TestHTTPSpoolAfterFailure(t *testing.T) {
+	spoolDir := t.TempDir()
+	server := newHTTPTestServer(t)
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+		FlushInterval: time.Hour,
+		SpoolDir:      spoolDir,
+	})
+	defer closeHTTPLogger(t, logger)
+
+	flush := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, logger.Flush(ctx))
+	}
+
+	// Wait for the batches of previous runs to be sent.
+	flush()
+
+	external := filepath.Join(spoolDir, "external.ndjson")
+	require.NoError(t, os.WriteFile(external, []byte(`{"message":"external"}`+"\n"), 0o600))
+
+	// The spool is not read again while requests succeed.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("1"))
+	flush()
+
+	require.Equal(t, [][]string{{"1"}}, server.batches())
+	require.FileExists(t, external)
+
+	server.mu.Lock()
+	server.statuses = []int{http.StatusServiceUnavailable}
+	server.mu.Unlock()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("2"))
+	flush()
+
+	// Once a batch is spooled, the next successful request sends the spool.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("3"))
+	flush()
+
+	require.Equal(t, [][]string{{"1"}, {"3"}, {"2"}, {"external"}}, server.batches())
+	require.Zero(t, logger.Dropped())
+
+	spooled, err := os.ReadDir(spoolDir)
+	require.NoError(t, err)
+	require.Empty(t, spooled)
+}
+
+func TestHTTPNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+
+	// The server never answers, until the end of the test.
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	spoolDir := t.TempDir()
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{
+		BatchSize:     10,
+		QueueSize:     20,
+		FlushInterval: time.Hour,
+		SpoolDir:      spoolDir,
+	})
+
+	start := time.Now()
+
+	for range 1000 {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+	}
+
+	require.Less(t, time.Since(start), time.Second)
+	// The queue overflowed, while the sender was waiting for the server.
+	require.Positive(t, logger.Dropped())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The server does not answer: pending messages are spooled.
+	require.ErrorIs(t, logger.Close(ctx), context.DeadlineExceeded)
+
+	spooled, err := os.ReadDir(spoolDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, spooled)
+}
+
+func TestHTTPAnimated(t *testing.T) {
+	server := newHTTPTestServer(t)
+
+	logger := loggers.NewHTTP(server.URL, &loggers.HTTPConfig{FlushInterval: time.Hour})
+
+	logChan := make(chan map[string]interface{})
+	animated := &fakeAnimated{outJSON: logChan}
+
+	cleaner := logger.LogAnimated(animated)
+
+	logChan <- messages.NewBase("This is an animated message.").RenderJSON()
+	// Ignore empty renders.
+	logChan <- nil
+	logChan <- messages.NewBase("This is another animated message.").RenderJSON()
+
+	cleaner()
+	closeHTTPLogger(t, logger)
+
+	require.Equal(
+		t,
+		[][]string{{"This is an animated message.", "This is another animated message."}},
+		server.batches(),
+	)
+}
@@ -0,0 +1,243 @@
+package loggers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Fields set by the journald logger. Fields of the JSON output that would override them are prefixed with
+// "QUICKLOG_".
+var journaldReservedFields = []string{"MESSAGE", "PRIORITY", "CODE_FILE", "CODE_LINE", "CODE_FUNC", "SYSLOG_IDENTIFIER"}
+
+// JournaldLogger is a Logger that sends messages to the systemd journal.
+type JournaldLogger interface {
+	quicklog.Logger
+
+	// Close the socket. Messages logged after Close are ignored.
+	Close() error
+}
+
+// ==============================================================================================================
+// Formatting.
+// ==============================================================================================================
+
+// Convert a key of the JSON output to a valid journal field name: upper-case letters, digits and underscores, not
+// starting with an underscore or a digit, and at most 64 characters.
+func journaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	// Fields starting with an underscore are trusted fields, set by the journal itself.
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || lo.Contains(journaldReservedFields, name) {
+		name = "QUICKLOG_" + name
+	}
+
+	if len(name) > 64 {
+		name = name[:64]
+	}
+
+	return name
+}
+
+// Append a field to a payload of the native protocol. Values that span multiple lines are prefixed with their length
+// instead.
+func appendJournaldField(buffer *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		buffer.WriteString(name + "=" + value + "\n")
+		return
+	}
+
+	buffer.WriteString(name + "\n")
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value + "\n")
+}
+
+// ==============================================================================================================
+// Logger.
+// ==============================================================================================================
+
+type journaldLogger struct {
+	config JournaldConfig
+
+	conn   *net.UnixConn
+	addr   *net.UnixAddr
+	closed atomic.Bool
+
+	animation animationState
+
+	quicklog.Logger
+}
+
+func (logger *journaldLogger) payload(
+	level quicklog.Level, text string, rendered map[string]interface{}, caller *callerFrame,
+) []byte {
+	var buffer bytes.Buffer
+
+	appendJournaldField(&buffer, "MESSAGE", text)
+	appendJournaldField(&buffer, "PRIORITY", strconv.Itoa(lo.ValueOr(syslogSeverities, level, 6)))
+	appendJournaldField(&buffer, "SYSLOG_IDENTIFIER", logger.config.Identifier)
+
+	if caller != nil {
+		appendJournaldField(&buffer, "CODE_FILE", caller.file)
+		appendJournaldField(&buffer, "CODE_LINE", strconv.Itoa(caller.line))
+		appendJournaldField(&buffer, "CODE_FUNC", caller.function)
+	}
+
+	fields := make(map[string]string)
+	for key, value := range flattenJSON(rendered) {
+		fields[journaldFieldName(key)] = value
+	}
+
+	names := lo.Keys(fields)
+	sort.Strings(names)
+
+	for _, name := range names {
+		appendJournaldField(&buffer, name, fields[name])
+	}
+
+	return buffer.Bytes()
+}
+
+// Send a payload to the journal. Payloads too large for a datagram are written to a memory file, whose descriptor is
+// sent instead.
+func (logger *journaldLogger) send(payload []byte) {
+	if logger.closed.Load() {
+		return
+	}
+
+	_, _, err := logger.conn.WriteMsgUnix(payload, nil, logger.addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		_ = sendJournaldMemfd(logger.conn, logger.addr, payload)
+	}
+}
+
+func (logger *journaldLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so its final state is logged.
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
+
+	text := renderPlainText(message.RenderTerminal())
+	rendered := message.RenderJSON()
+
+	if text != "" || rendered != nil {
+		logger.send(logger.payload(level, text, rendered, findMessageCaller(message)))
+	}
+
+	if level == quicklog.LevelFatal {
+		_ = logger.Close()
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
+}
+
+func (logger *journaldLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
+
+	// Frames are sent from a background goroutine: they are attributed to the code that started the animation.
+	caller := findCaller()
+
+	// The JSON output of animated messages only changes on meaningful transitions.
+	output := message.RunJSON()
+
+	return logger.animation.start(message, func() {
+		for frame := range output {
+			if frame == nil {
+				continue
+			}
+
+			// The message field is the closest to a text version of the frame.
+			text, _ := frame["message"].(string)
+			logger.send(logger.payload(quicklog.LevelInfo, text, frame, caller))
+		}
+	})
+}
+
+func (logger *journaldLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *journaldLogger) Close() error {
+	logger.animation.interrupt(nil)
+
+	if logger.closed.Swap(true) {
+		return nil
+	}
+
+	return logger.conn.Close()
+}
+
+type JournaldConfig struct {
+	// SocketPath is the socket of the journal. Defaults to "/run/systemd/journal/socket".
+	SocketPath string
+	// Identifier is the SYSLOG_IDENTIFIER of the messages. Defaults to the name of the executable.
+	Identifier string
+}
+
+var JournaldConfigDefault = JournaldConfig{
+	SocketPath: "/run/systemd/journal/socket",
+}
+
+// NewJournald creates a new Logger that sends messages to the systemd journal, using its native protocol.
+//
+// The MESSAGE of each entry is the terminal rendering of the message, without styles. The JSON output of the message
+// is flattened into upper-case fields (DATA_0_MESSAGE for data.0.message), so they can be used to filter entries:
+//
+//	journalctl OP_ID=2b7b7a5e-1f2c-4a4e-9b1a-6f0e2c6d1a3b
+//
+// Levels map to the critical, error, warning and informational priorities.
+func NewJournald() (JournaldLogger, error) {
+	return NewJournaldWithConfig(&JournaldConfigDefault)
+}
+
+// NewJournaldWithConfig creates a new journald logger, with a custom configuration.
+func NewJournaldWithConfig(config *JournaldConfig) (JournaldLogger, error) {
+	socketPath := lo.CoalesceOrEmpty(config.SocketPath, JournaldConfigDefault.SocketPath)
+
+	if _, err := os.Stat(socketPath); err != nil {
+		return nil, fmt.Errorf("journal socket: %w", err)
+	}
+
+	// The socket is not connected, so messages reach the journal again if it restarts.
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("open journal socket: %w", err)
+	}
+
+	return &journaldLogger{
+		config: JournaldConfig{
+			SocketPath: socketPath,
+			Identifier: lo.CoalesceOrEmpty(config.Identifier, filepath.Base(os.Args[0])),
+		},
+		conn: conn,
+		addr: &net.UnixAddr{Name: socketPath, Net: "unixgram"},
+	}, nil
+}
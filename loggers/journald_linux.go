@@ -0,0 +1,36 @@
+package loggers
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Write a payload to a sealed memory file, and send its descriptor to the journal.
+func sendJournaldMemfd(conn *net.UnixConn, addr *net.UnixAddr, payload []byte) error {
+	fd, err := unix.MemfdCreate("quicklog-journal", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("create memory file: %w", err)
+	}
+
+	file := os.NewFile(uintptr(fd), "quicklog-journal")
+	defer file.Close()
+
+	if _, err := file.Write(payload); err != nil {
+		return fmt.Errorf("write memory file: %w", err)
+	}
+
+	// The journal only accepts memory files that can no longer be modified.
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		return fmt.Errorf("seal memory file: %w", err)
+	}
+
+	if _, _, err := conn.WriteMsgUnix(nil, unix.UnixRights(int(file.Fd())), addr); err != nil {
+		return fmt.Errorf("send memory file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package loggers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Decode a payload of the journal native protocol.
+func decodeJournaldPayload(t *testing.T, payload []byte) map[string]string {
+	t.Helper()
+
+	fields := make(map[string]string)
+	reader := bytes.NewReader(payload)
+
+	for reader.Len() > 0 {
+		line, err := readJournaldLine(reader)
+		require.NoError(t, err)
+
+		if name, value, ok := strings.Cut(line, "="); ok {
+			fields[name] = value
+			continue
+		}
+
+		var length uint64
+		require.NoError(t, binary.Read(reader, binary.LittleEndian, &length))
+
+		value := make([]byte, length+1)
+		_, err = io.ReadFull(reader, value)
+		require.NoError(t, err)
+		require.Equal(t, byte('\n'), value[length])
+
+		fields[line] = string(value[:length])
+	}
+
+	return fields
+}
+
+func readJournaldLine(reader *bytes.Reader) (string, error) {
+	var line []byte
+
+	for {
+		char, err := reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		if char == '\n' {
+			return string(line), nil
+		}
+
+		line = append(line, char)
+	}
+}
+
+// Start a stand-in for the journal socket, and return the entries it receives. Entries sent as memory files are
+// read from the received descriptor.
+func listenJournald(t *testing.T) (string, <-chan map[string]string) {
+	t.Helper()
+
+	// Unix socket paths are limited in length, so the default test directory may be too long.
+	dir, err := os.MkdirTemp("", "quicklog")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "journal.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	received := make(chan map[string]string, 16)
+
+	go func() {
+		buffer := make([]byte, 256*1024)
+		oob := make([]byte, syscall.CmsgSpace(4))
+
+		for {
+			n, oobn, _, _, err := conn.ReadMsgUnix(buffer, oob)
+			if err != nil {
+				return
+			}
+
+			payload := append([]byte{}, buffer[:n]...)
+
+			if oobn > 0 {
+				payload = readJournaldMemfd(t, oob[:oobn])
+			}
+
+			received <- decodeJournaldPayload(t, payload)
+		}
+	}()
+
+	return socket, received
+}
+
+func readJournaldMemfd(t *testing.T, oob []byte) []byte {
+	t.Helper()
+
+	messages, err := syscall.ParseSocketControlMessage(oob)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	require.NoError(t, err)
+	require.Len(t, fds, 1)
+
+	file := os.NewFile(uintptr(fds[0]), "memfd")
+	defer file.Close()
+
+	// The descriptor shares its offset with the sender, which left it at the end of the file.
+	payload, err := io.ReadAll(io.NewSectionReader(file, 0, 1<<32))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func receiveJournald(t *testing.T, received <-chan map[string]string) map[string]string {
+	t.Helper()
+
+	select {
+	case fields := <-received:
+		return fields
+	case <-time.After(time.Second):
+		require.FailNow(t, "no entry received")
+		return nil
+	}
+}
+
+func newTestJournald(t *testing.T) (loggers.JournaldLogger, <-chan map[string]string) {
+	t.Helper()
+
+	socket, received := listenJournald(t)
+
+	logger, err := loggers.NewJournaldWithConfig(&loggers.JournaldConfig{SocketPath: socket, Identifier: "app"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+
+	return logger, received
+}
+
+func TestJournaldLog(t *testing.T) {
+	logger, received := newTestJournald(t)
+
+	testCases := []struct {
+		name string
+
+		level    quicklog.Level
+		priority string
+	}{
+		{name: "Info", level: quicklog.LevelInfo, priority: "6"},
+		{name: "Warning", level: quicklog.LevelWarning, priority: "4"},
+		{name: "Error", level: quicklog.LevelError, priority: "3"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, file, line, _ := runtime.Caller(0)
+			logger.Log(testCase.level, messages.NewBase("Hello world", messages.NewBase("Child")))
+
+			require.Equal(t, map[string]string{
+				"MESSAGE":           "Hello world\nChild",
+				"PRIORITY":          testCase.priority,
+				"SYSLOG_IDENTIFIER": "app",
+				"CODE_FILE":         file,
+				"CODE_LINE":         strconv.Itoa(line + 1),
+				"CODE_FUNC":         "github.com/a-novel-kit/quicklog/loggers_test.TestJournaldLog.func1",
+				"QUICKLOG_MESSAGE":  "Hello world",
+				"KIND":              "base",
+				"SCHEMA_VERSION":    "1",
+				"DATA_MESSAGE":      "Child",
+				"DATA_KIND":         "base",
+			}, receiveJournald(t, received))
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(""))
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Not empty"))
+
+		require.Equal(t, "Not empty", receiveJournald(t, received)["MESSAGE"])
+	})
+}
+
+func TestJournaldFields(t *testing.T) {
+	logger, received := newTestJournald(t)
+
+	logger.Log(quicklog.LevelError, messages.NewError(errors.New("out of memory"), "Deploy failed"))
+
+	fields := receiveJournald(t, received)
+	require.Equal(t, "Deploy failed\nout of memory", fields["MESSAGE"])
+	require.Equal(t, "out of memory", fields["ERROR_CHAIN_0_MESSAGE"])
+	require.Equal(t, "*errors.errorString", fields["ERROR_TYPE"])
+}
+
+func TestJournaldAnimated(t *testing.T) {
+	logger, received := newTestJournald(t)
+
+	opID := uuid.MustParse("2b7b7a5e-1f2c-4a4e-9b1a-6f0e2c6d1a3b")
+	config := messages.LoaderConfigDefault
+	config.OpID = &opID
+
+	loader := messages.NewLoader("Building", &config)
+
+	_, file, line, _ := runtime.Caller(0)
+	cleaner := logger.LogAnimated(loader)
+	loader.Success("Built")
+	cleaner()
+
+	// Intermediate frames may be skipped, but the final state is always sent.
+	var fields map[string]string
+	for fields == nil || fields["STATUS"] != "success" {
+		fields = receiveJournald(t, received)
+
+		// The operation ID can be used to filter entries.
+		require.Equal(t, opID.String(), fields["OP_ID"])
+		require.Equal(t, file, fields["CODE_FILE"])
+		require.Equal(t, strconv.Itoa(line+1), fields["CODE_LINE"])
+	}
+
+	require.Equal(t, "Built", fields["MESSAGE"])
+	require.Equal(t, "6", fields["PRIORITY"])
+}
+
+func TestJournaldMemfd(t *testing.T) {
+	logger, received := newTestJournald(t)
+
+	// Larger than the maximum size of a datagram.
+	message := strings.Repeat("a", 1024*1024)
+	logger.Log(quicklog.LevelInfo, messages.NewBase(message))
+
+	fields := receiveJournald(t, received)
+	// Avoid printing the whole message on failure.
+	require.True(t, fields["QUICKLOG_MESSAGE"] == message, "message was altered")
+	require.Equal(t, len(message), len(strings.ReplaceAll(fields["MESSAGE"], "\n", "")))
+}
+
+func TestJournaldMissingSocket(t *testing.T) {
+	_, err := loggers.NewJournaldWithConfig(&loggers.JournaldConfig{
+		SocketPath: filepath.Join(t.TempDir(), "missing.sock"),
+	})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package loggers
+
+import (
+	"errors"
+	"net"
+)
+
+// Memory files are specific to Linux, as is the journal.
+func sendJournaldMemfd(_ *net.UnixConn, _ *net.UnixAddr, _ []byte) error {
+	return errors.New("memory files are not supported on this platform")
+}
@@ -0,0 +1,252 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFileLogger is an autogenerated mock type for the FileLogger type
+type MockFileLogger struct {
+	mock.Mock
+}
+
+type MockFileLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileLogger) EXPECT() *MockFileLogger_Expecter {
+	return &MockFileLogger_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockFileLogger) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileLogger_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockFileLogger_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockFileLogger_Expecter) Close() *MockFileLogger_Close_Call {
+	return &MockFileLogger_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockFileLogger_Close_Call) Run(run func()) *MockFileLogger_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFileLogger_Close_Call) Return(_a0 error) *MockFileLogger_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileLogger_Close_Call) RunAndReturn(run func() error) *MockFileLogger_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockFileLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockFileLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockFileLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockFileLogger_Expecter) Log(level interface{}, message interface{}) *MockFileLogger_Log_Call {
+	return &MockFileLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockFileLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockFileLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockFileLogger_Log_Call) Return() *MockFileLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockFileLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockFileLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockFileLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockFileLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockFileLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockFileLogger_Expecter) LogAnimated(message interface{}) *MockFileLogger_LogAnimated_Call {
+	return &MockFileLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockFileLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockFileLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockFileLogger_LogAnimated_Call) Return(cleaner func()) *MockFileLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockFileLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockFileLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reopen provides a mock function with given fields:
+func (_m *MockFileLogger) Reopen() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileLogger_Reopen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reopen'
+type MockFileLogger_Reopen_Call struct {
+	*mock.Call
+}
+
+// Reopen is a helper method to define mock.On call
+func (_e *MockFileLogger_Expecter) Reopen() *MockFileLogger_Reopen_Call {
+	return &MockFileLogger_Reopen_Call{Call: _e.mock.On("Reopen")}
+}
+
+func (_c *MockFileLogger_Reopen_Call) Run(run func()) *MockFileLogger_Reopen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFileLogger_Reopen_Call) Return(_a0 error) *MockFileLogger_Reopen_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileLogger_Reopen_Call) RunAndReturn(run func() error) *MockFileLogger_Reopen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rotate provides a mock function with given fields:
+func (_m *MockFileLogger) Rotate() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFileLogger_Rotate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rotate'
+type MockFileLogger_Rotate_Call struct {
+	*mock.Call
+}
+
+// Rotate is a helper method to define mock.On call
+func (_e *MockFileLogger_Expecter) Rotate() *MockFileLogger_Rotate_Call {
+	return &MockFileLogger_Rotate_Call{Call: _e.mock.On("Rotate")}
+}
+
+func (_c *MockFileLogger_Rotate_Call) Run(run func()) *MockFileLogger_Rotate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFileLogger_Rotate_Call) Return(_a0 error) *MockFileLogger_Rotate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFileLogger_Rotate_Call) RunAndReturn(run func() error) *MockFileLogger_Rotate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileLogger creates a new instance of MockFileLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileLogger {
+	mock := &MockFileLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
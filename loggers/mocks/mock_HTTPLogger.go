@@ -0,0 +1,257 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	quicklog "github.com/a-novel-kit/quicklog"
+)
+
+// MockHTTPLogger is an autogenerated mock type for the HTTPLogger type
+type MockHTTPLogger struct {
+	mock.Mock
+}
+
+type MockHTTPLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHTTPLogger) EXPECT() *MockHTTPLogger_Expecter {
+	return &MockHTTPLogger_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with given fields: ctx
+func (_m *MockHTTPLogger) Close(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHTTPLogger_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockHTTPLogger_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHTTPLogger_Expecter) Close(ctx interface{}) *MockHTTPLogger_Close_Call {
+	return &MockHTTPLogger_Close_Call{Call: _e.mock.On("Close", ctx)}
+}
+
+func (_c *MockHTTPLogger_Close_Call) Run(run func(ctx context.Context)) *MockHTTPLogger_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockHTTPLogger_Close_Call) Return(_a0 error) *MockHTTPLogger_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHTTPLogger_Close_Call) RunAndReturn(run func(context.Context) error) *MockHTTPLogger_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Dropped provides a mock function with given fields:
+func (_m *MockHTTPLogger) Dropped() uint64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dropped")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// MockHTTPLogger_Dropped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dropped'
+type MockHTTPLogger_Dropped_Call struct {
+	*mock.Call
+}
+
+// Dropped is a helper method to define mock.On call
+func (_e *MockHTTPLogger_Expecter) Dropped() *MockHTTPLogger_Dropped_Call {
+	return &MockHTTPLogger_Dropped_Call{Call: _e.mock.On("Dropped")}
+}
+
+func (_c *MockHTTPLogger_Dropped_Call) Run(run func()) *MockHTTPLogger_Dropped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockHTTPLogger_Dropped_Call) Return(_a0 uint64) *MockHTTPLogger_Dropped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHTTPLogger_Dropped_Call) RunAndReturn(run func() uint64) *MockHTTPLogger_Dropped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Flush provides a mock function with given fields: ctx
+func (_m *MockHTTPLogger) Flush(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHTTPLogger_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockHTTPLogger_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHTTPLogger_Expecter) Flush(ctx interface{}) *MockHTTPLogger_Flush_Call {
+	return &MockHTTPLogger_Flush_Call{Call: _e.mock.On("Flush", ctx)}
+}
+
+func (_c *MockHTTPLogger_Flush_Call) Run(run func(ctx context.Context)) *MockHTTPLogger_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockHTTPLogger_Flush_Call) Return(_a0 error) *MockHTTPLogger_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHTTPLogger_Flush_Call) RunAndReturn(run func(context.Context) error) *MockHTTPLogger_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockHTTPLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockHTTPLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockHTTPLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockHTTPLogger_Expecter) Log(level interface{}, message interface{}) *MockHTTPLogger_Log_Call {
+	return &MockHTTPLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockHTTPLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockHTTPLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockHTTPLogger_Log_Call) Return() *MockHTTPLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHTTPLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockHTTPLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockHTTPLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockHTTPLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockHTTPLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockHTTPLogger_Expecter) LogAnimated(message interface{}) *MockHTTPLogger_LogAnimated_Call {
+	return &MockHTTPLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockHTTPLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockHTTPLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockHTTPLogger_LogAnimated_Call) Return(cleaner func()) *MockHTTPLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockHTTPLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockHTTPLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHTTPLogger creates a new instance of MockHTTPLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHTTPLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHTTPLogger {
+	mock := &MockHTTPLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
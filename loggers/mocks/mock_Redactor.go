@@ -0,0 +1,78 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRedactor is an autogenerated mock type for the Redactor type
+type MockRedactor struct {
+	mock.Mock
+}
+
+type MockRedactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRedactor) EXPECT() *MockRedactor_Expecter {
+	return &MockRedactor_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: value
+func (_m *MockRedactor) Execute(value string) string {
+	ret := _m.Called(value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(value)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockRedactor_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockRedactor_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - value string
+func (_e *MockRedactor_Expecter) Execute(value interface{}) *MockRedactor_Execute_Call {
+	return &MockRedactor_Execute_Call{Call: _e.mock.On("Execute", value)}
+}
+
+func (_c *MockRedactor_Execute_Call) Run(run func(value string)) *MockRedactor_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedactor_Execute_Call) Return(_a0 string) *MockRedactor_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedactor_Execute_Call) RunAndReturn(run func(string) string) *MockRedactor_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRedactor creates a new instance of MockRedactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRedactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRedactor {
+	mock := &MockRedactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
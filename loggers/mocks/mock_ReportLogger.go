@@ -0,0 +1,162 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReportLogger is an autogenerated mock type for the ReportLogger type
+type MockReportLogger struct {
+	mock.Mock
+}
+
+type MockReportLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReportLogger) EXPECT() *MockReportLogger_Expecter {
+	return &MockReportLogger_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockReportLogger) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReportLogger_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockReportLogger_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockReportLogger_Expecter) Close() *MockReportLogger_Close_Call {
+	return &MockReportLogger_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockReportLogger_Close_Call) Run(run func()) *MockReportLogger_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockReportLogger_Close_Call) Return(_a0 error) *MockReportLogger_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReportLogger_Close_Call) RunAndReturn(run func() error) *MockReportLogger_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockReportLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockReportLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockReportLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockReportLogger_Expecter) Log(level interface{}, message interface{}) *MockReportLogger_Log_Call {
+	return &MockReportLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockReportLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockReportLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockReportLogger_Log_Call) Return() *MockReportLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockReportLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockReportLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockReportLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockReportLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockReportLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockReportLogger_Expecter) LogAnimated(message interface{}) *MockReportLogger_LogAnimated_Call {
+	return &MockReportLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockReportLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockReportLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockReportLogger_LogAnimated_Call) Return(cleaner func()) *MockReportLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockReportLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockReportLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReportLogger creates a new instance of MockReportLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReportLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReportLogger {
+	mock := &MockReportLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSampleKeyFunc is an autogenerated mock type for the SampleKeyFunc type
+type MockSampleKeyFunc struct {
+	mock.Mock
+}
+
+type MockSampleKeyFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSampleKeyFunc) EXPECT() *MockSampleKeyFunc_Expecter {
+	return &MockSampleKeyFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: level, message
+func (_m *MockSampleKeyFunc) Execute(level quicklog.Level, message quicklog.Message) string {
+	ret := _m.Called(level, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(quicklog.Level, quicklog.Message) string); ok {
+		r0 = rf(level, message)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockSampleKeyFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockSampleKeyFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockSampleKeyFunc_Expecter) Execute(level interface{}, message interface{}) *MockSampleKeyFunc_Execute_Call {
+	return &MockSampleKeyFunc_Execute_Call{Call: _e.mock.On("Execute", level, message)}
+}
+
+func (_c *MockSampleKeyFunc_Execute_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockSampleKeyFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockSampleKeyFunc_Execute_Call) Return(_a0 string) *MockSampleKeyFunc_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSampleKeyFunc_Execute_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message) string) *MockSampleKeyFunc_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSampleKeyFunc creates a new instance of MockSampleKeyFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSampleKeyFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSampleKeyFunc {
+	mock := &MockSampleKeyFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,194 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSampledLogger is an autogenerated mock type for the SampledLogger type
+type MockSampledLogger struct {
+	mock.Mock
+}
+
+type MockSampledLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSampledLogger) EXPECT() *MockSampledLogger_Expecter {
+	return &MockSampledLogger_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockSampledLogger) Close() {
+	_m.Called()
+}
+
+// MockSampledLogger_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockSampledLogger_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockSampledLogger_Expecter) Close() *MockSampledLogger_Close_Call {
+	return &MockSampledLogger_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockSampledLogger_Close_Call) Run(run func()) *MockSampledLogger_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSampledLogger_Close_Call) Return() *MockSampledLogger_Close_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSampledLogger_Close_Call) RunAndReturn(run func()) *MockSampledLogger_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockSampledLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockSampledLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockSampledLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockSampledLogger_Expecter) Log(level interface{}, message interface{}) *MockSampledLogger_Log_Call {
+	return &MockSampledLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockSampledLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockSampledLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockSampledLogger_Log_Call) Return() *MockSampledLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSampledLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockSampledLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockSampledLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockSampledLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockSampledLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockSampledLogger_Expecter) LogAnimated(message interface{}) *MockSampledLogger_LogAnimated_Call {
+	return &MockSampledLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockSampledLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockSampledLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockSampledLogger_LogAnimated_Call) Return(cleaner func()) *MockSampledLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockSampledLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockSampledLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suppressed provides a mock function with given fields:
+func (_m *MockSampledLogger) Suppressed() uint64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suppressed")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// MockSampledLogger_Suppressed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suppressed'
+type MockSampledLogger_Suppressed_Call struct {
+	*mock.Call
+}
+
+// Suppressed is a helper method to define mock.On call
+func (_e *MockSampledLogger_Expecter) Suppressed() *MockSampledLogger_Suppressed_Call {
+	return &MockSampledLogger_Suppressed_Call{Call: _e.mock.On("Suppressed")}
+}
+
+func (_c *MockSampledLogger_Suppressed_Call) Run(run func()) *MockSampledLogger_Suppressed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSampledLogger_Suppressed_Call) Return(_a0 uint64) *MockSampledLogger_Suppressed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSampledLogger_Suppressed_Call) RunAndReturn(run func() uint64) *MockSampledLogger_Suppressed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSampledLogger creates a new instance of MockSampledLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSampledLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSampledLogger {
+	mock := &MockSampledLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package loggersmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTerminalLogger is an autogenerated mock type for the TerminalLogger type
+type MockTerminalLogger struct {
+	mock.Mock
+}
+
+type MockTerminalLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTerminalLogger) EXPECT() *MockTerminalLogger_Expecter {
+	return &MockTerminalLogger_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function with given fields:
+func (_m *MockTerminalLogger) Flush() {
+	_m.Called()
+}
+
+// MockTerminalLogger_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockTerminalLogger_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockTerminalLogger_Expecter) Flush() *MockTerminalLogger_Flush_Call {
+	return &MockTerminalLogger_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockTerminalLogger_Flush_Call) Run(run func()) *MockTerminalLogger_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTerminalLogger_Flush_Call) Return() *MockTerminalLogger_Flush_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTerminalLogger_Flush_Call) RunAndReturn(run func()) *MockTerminalLogger_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockTerminalLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockTerminalLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockTerminalLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockTerminalLogger_Expecter) Log(level interface{}, message interface{}) *MockTerminalLogger_Log_Call {
+	return &MockTerminalLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockTerminalLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockTerminalLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockTerminalLogger_Log_Call) Return() *MockTerminalLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTerminalLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockTerminalLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockTerminalLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockTerminalLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockTerminalLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockTerminalLogger_Expecter) LogAnimated(message interface{}) *MockTerminalLogger_LogAnimated_Call {
+	return &MockTerminalLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockTerminalLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockTerminalLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockTerminalLogger_LogAnimated_Call) Return(cleaner func()) *MockTerminalLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockTerminalLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockTerminalLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTerminalLogger creates a new instance of MockTerminalLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTerminalLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTerminalLogger {
+	mock := &MockTerminalLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,216 @@
+package loggers
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// RecordFormat is the file format used to record a logging session.
+type RecordFormat string
+
+const (
+	// RecordFormatJSONLines writes one JSON event per line. Events keep both the terminal and JSON renderings of
+	// static messages.
+	RecordFormatJSONLines RecordFormat = "jsonl"
+	// RecordFormatAsciicast writes an asciicast v2 file, that can be played by asciinema.
+	// https://docs.asciinema.org/manual/asciicast/v2/
+	RecordFormatAsciicast RecordFormat = "asciicast"
+)
+
+// RecordEvent is a single output of a recorded session, in the RecordFormatJSONLines format.
+type RecordEvent struct {
+	// Time elapsed since the start of the recording, in seconds.
+	Time float64 `json:"time"`
+	// Level of static messages. Empty for animated frames.
+	Level quicklog.Level `json:"level,omitempty"`
+	// Output is the terminal rendering of the event.
+	Output string `json:"output,omitempty"`
+	// Data is the JSON rendering of the event.
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+type asciicastHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Append the final newline added by the terminal logger, if missing.
+func terminalLine(output string) string {
+	if strings.HasSuffix(output, "\n") {
+		return output
+	}
+
+	return output + "\n"
+}
+
+type recorderLogger struct {
+	inner  quicklog.Logger
+	output io.Writer
+	format RecordFormat
+
+	startedAt time.Time
+
+	mu sync.Mutex
+
+	quicklog.Logger
+}
+
+// Write a new event to the recording.
+func (logger *recorderLogger) record(event RecordEvent) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	event.Time = time.Since(logger.startedAt).Seconds()
+
+	var line []byte
+
+	if logger.format == RecordFormatAsciicast {
+		output := event.Output
+		if output == "" && event.Data != nil {
+			rendered, _ := json.Marshal(event.Data)
+			output = string(rendered)
+		}
+
+		if output == "" {
+			return
+		}
+
+		// Recordings are played in a raw terminal, where line feeds do not return the cursor to the line start.
+		output = strings.ReplaceAll(terminalLine(output), "\n", "\r\n")
+		line, _ = json.Marshal([]interface{}{event.Time, "o", output})
+	} else {
+		line, _ = json.Marshal(event)
+	}
+
+	_, _ = logger.output.Write(append(line, '\n'))
+}
+
+func (logger *recorderLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Record before forwarding, since fatal messages exit the program.
+	event := RecordEvent{Level: level, Output: message.RenderTerminal()}
+	if logger.format == RecordFormatJSONLines {
+		event.Data = message.RenderJSON()
+	}
+
+	if event.Output != "" || event.Data != nil {
+		logger.record(event)
+	}
+
+	logger.inner.Log(level, message)
+}
+
+func (logger *recorderLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	return logger.inner.LogAnimated(&recordedAnimatedMessage{AnimatedMessage: message, recorder: logger})
+}
+
+func (logger *recorderLogger) Interrupt(err error) {
+	if interruptible, ok := logger.inner.(quicklog.InterruptibleLogger); ok {
+		interruptible.Interrupt(err)
+	}
+}
+
+// recordedAnimatedMessage records every frame of an animated message, as the logger receives it.
+type recordedAnimatedMessage struct {
+	recorder *recorderLogger
+
+	quicklog.AnimatedMessage
+}
+
+func (message *recordedAnimatedMessage) RunTerminal(ci bool) <-chan string {
+	source := message.AnimatedMessage.RunTerminal(ci)
+	output := make(chan string)
+
+	go func() {
+		defer close(output)
+
+		for frame := range source {
+			if frame != "" {
+				message.recorder.record(RecordEvent{Output: frame})
+			}
+
+			output <- frame
+		}
+	}()
+
+	return output
+}
+
+func (message *recordedAnimatedMessage) RunJSON() <-chan map[string]interface{} {
+	source := message.AnimatedMessage.RunJSON()
+	output := make(chan map[string]interface{})
+
+	go func() {
+		defer close(output)
+
+		for frame := range source {
+			if frame != nil {
+				message.recorder.record(RecordEvent{Data: frame})
+			}
+
+			output <- frame
+		}
+	}()
+
+	return output
+}
+
+// SectionTitle forwards the title of the recorded message, so it is still folded in CI logs.
+func (message *recordedAnimatedMessage) SectionTitle() string {
+	if sectionMessage, ok := message.AnimatedMessage.(quicklog.SectionMessage); ok {
+		return sectionMessage.SectionTitle()
+	}
+
+	return ""
+}
+
+// Error forwards the error state to the recorded message, if it supports it.
+func (message *recordedAnimatedMessage) Error(err error) {
+	if failable, ok := message.AnimatedMessage.(interface{ Error(err error) }); ok {
+		failable.Error(err)
+	}
+}
+
+type RecorderConfig struct {
+	// Format of the recording. Defaults to RecordFormatJSONLines.
+	Format RecordFormat
+	// Height of the terminal, reported in asciicast recordings. Defaults to 24.
+	Height int
+}
+
+var RecorderConfigDefault = RecorderConfig{
+	Format: RecordFormatJSONLines,
+	Height: 24,
+}
+
+// NewRecorder wraps a Logger, and records everything it emits to output, including every frame of animated
+// messages, with timestamps relative to the creation of the recorder. The recording can be played with Replay.
+func NewRecorder(inner quicklog.Logger, output io.Writer, config *RecorderConfig) quicklog.Logger {
+	logger := &recorderLogger{
+		inner:     inner,
+		output:    output,
+		format:    lo.CoalesceOrEmpty(config.Format, RecorderConfigDefault.Format),
+		startedAt: time.Now(),
+	}
+
+	if logger.format == RecordFormatAsciicast {
+		header, _ := json.Marshal(asciicastHeader{
+			Version:   2,
+			Width:     quicklog.TermWidth,
+			Height:    lo.CoalesceOrEmpty(config.Height, RecorderConfigDefault.Height),
+			Timestamp: logger.startedAt.Unix(),
+		})
+
+		_, _ = output.Write(append(header, '\n'))
+	}
+
+	return logger
+}
@@ -0,0 +1,193 @@
+package loggers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Run a short session, with a static message and an animated one.
+func recordSession(t *testing.T, logger quicklog.Logger) {
+	t.Helper()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("static message", nil))
+
+	logChan := make(chan string)
+	cleaner := logger.LogAnimated(&fakeAnimated{outTerm: logChan})
+
+	logChan <- "frame 1\n"
+	logChan <- "frame 2\n"
+
+	cleaner()
+}
+
+// recordingLogger renders animated messages in terminal mode, and ignores static messages.
+type recordingLogger struct {
+	fakeLogger
+}
+
+func (logger *recordingLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range message.RunTerminal(true) {
+		}
+	}()
+
+	return func() {
+		message.Close()
+		<-done
+	}
+}
+
+func TestRecorderJSONLines(t *testing.T) {
+	output := new(bytes.Buffer)
+	inner := &recordingLogger{}
+
+	recordSession(t, loggers.NewRecorder(inner, output, &loggers.RecorderConfigDefault))
+
+	// Static messages are forwarded.
+	require.Equal(t, []string{"static message"}, inner.messages())
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var events []loggers.RecordEvent
+
+	for _, line := range lines {
+		var event loggers.RecordEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+
+		events = append(events, event)
+	}
+
+	require.Equal(t, quicklog.LevelInfo, events[0].Level)
+	require.Equal(t, messages.NewBase("static message", nil).RenderTerminal(), events[0].Output)
+	require.Equal(t, map[string]interface{}{
+		"kind": "base", "schema_version": float64(quicklog.SchemaVersion), "message": "static message",
+	}, events[0].Data)
+
+	require.Equal(t, "frame 1\n", events[1].Output)
+	require.Equal(t, "frame 2\n", events[2].Output)
+
+	require.LessOrEqual(t, events[0].Time, events[1].Time)
+	require.LessOrEqual(t, events[1].Time, events[2].Time)
+}
+
+func TestRecorderAsciicast(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	recordSession(t, loggers.NewRecorder(&recordingLogger{}, output, &loggers.RecorderConfig{
+		Format: loggers.RecordFormatAsciicast,
+	}))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 4)
+
+	var header map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	require.InDelta(t, 2, header["version"], 0)
+	require.InDelta(t, quicklog.TermWidth, header["width"], 0)
+	require.InDelta(t, 24, header["height"], 0)
+
+	var event []interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	require.Equal(t, "o", event[1])
+	require.Equal(t, "frame 1\r\n", event[2])
+}
+
+func TestRecorderSection(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewRecorder(loggers.NewTerminal(), io.Discard, &loggers.RecorderConfigDefault)
+
+			loader := messages.NewLoader("Connecting", &messages.LoaderConfigDefault)
+			cleaner := logger.LogAnimated(loader)
+			loader.Success("Connected")
+			cleaner()
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			// Recorded loaders are still folded.
+			require.Contains(t, res.STDOut, "::group::Connecting\n")
+			require.Contains(t, res.STDOut, "::endgroup::\n")
+		},
+		Env: []string{"CI=", "GITHUB_ACTIONS=true", "GITLAB_CI="},
+	})
+}
+
+func TestReplay(t *testing.T) {
+	for _, format := range []loggers.RecordFormat{loggers.RecordFormatJSONLines, loggers.RecordFormatAsciicast} {
+		t.Run(string(format), func(t *testing.T) {
+			recording := new(bytes.Buffer)
+
+			recordSession(t, loggers.NewRecorder(&recordingLogger{}, recording, &loggers.RecorderConfig{
+				Format: format,
+			}))
+
+			output := new(bytes.Buffer)
+			require.NoError(t, loggers.Replay(
+				context.Background(), recording, output, &loggers.ReplayConfig{Speed: 100},
+			))
+
+			expect := messages.NewBase("static message", nil).RenderTerminal() + "frame 1\nframe 2\n"
+			if format == loggers.RecordFormatAsciicast {
+				expect = strings.ReplaceAll(expect, "\n", "\r\n")
+			}
+
+			require.Equal(t, expect, output.String())
+		})
+	}
+}
+
+func TestReplayTiming(t *testing.T) {
+	recording := strings.NewReader(
+		`{"time":0,"output":"first\n"}` + "\n" +
+			`{"time":10,"output":"second\n"}` + "\n" +
+			`{"time":10.2,"data":{"message":"third"}}` + "\n",
+	)
+
+	output := new(bytes.Buffer)
+	start := time.Now()
+
+	// The 10s gap is capped, and the 200ms one is accelerated.
+	require.NoError(t, loggers.Replay(context.Background(), recording, output, &loggers.ReplayConfig{
+		Speed:   2,
+		MaxWait: 50 * time.Millisecond,
+	}))
+
+	require.Less(t, time.Since(start), time.Second)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	require.Equal(t, "first\nsecond\n{\"message\":\"third\"}\n", output.String())
+}
+
+func TestReplayInvalid(t *testing.T) {
+	err := loggers.Replay(
+		context.Background(), strings.NewReader("not json\n"), new(bytes.Buffer), &loggers.ReplayConfigDefault,
+	)
+	require.ErrorIs(t, err, loggers.ErrInvalidRecording)
+}
+
+func TestReplayCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := loggers.Replay(
+		ctx, strings.NewReader(`{"time":10,"output":"late\n"}`+"\n"), new(bytes.Buffer), &loggers.ReplayConfigDefault,
+	)
+	require.ErrorIs(t, err, context.Canceled)
+}
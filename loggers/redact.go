@@ -0,0 +1,599 @@
+package loggers
+
+import (
+	"html"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Redactor is a custom redaction rule. It receives every string rendered by a message, after the patterns of the
+// configuration are applied, and returns it with its secrets removed.
+type Redactor func(value string) string
+
+// RedactSecretGroup is the name of the group of a pattern that captures the secret. If a pattern has this group,
+// only the group is masked, so the surrounding text stays readable.
+const RedactSecretGroup = "secret"
+
+// RedactPatternsDefault match common secrets: bearer tokens, JWTs, AWS keys, GitHub and Slack tokens, passwords in
+// URLs, and values assigned to keys that look like credentials.
+var RedactPatternsDefault = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[a-z0-9\-._~+/]+=*)`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]+`),
+	regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]+:(?P<secret>[^@/\s]+)@`),
+	regexp.MustCompile(
+		`(?i)\b(?:password|passwd|secret|token|api_?key)["']?\s*[:=]\s*["']?(?P<secret>[^\s"',;&]+)`,
+	),
+}
+
+// RedactKeysDefault are the keys of JSON renderings whose values are always masked.
+var RedactKeysDefault = []string{
+	"password", "passwd", "secret", "token", "access_token", "refresh_token", "id_token", "api_key", "client_secret",
+	"private_key", "authorization", "cookie", "set_cookie",
+}
+
+// Normalize a key of a JSON rendering, so "apiKey", "api-key" and "API_KEY" match the same denylisted key.
+func normalizeRedactKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// redactor applies the rules of a configuration to the renderings of messages.
+type redactor struct {
+	patterns  []*regexp.Regexp
+	keys      map[string]bool
+	redactors []Redactor
+	mask      string
+}
+
+// escapedText is the unescaped version of a rendering, so patterns match secrets regardless of the escaping of
+// Markdown and HTML, or the styles of the terminal. Offsets and ends map each byte of the unescaped text to the
+// start and end of its token in the rendering.
+type escapedText struct {
+	text    string
+	offsets []int
+	ends    []int
+}
+
+// Unescape a rendering, with a function that returns the unescaped text of the token at the start of value, and the
+// length of that token. Tokens that unescape to an empty string are hidden from the patterns.
+func unescapeText(value string, unescapeToken func(value string) (string, int)) escapedText {
+	var builder strings.Builder
+
+	offsets := make([]int, 0, len(value))
+	ends := make([]int, 0, len(value))
+
+	for i := 0; i < len(value); {
+		token, length := unescapeToken(value[i:])
+		if length == 0 {
+			token, length = value[i:i+1], 1
+		}
+
+		builder.WriteString(token)
+
+		for range len(token) {
+			offsets = append(offsets, i)
+			ends = append(ends, i+length)
+		}
+
+		i += length
+	}
+
+	return escapedText{text: builder.String(), offsets: offsets, ends: ends}
+}
+
+// Return the hidden tokens of a rendering, such as the escape sequences of terminal styles.
+func hiddenTokens(value string, unescapeToken func(value string) (string, int)) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(value); {
+		token, length := unescapeToken(value[i:])
+		if length == 0 {
+			i++
+
+			continue
+		}
+
+		if token == "" {
+			builder.WriteString(value[i : i+length])
+		}
+
+		i += length
+	}
+
+	return builder.String()
+}
+
+func unescapeNothing(string) (string, int) {
+	return "", 0
+}
+
+// Markdown escapes punctuation with a backslash.
+func unescapeMarkdown(value string) (string, int) {
+	if len(value) > 1 && value[0] == '\\' && strings.ContainsRune(markdownPunctuation, rune(value[1])) {
+		return value[1:2], 2
+	}
+
+	return "", 0
+}
+
+const markdownPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// HTML escapes special characters with entities.
+func unescapeHTML(value string) (string, int) {
+	if value[0] != '&' {
+		return "", 0
+	}
+
+	end := strings.IndexByte(value, ';')
+	if end < 0 || end > 10 {
+		return "", 0
+	}
+
+	unescaped := html.UnescapeString(value[:end+1])
+	if unescaped == value[:end+1] {
+		return "", 0
+	}
+
+	return unescaped, end + 1
+}
+
+// The terminal styles text with escape sequences. They are hidden, so word boundaries of the patterns match on styled
+// text.
+func unescapeANSI(value string) (string, int) {
+	if value[0] != ansi.ESC {
+		return "", 0
+	}
+
+	_, _, length, _ := ansi.DecodeSequence(value, ansi.NormalState, nil)
+
+	return "", length
+}
+
+// Join the lines of a terminal rendering that were wrapped in the middle of a word, so secrets longer than the
+// terminal are matched as a whole.
+//
+// Words are wrapped in the middle when they do not fit on a line: their first lines fill the whole width of the
+// rendering. The line breaks after those lines are removed, with the padding and indentation around them.
+func joinWrappedLines(value escapedText) escapedText {
+	lines := strings.Split(value.text, "\n")
+	widths := lo.Map(lines, func(line string, _ int) int {
+		return ansi.StringWidth(strings.TrimRight(line, " "))
+	})
+	fullWidth := lo.Max(widths)
+
+	output := escapedText{offsets: make([]int, 0, len(value.text)), ends: make([]int, 0, len(value.text))}
+
+	var builder strings.Builder
+
+	position := 0
+
+	for i, line := range lines {
+		start, end := position, position+len(line)
+		position = end + 1
+
+		if i > 0 && widths[i-1] == fullWidth && fullWidth > 0 && strings.TrimSpace(line) != "" {
+			// Continuation of a wrapped word.
+			start += len(line) - len(strings.TrimLeft(line, " "))
+		} else if i > 0 {
+			start--
+		}
+
+		if i < len(lines)-1 && widths[i] == fullWidth && fullWidth > 0 && strings.TrimSpace(lines[i+1]) != "" {
+			end -= len(line) - len(strings.TrimRight(line, " "))
+		}
+
+		builder.WriteString(value.text[start:end])
+
+		output.offsets = append(output.offsets, value.offsets[start:end]...)
+		output.ends = append(output.ends, value.ends[start:end]...)
+	}
+
+	output.text = builder.String()
+
+	return output
+}
+
+// redactRange is a secret, located by its start and end in a rendering.
+type redactRange struct {
+	start, end int
+}
+
+// Locate the matches of a pattern, or only their secret group if the pattern has one.
+func patternRanges(pattern *regexp.Regexp, value escapedText) []redactRange {
+	group := pattern.SubexpIndex(RedactSecretGroup)
+
+	output := make([]redactRange, 0)
+
+	for _, match := range pattern.FindAllStringSubmatchIndex(value.text, -1) {
+		start, end := match[0], match[1]
+		if group >= 0 {
+			start, end = match[2*group], match[2*group+1]
+		}
+
+		// The secret group did not participate in the match, or the match is empty.
+		if start < 0 || start == end {
+			continue
+		}
+
+		output = append(output, redactRange{start: value.offsets[start], end: value.ends[end-1]})
+	}
+
+	return output
+}
+
+// Replace secrets with the mask. Hidden tokens and line breaks within secrets are kept, so the styles and the layout
+// of the rendering are not broken: secrets that span multiple lines are masked on their first line, and removed from
+// the next ones.
+func (redactor *redactor) maskRanges(
+	value string, ranges []redactRange, unescapeToken func(value string) (string, int),
+) string {
+	slices.SortFunc(ranges, func(a, b redactRange) int {
+		return a.start - b.start
+	})
+
+	var output strings.Builder
+
+	last := 0
+
+	for _, secret := range ranges {
+		// Secrets that overlap a previous one are merged with it.
+		if secret.end <= last {
+			continue
+		}
+
+		output.WriteString(value[last:max(last, secret.start)])
+
+		for i, part := range strings.Split(value[max(last, secret.start):secret.end], "\n") {
+			output.WriteString(lo.Ternary(i == 0, redactor.mask, "\n"))
+			output.WriteString(hiddenTokens(part, unescapeToken))
+		}
+
+		last = secret.end
+	}
+
+	output.WriteString(value[last:])
+
+	return output.String()
+}
+
+// Apply the patterns to a rendering, escaped by a format, then the custom redactors.
+func (redactor *redactor) redactEscaped(value string, unescapeToken func(value string) (string, int)) string {
+	for _, pattern := range redactor.patterns {
+		value = redactor.maskRanges(value, patternRanges(pattern, unescapeText(value, unescapeToken)), unescapeToken)
+	}
+
+	for _, custom := range redactor.redactors {
+		value = custom(value)
+	}
+
+	return value
+}
+
+func (redactor *redactor) redactString(value string) string {
+	return redactor.redactEscaped(value, unescapeNothing)
+}
+
+// Borders of the boxes of terminal renderings. Boxes are padded before their right border.
+const redactBoxBorders = "│┃║"
+
+// Whether a line of a terminal rendering is padded to the width of the terminal, or of its box.
+func isPaddedLine(line string) bool {
+	return strings.HasSuffix(strings.TrimRight(ansi.Strip(line), redactBoxBorders), " ")
+}
+
+// Restore the width of a padded line, after its secrets were replaced with a mask of a different width. The padding
+// is the last run of spaces of the line.
+func padRedactedLine(line string, width int) string {
+	missing := width - ansi.StringWidth(line)
+	end := strings.LastIndex(line, " ") + 1
+
+	if missing == 0 || end == 0 {
+		return line
+	}
+
+	if missing > 0 {
+		return line[:end] + strings.Repeat(" ", missing) + line[end:]
+	}
+
+	// A space is kept between the text and the border of its box.
+	padding := end - len(strings.TrimRight(line[:end], " ")) - lo.Ternary(ansi.Strip(line[end:]) != "", 1, 0)
+
+	return line[:end-min(-missing, max(padding, 0))] + line[end:]
+}
+
+// Redact a terminal rendering. Patterns ignore the styles of the text, and match secrets that were wrapped over
+// multiple lines. Lines padded to the width of the terminal keep their width, so the layout is kept.
+func (redactor *redactor) redactTerminal(rendered string) string {
+	if rendered == "" {
+		return rendered
+	}
+
+	value := rendered
+
+	for _, pattern := range redactor.patterns {
+		unescaped := unescapeText(value, unescapeANSI)
+		ranges := append(patternRanges(pattern, unescaped), patternRanges(pattern, joinWrappedLines(unescaped))...)
+
+		value = redactor.maskRanges(value, ranges, unescapeANSI)
+	}
+
+	originalLines := strings.Split(rendered, "\n")
+
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		for _, custom := range redactor.redactors {
+			line = custom(line)
+		}
+
+		// Padding is only adjusted on padded lines, so unpadded lines are not given trailing spaces.
+		if isPaddedLine(originalLines[i]) {
+			line = padRedactedLine(line, ansi.StringWidth(originalLines[i]))
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Redact a value of a JSON rendering. Strings are redacted wherever they are nested, and values of denylisted keys
+// are masked. The rendering is copied, so the original is left untouched.
+func (redactor *redactor) redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return redactor.redactString(typed)
+	case map[string]interface{}:
+		return redactor.redactJSON(typed)
+	}
+
+	// Renderings also contain typed slices and maps, such as the chain of errors.
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(redactor.redactString(reflected.String())).Convert(reflected.Type()).Interface()
+	case reflect.Slice:
+		if reflected.IsNil() {
+			return value
+		}
+
+		output := reflect.MakeSlice(reflected.Type(), reflected.Len(), reflected.Len())
+		for i := range reflected.Len() {
+			output.Index(i).Set(redactor.reflectedValue(reflected.Index(i), reflected.Type().Elem()))
+		}
+
+		return output.Interface()
+	case reflect.Map:
+		if reflected.IsNil() || reflected.Type().Key().Kind() != reflect.String {
+			return value
+		}
+
+		output := reflect.MakeMapWithSize(reflected.Type(), reflected.Len())
+		elementType := reflected.Type().Elem()
+		mask := reflect.ValueOf(redactor.mask)
+
+		for _, key := range reflected.MapKeys() {
+			// Values that cannot hold the mask are removed.
+			switch {
+			case !redactor.keys[normalizeRedactKey(key.String())]:
+				output.SetMapIndex(key, redactor.reflectedValue(reflected.MapIndex(key), elementType))
+			case mask.CanConvert(elementType):
+				output.SetMapIndex(key, mask.Convert(elementType))
+			}
+		}
+
+		return output.Interface()
+	default:
+		return value
+	}
+}
+
+// Redact a reflected element of a slice or map, and return it as a value of the element type.
+func (redactor *redactor) reflectedValue(element reflect.Value, elementType reflect.Type) reflect.Value {
+	redacted := redactor.redactValue(element.Interface())
+	if redacted == nil {
+		return reflect.Zero(elementType)
+	}
+
+	return reflect.ValueOf(redacted)
+}
+
+// Redact a JSON rendering.
+func (redactor *redactor) redactJSON(rendered map[string]interface{}) map[string]interface{} {
+	if rendered == nil {
+		return nil
+	}
+
+	output := make(map[string]interface{}, len(rendered))
+
+	for key, value := range rendered {
+		if value != nil && redactor.keys[normalizeRedactKey(key)] {
+			output[key] = redactor.mask
+
+			continue
+		}
+
+		output[key] = redactor.redactValue(value)
+	}
+
+	return output
+}
+
+// ==============================================================================================================
+// Messages.
+// ==============================================================================================================
+
+// redactedMessage redacts every rendering of a message.
+type redactedMessage struct {
+	message  quicklog.Message
+	redactor *redactor
+}
+
+func (message *redactedMessage) RenderTerminal() string {
+	return message.redactor.redactTerminal(message.message.RenderTerminal())
+}
+
+func (message *redactedMessage) RenderTerminalWidth(width int) string {
+	return message.redactor.redactTerminal(quicklog.RenderTerminalWidth(message.message, width))
+}
+
+func (message *redactedMessage) RenderJSON() map[string]interface{} {
+	return message.redactor.redactJSON(message.message.RenderJSON())
+}
+
+func (message *redactedMessage) RenderMarkdown() string {
+	return message.redactor.redactEscaped(quicklog.RenderMarkdown(message.message), unescapeMarkdown)
+}
+
+func (message *redactedMessage) RenderHTML() string {
+	return message.redactor.redactEscaped(quicklog.RenderHTML(message.message), unescapeHTML)
+}
+
+func (message *redactedMessage) loggedFrom() *callerFrame {
+	if carrier, ok := message.message.(interface{ loggedFrom() *callerFrame }); ok {
+		return carrier.loggedFrom()
+	}
+
+	return nil
+}
+
+func (message *redactedMessage) SectionTitle() string {
+	return redactSectionTitle(message.message, message.redactor)
+}
+
+func redactSectionTitle(message interface{}, redactor *redactor) string {
+	sectionMessage, ok := message.(quicklog.SectionMessage)
+	if !ok {
+		return ""
+	}
+
+	return redactor.redactString(sectionMessage.SectionTitle())
+}
+
+// redactedAnimatedMessage redacts every frame of an animated message.
+type redactedAnimatedMessage struct {
+	redactor *redactor
+
+	quicklog.AnimatedMessage
+}
+
+func (message *redactedAnimatedMessage) RunTerminal(ci bool) <-chan string {
+	source := message.AnimatedMessage.RunTerminal(ci)
+	output := make(chan string)
+
+	go func() {
+		defer close(output)
+
+		for frame := range source {
+			output <- message.redactor.redactTerminal(frame)
+		}
+	}()
+
+	return output
+}
+
+func (message *redactedAnimatedMessage) RunJSON() <-chan map[string]interface{} {
+	source := message.AnimatedMessage.RunJSON()
+	output := make(chan map[string]interface{})
+
+	go func() {
+		defer close(output)
+
+		for frame := range source {
+			output <- message.redactor.redactJSON(frame)
+		}
+	}()
+
+	return output
+}
+
+func (message *redactedAnimatedMessage) SectionTitle() string {
+	return redactSectionTitle(message.AnimatedMessage, message.redactor)
+}
+
+// Error forwards the error state to the redacted message, if it supports it.
+func (message *redactedAnimatedMessage) Error(err error) {
+	if failable, ok := message.AnimatedMessage.(interface{ Error(err error) }); ok {
+		failable.Error(err)
+	}
+}
+
+// ==============================================================================================================
+// Logger.
+// ==============================================================================================================
+
+type redactedLogger struct {
+	inner    quicklog.Logger
+	redactor *redactor
+
+	quicklog.Logger
+}
+
+func (logger *redactedLogger) Log(level quicklog.Level, message quicklog.Message) {
+	logger.inner.Log(level, &redactedMessage{message: message, redactor: logger.redactor})
+}
+
+func (logger *redactedLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	return logger.inner.LogAnimated(&redactedAnimatedMessage{AnimatedMessage: message, redactor: logger.redactor})
+}
+
+func (logger *redactedLogger) Interrupt(err error) {
+	if interruptible, ok := logger.inner.(quicklog.InterruptibleLogger); ok {
+		interruptible.Interrupt(err)
+	}
+}
+
+type RedactConfig struct {
+	// Patterns match secrets in the renderings of messages. Matches are replaced with Mask, or only their
+	// RedactSecretGroup group if they have one. Defaults to RedactPatternsDefault.
+	Patterns []*regexp.Regexp
+	// Keys of JSON renderings whose values are masked, wherever they are nested. Keys are compared regardless of
+	// their case, underscores and dashes. Defaults to RedactKeysDefault.
+	Keys []string
+	// Redactors are custom rules, applied to every string after the patterns.
+	Redactors []Redactor
+	// Mask replaces the secrets. Defaults to "[REDACTED]".
+	Mask string
+}
+
+var RedactConfigDefault = RedactConfig{
+	Patterns: RedactPatternsDefault,
+	Keys:     RedactKeysDefault,
+	Mask:     "[REDACTED]",
+}
+
+// NewRedacted wraps a Logger, and removes secrets from messages before they reach it. Rules apply to every rendering
+// of a message, including its nested messages, and to every frame of animated messages.
+//
+// Patterns and redactors run over the terminal, Markdown and HTML renderings, and over every string of the JSON
+// rendering. Patterns ignore the escaping of Markdown and HTML, and the styles of the terminal. Secrets wrapped over
+// multiple lines of the terminal are matched as a whole. Set Patterns or Keys to an empty slice to disable the default
+// rules.
+func NewRedacted(inner quicklog.Logger, config *RedactConfig) quicklog.Logger {
+	patterns := lo.Ternary(config.Patterns != nil, config.Patterns, RedactConfigDefault.Patterns)
+	keys := lo.Ternary(config.Keys != nil, config.Keys, RedactConfigDefault.Keys)
+
+	return &redactedLogger{
+		inner: inner,
+		redactor: &redactor{
+			patterns: patterns,
+			keys: lo.SliceToMap(keys, func(key string) (string, bool) {
+				return normalizeRedactKey(key), true
+			}),
+			redactors: config.Redactors,
+			mask:      lo.CoalesceOrEmpty(config.Mask, RedactConfigDefault.Mask),
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package loggers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var ErrInvalidRecording = errors.New("invalid recording")
+
+type ReplayConfig struct {
+	// Speed multiplies the playback speed. Defaults to 1 (real speed).
+	Speed float64
+	// MaxWait caps the delay between two events, to skip long idle periods. Zero means no limit.
+	MaxWait time.Duration
+}
+
+var ReplayConfigDefault = ReplayConfig{
+	Speed: 1,
+}
+
+// A single output to play, at a given time.
+type replayEvent struct {
+	time   float64
+	output string
+}
+
+// Parse a line of a recording. The format is detected from the first line, which is the header of asciicast
+// recordings.
+func parseReplayEvent(line []byte, format RecordFormat) (*replayEvent, error) {
+	if format == RecordFormatAsciicast {
+		var event []interface{}
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecording, err)
+		}
+
+		if len(event) != 3 {
+			return nil, fmt.Errorf("%w: unexpected asciicast event %s", ErrInvalidRecording, line)
+		}
+
+		eventTime, _ := event[0].(float64)
+		eventType, _ := event[1].(string)
+		eventData, _ := event[2].(string)
+
+		// Only output events are played.
+		if eventType != "o" {
+			return nil, nil
+		}
+
+		return &replayEvent{time: eventTime, output: eventData}, nil
+	}
+
+	var event RecordEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecording, err)
+	}
+
+	output := event.Output
+	if output == "" && event.Data != nil {
+		rendered, _ := json.Marshal(event.Data)
+		output = string(rendered)
+	}
+
+	return &replayEvent{time: event.Time, output: terminalLine(output)}, nil
+}
+
+// Replay plays a recording created with NewRecorder, writing each event to output at the time it was recorded.
+// Both RecordFormatJSONLines and RecordFormatAsciicast recordings are supported.
+func Replay(ctx context.Context, recording io.Reader, output io.Writer, config *ReplayConfig) error {
+	scanner := bufio.NewScanner(recording)
+	// Animated frames and nested messages may produce long lines.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	speed := config.Speed
+	if speed <= 0 {
+		speed = ReplayConfigDefault.Speed
+	}
+
+	format := RecordFormatJSONLines
+	first := true
+	lastTime := 0.0
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		if first {
+			first = false
+
+			var header asciicastHeader
+			if json.Unmarshal(line, &header) == nil && header.Version == 2 {
+				format = RecordFormatAsciicast
+				continue
+			}
+		}
+
+		event, err := parseReplayEvent(line, format)
+		if err != nil {
+			return err
+		}
+
+		if event == nil {
+			continue
+		}
+
+		wait := time.Duration((event.time - lastTime) / speed * float64(time.Second))
+		if config.MaxWait > 0 && wait > config.MaxWait {
+			wait = config.MaxWait
+		}
+
+		lastTime = event.time
+
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		if _, err := io.WriteString(output, event.output); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
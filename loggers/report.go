@@ -0,0 +1,198 @@
+package loggers
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// ReportLogger collects the messages of a session, and writes them as a single document once closed.
+type ReportLogger interface {
+	quicklog.Logger
+
+	// Close writes the document to the output of the report. Messages logged after Close are ignored.
+	Close() error
+}
+
+type reportFormat string
+
+const (
+	reportFormatMarkdown reportFormat = "markdown"
+	reportFormatHTML     reportFormat = "html"
+)
+
+const reportHTMLHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Report</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 2em auto; line-height: 1.5; }
+.quicklog-title { color: #0087ff; }
+.quicklog-title-description { color: #5f87af; }
+.quicklog-error { border-left: 4px solid #ff0000; background: #fff0f0; padding: 0.5em 1em; margin: 1em 0; }
+.quicklog-error-message { font-weight: bold; }
+.quicklog-loader-success { color: #00af00; }
+.quicklog-loader-error { color: #ff0000; }
+.quicklog-loader small { color: #808080; }
+pre { background: #f5f5f5; padding: 0.5em; overflow-x: auto; }
+.quicklog-diff-insert { color: #00af00; }
+.quicklog-diff-delete { color: #ff0000; }
+.quicklog-diff-header { color: #0087ff; }
+.quicklog-diff-equal { color: #808080; }
+.quicklog-tree { font-family: monospace; }
+.quicklog-dim { color: #808080; }
+.quicklog-group-children { border-left: 2px solid #d0d0d0; padding-left: 1em; }
+</style>
+</head>
+<body>
+`
+
+const reportHTMLFooter = `</body>
+</html>
+`
+
+// renderedFrame is a static message, built from the last frame rendered by an animated message.
+type renderedFrame struct {
+	terminal string
+
+	quicklog.Message
+}
+
+func (frame *renderedFrame) RenderTerminal() string {
+	return frame.terminal
+}
+
+type reportLogger struct {
+	output io.Writer
+	format reportFormat
+
+	// Rendered blocks of the document, in the order they were logged.
+	blocks []string
+	closed bool
+
+	animation animationState
+
+	mu sync.Mutex
+
+	quicklog.Logger
+}
+
+func (logger *reportLogger) render(message quicklog.Message) string {
+	if logger.format == reportFormatHTML {
+		return quicklog.RenderHTML(message)
+	}
+
+	return quicklog.RenderMarkdown(message)
+}
+
+// Render the final state of an animated message. Messages that do not support the format of the report are
+// rendered from their last frame.
+func (logger *reportLogger) renderAnimated(message quicklog.AnimatedMessage, lastFrame string) string {
+	if logger.format == reportFormatHTML {
+		if htmlMessage, ok := message.(quicklog.HTMLMessage); ok {
+			return htmlMessage.RenderHTML()
+		}
+	} else if markdownMessage, ok := message.(quicklog.MarkdownMessage); ok {
+		return markdownMessage.RenderMarkdown()
+	}
+
+	return logger.render(&renderedFrame{terminal: lastFrame})
+}
+
+func (logger *reportLogger) append(block string) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.closed || block == "" {
+		return
+	}
+
+	logger.blocks = append(logger.blocks, block)
+}
+
+func (logger *reportLogger) Log(level quicklog.Level, message quicklog.Message) {
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
+
+	logger.append(logger.render(message))
+
+	if level == quicklog.LevelFatal {
+		// Write the report before exiting, so the session is not lost.
+		_ = logger.Close()
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
+}
+
+func (logger *reportLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
+
+	// Intermediate frames are not part of the report, but they must be consumed for the message to progress.
+	// The CI mode prevents frames from containing erase sequences.
+	output := message.RunTerminal(true)
+
+	return logger.animation.start(message, func() {
+		var lastFrame string
+
+		for frame := range output {
+			if frame != "" {
+				lastFrame = frame
+			}
+		}
+
+		logger.append(logger.renderAnimated(message, lastFrame))
+	})
+}
+
+func (logger *reportLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *reportLogger) Close() error {
+	logger.animation.interrupt(nil)
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.closed {
+		return nil
+	}
+
+	logger.closed = true
+
+	document := strings.Join(logger.blocks, "")
+	if logger.format == reportFormatHTML {
+		document = reportHTMLHeader + document + reportHTMLFooter
+	}
+
+	_, err := io.WriteString(logger.output, document)
+
+	return err
+}
+
+// NewMarkdownReport creates a new Logger that collects the messages of a session, and writes them to output as a
+// single Markdown document when closed. Animated messages are reported in their final state.
+func NewMarkdownReport(output io.Writer) ReportLogger {
+	return &reportLogger{
+		output: output,
+		format: reportFormatMarkdown,
+	}
+}
+
+// NewHTMLReport creates a new Logger that collects the messages of a session, and writes them to output as a
+// single HTML document when closed. Animated messages are reported in their final state.
+func NewHTMLReport(output io.Writer) ReportLogger {
+	return &reportLogger{
+		output: output,
+		format: reportFormatHTML,
+	}
+}
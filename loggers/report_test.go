@@ -0,0 +1,105 @@
+package loggers_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Log a session with every kind of message, to a report.
+func reportSession(logger loggers.ReportLogger) {
+	logger.Log(quicklog.LevelInfo, messages.NewTitle("Deploy", "Deploying the application.", nil))
+
+	loader := messages.NewLoader("Building", &messages.LoaderConfigDefault)
+	cleaner := logger.LogAnimated(loader)
+	loader.Update("Compiling")
+	loader.Success("Built")
+	cleaner()
+
+	fake := &fakeAnimated{outTerm: make(chan string)}
+	cleaner = logger.LogAnimated(fake)
+	fake.outTerm <- "\x1b[1mframe 1\x1b[0m"
+	fake.outTerm <- "\x1b[1mframe 2\x1b[0m"
+	cleaner()
+
+	logger.Log(quicklog.LevelError, messages.NewError(errors.New("timeout"), "Deploy failed"))
+}
+
+func TestMarkdownReport(t *testing.T) {
+	output := new(bytes.Buffer)
+	logger := loggers.NewMarkdownReport(output)
+
+	reportSession(logger)
+
+	// Nothing is written until the report is closed.
+	require.Empty(t, output.String())
+
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close())
+
+	// Messages logged after the report is closed are ignored.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Ignored", nil))
+
+	require.Regexp(t, regexp.MustCompile(
+		`^## Deploy\n\n`+
+			`Deploying the application\.\n\n`+
+			`✓ Built \*\([^)]+\)\*\n\n`+
+			"```\nframe 2\n```\n\n"+
+			`> \[!CAUTION\]\n> \*\*Deploy failed\*\*\n>\n> timeout\n\n$`,
+	), output.String())
+}
+
+func TestHTMLReport(t *testing.T) {
+	output := new(bytes.Buffer)
+	logger := loggers.NewHTMLReport(output)
+
+	reportSession(logger)
+	require.NoError(t, logger.Close())
+
+	require.Regexp(t, regexp.MustCompile(
+		`(?s)^<!DOCTYPE html>\n.*<body>\n`+
+			`<h2 class="quicklog-title">Deploy</h2>\n`+
+			`<p class="quicklog-title-description">Deploying the application\.</p>\n`+
+			`<p class="quicklog-loader quicklog-loader-success">✓ Built <small>[^<]+</small></p>\n`+
+			`<pre>frame 2</pre>\n`+
+			`<div class="quicklog-error">\n`+
+			`<p class="quicklog-error-message">Deploy failed</p>\n`+
+			`<p class="quicklog-error-cause">timeout</p>\n`+
+			`</div>\n`+
+			`</body>\n</html>\n$`,
+	), output.String())
+}
+
+func TestReportFatal(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewMarkdownReport(os.Stdout)
+
+			loader := messages.NewLoader("Building", &messages.LoaderConfigDefault)
+			logger.LogAnimated(loader)
+
+			logger.Log(quicklog.LevelFatal, messages.NewBase("This is a fatal message.", nil))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			// The report is written before exiting, with the interrupted loader.
+			require.Regexp(
+				t,
+				regexp.MustCompile(
+					`^✗ interrupted by a fatal error \*\([^)]+\)\*\n\nThis is a fatal message\.\n\n$`,
+				),
+				res.STDOut,
+			)
+		},
+	})
+}
@@ -0,0 +1,299 @@
+package loggers
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layout of the timestamp in the name of backups. It sorts in chronological order.
+const backupTimeLayout = "2006-01-02T15-04-05.000000000"
+
+// rotatingFile is a file writer that moves the file to a backup once it grows too large or too old, and starts a new
+// one. Backups are compressed and pruned in the background.
+type rotatingFile struct {
+	path   string
+	config FileConfig
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	mu sync.Mutex
+
+	// Compression and pruning of backups run one at a time, in the background.
+	millMu   sync.Mutex
+	millWait sync.WaitGroup
+}
+
+// Open the file, or create it if it does not exist. Caller must hold the lock.
+func (writer *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(writer.path), 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(writer.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, writer.config.FileMode)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	writer.file = file
+	writer.size = info.Size()
+	writer.openedAt = time.Now()
+
+	return nil
+}
+
+// Close the current file, if any. Caller must hold the lock.
+func (writer *rotatingFile) closeFile() error {
+	if writer.file == nil {
+		return nil
+	}
+
+	err := writer.file.Close()
+	writer.file = nil
+
+	return err
+}
+
+// Return whether writing length more bytes requires a new file. Empty files are never rotated. Caller must hold
+// the lock.
+func (writer *rotatingFile) shouldRotate(length int) bool {
+	if writer.size == 0 {
+		return false
+	}
+
+	if writer.config.MaxSize > 0 && writer.size+int64(length) > writer.config.MaxSize {
+		return true
+	}
+
+	return writer.config.MaxAge > 0 && time.Since(writer.openedAt) >= writer.config.MaxAge
+}
+
+func (writer *rotatingFile) backupPrefix() (prefix, ext string) {
+	base := filepath.Base(writer.path)
+	ext = filepath.Ext(base)
+
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// Return a name for a new backup, that is not used by a previous one.
+func (writer *rotatingFile) backupPath(now time.Time) string {
+	prefix, ext := writer.backupPrefix()
+
+	for {
+		path := filepath.Join(filepath.Dir(writer.path), prefix+now.Format(backupTimeLayout)+ext)
+
+		_, err := os.Stat(path)
+		_, errCompressed := os.Stat(path + ".gz")
+
+		if os.IsNotExist(err) && os.IsNotExist(errCompressed) {
+			return path
+		}
+
+		now = now.Add(time.Nanosecond)
+	}
+}
+
+// Move the current file to a backup, and open a new one. Caller must hold the lock.
+func (writer *rotatingFile) rotate() error {
+	if err := writer.closeFile(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	if err := os.Rename(writer.path, writer.backupPath(time.Now())); err != nil && !os.IsNotExist(err) {
+		// Keep writing to the current file, rather than losing logs.
+		return fmt.Errorf("move log file to backup: %w", errors.Join(err, writer.open()))
+	}
+
+	if err := writer.open(); err != nil {
+		return err
+	}
+
+	writer.millWait.Add(1)
+
+	go func() {
+		defer writer.millWait.Done()
+
+		writer.mill()
+	}()
+
+	return nil
+}
+
+// Return the backups of the file, from the oldest to the newest.
+func (writer *rotatingFile) backups() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(writer.path))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, ext := writer.backupPrefix()
+
+	var backups []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimSuffix(name[len(prefix):], ".gz"), ext)
+		if _, err := time.Parse(backupTimeLayout, timestamp); err != nil {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(filepath.Dir(writer.path), name))
+	}
+
+	// The timestamp sorts in chronological order, regardless of the compression suffix.
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") < strings.TrimSuffix(backups[j], ".gz")
+	})
+
+	return backups, nil
+}
+
+// Compress a backup, and remove the original.
+func compressBackup(path string, mode os.FileMode) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	compressor := gzip.NewWriter(destination)
+
+	_, err = io.Copy(compressor, source)
+	err = errors.Join(err, compressor.Close(), destination.Close())
+
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// Compress the backups, if enabled, and remove the oldest ones beyond the limit.
+func (writer *rotatingFile) mill() {
+	writer.millMu.Lock()
+	defer writer.millMu.Unlock()
+
+	backups, err := writer.backups()
+	if err != nil {
+		return
+	}
+
+	if writer.config.MaxBackups > 0 && len(backups) > writer.config.MaxBackups {
+		for _, backup := range backups[:len(backups)-writer.config.MaxBackups] {
+			_ = os.Remove(backup)
+		}
+
+		backups = backups[len(backups)-writer.config.MaxBackups:]
+	}
+
+	if !writer.config.Compress {
+		return
+	}
+
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".gz") {
+			_ = compressBackup(backup, writer.config.FileMode)
+		}
+	}
+}
+
+func (writer *rotatingFile) Write(data []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if writer.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if writer.shouldRotate(len(data)) {
+		// A failed rotation still leaves a file to write to, if possible.
+		_ = writer.rotate()
+
+		if writer.file == nil {
+			return 0, os.ErrClosed
+		}
+	}
+
+	written, err := writer.file.Write(data)
+	writer.size += int64(written)
+
+	return written, err
+}
+
+// Rotate moves the current file to a backup, and starts a new one. Empty files are not rotated.
+func (writer *rotatingFile) Rotate() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if writer.file == nil {
+		return os.ErrClosed
+	}
+
+	if writer.size == 0 {
+		return nil
+	}
+
+	return writer.rotate()
+}
+
+// Reopen closes the file, and opens it again at its path. This picks up a new file, if the previous one was moved.
+func (writer *rotatingFile) Reopen() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if writer.file == nil {
+		return os.ErrClosed
+	}
+
+	if err := writer.closeFile(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	return writer.open()
+}
+
+// Close the file, and wait for the background tasks to complete.
+func (writer *rotatingFile) Close() error {
+	writer.mu.Lock()
+	err := writer.closeFile()
+	writer.mu.Unlock()
+
+	writer.millWait.Wait()
+
+	return err
+}
+
+func newRotatingFile(path string, config FileConfig) (*rotatingFile, error) {
+	writer := &rotatingFile{path: path, config: config}
+
+	if err := writer.open(); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
@@ -0,0 +1,370 @@
+package loggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// SampledLogger is a Logger that drops repetitive messages, and summarizes them at the end of each window.
+type SampledLogger interface {
+	quicklog.Logger
+
+	// Close stops the windows, and logs the summaries of the current one.
+	Close()
+	// Suppressed returns the number of messages dropped since the logger was created.
+	Suppressed() uint64
+}
+
+// SampleKeyFunc returns the key of a message. Messages with the same key are sampled together.
+type SampleKeyFunc func(level quicklog.Level, message quicklog.Message) string
+
+// SampleByContent keys messages by their level and content, so identical messages are sampled together.
+func SampleByContent(level quicklog.Level, message quicklog.Message) string {
+	content := message.RenderTerminal()
+	if content == "" {
+		rendered, _ := json.Marshal(message.RenderJSON())
+		content = string(rendered)
+	}
+
+	return string(level) + "\x00" + content
+}
+
+// RateLimit is a token bucket. Each message takes a token, and tokens are refilled at a constant rate.
+type RateLimit struct {
+	// Rate is the number of tokens refilled per second.
+	Rate float64
+	// Burst is the capacity of the bucket, so the number of messages that can be logged at once.
+	Burst int
+}
+
+type tokenBucket struct {
+	limit RateLimit
+
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst), updatedAt: time.Now()}
+}
+
+// Take a token, if one is available.
+func (bucket *tokenBucket) take(now time.Time) bool {
+	bucket.tokens = min(
+		float64(bucket.limit.Burst),
+		bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*bucket.limit.Rate,
+	)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+// ==============================================================================================================
+// Summaries.
+// ==============================================================================================================
+
+// samplingSummary reports the messages of a key that were dropped during a window. It renders as a base message,
+// with the last dropped message as its child.
+type samplingSummary struct {
+	level      quicklog.Level
+	suppressed uint64
+	// Summaries are logged from the background goroutine of the windows: they are attributed to the caller of their
+	// sample.
+	caller *callerFrame
+
+	quicklog.Message
+}
+
+func newSamplingSummary(level quicklog.Level, suppressed uint64, sample quicklog.Message) *samplingSummary {
+	return &samplingSummary{
+		level:      level,
+		suppressed: suppressed,
+		caller:     findMessageCaller(sample),
+		Message: messages.NewBase(
+			fmt.Sprintf("Suppressed %d similar %s.", suppressed, lo.Ternary(suppressed == 1, "message", "messages")),
+			sample,
+		),
+	}
+}
+
+func (summary *samplingSummary) loggedFrom() *callerFrame {
+	return summary.caller
+}
+
+func (summary *samplingSummary) RenderJSON() map[string]interface{} {
+	return lo.Assign(summary.Message.RenderJSON(), map[string]interface{}{"suppressed": summary.suppressed})
+}
+
+func (summary *samplingSummary) RenderTerminalWidth(width int) string {
+	return quicklog.RenderTerminalWidth(summary.Message, width)
+}
+
+func (summary *samplingSummary) RenderMarkdown() string {
+	return quicklog.RenderMarkdown(summary.Message)
+}
+
+func (summary *samplingSummary) RenderHTML() string {
+	return quicklog.RenderHTML(summary.Message)
+}
+
+// samplingKey counts the messages of a key during the current window.
+type samplingKey struct {
+	seen       int
+	suppressed uint64
+
+	// Last dropped message, used as a sample in the summary.
+	sample      quicklog.Message
+	sampleLevel quicklog.Level
+}
+
+// ==============================================================================================================
+// Logger.
+// ==============================================================================================================
+
+type sampledLogger struct {
+	inner  quicklog.Logger
+	policy SamplingPolicy
+
+	buckets map[quicklog.Level]*tokenBucket
+	keys    map[string]*samplingKey
+	// Order in which keys were first seen during the window, so summaries are logged in a stable order.
+	order []string
+
+	// Summaries cannot be logged while an animated message is running. They are postponed to the next window.
+	animated   bool
+	suppressed uint64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu sync.Mutex
+	// Serializes the summaries and the start of animated messages, so an animation cannot start while summaries are
+	// being logged.
+	logMu sync.Mutex
+
+	quicklog.Logger
+}
+
+// Decide whether a message is logged, and count it otherwise. Caller must hold the lock.
+func (logger *sampledLogger) allow(level quicklog.Level, message quicklog.Message) bool {
+	key := logger.policy.Key(level, message)
+
+	state, ok := logger.keys[key]
+	if !ok {
+		state = &samplingKey{}
+		logger.keys[key] = state
+		logger.order = append(logger.order, key)
+	}
+
+	state.seen++
+
+	allowed := logger.policy.First <= 0 || state.seen <= logger.policy.First ||
+		(logger.policy.Thereafter > 0 && (state.seen-logger.policy.First)%logger.policy.Thereafter == 0)
+
+	if bucket, ok := logger.buckets[level]; ok && allowed {
+		allowed = bucket.take(time.Now())
+	}
+
+	if !allowed {
+		state.suppressed++
+		// The sample is summarized from another goroutine, which cannot find its caller.
+		state.sample = withCaller(message, findMessageCaller(message))
+		state.sampleLevel = level
+		logger.suppressed++
+	}
+
+	return allowed
+}
+
+// End the current window, and return the summaries of its dropped messages. Caller must hold the lock.
+func (logger *sampledLogger) endWindow() []*samplingSummary {
+	summaries := make([]*samplingSummary, 0)
+
+	for _, key := range logger.order {
+		if state := logger.keys[key]; state.suppressed > 0 {
+			summaries = append(summaries, newSamplingSummary(state.sampleLevel, state.suppressed, state.sample))
+		}
+	}
+
+	logger.keys = make(map[string]*samplingKey)
+	logger.order = nil
+
+	return summaries
+}
+
+// Log the summaries of the current window, and start a new one. The window is extended if an animated message is
+// running.
+func (logger *sampledLogger) flush() {
+	logger.logMu.Lock()
+	defer logger.logMu.Unlock()
+
+	logger.mu.Lock()
+	if logger.animated {
+		logger.mu.Unlock()
+		return
+	}
+
+	summaries := logger.endWindow()
+	logger.mu.Unlock()
+
+	for _, summary := range summaries {
+		logger.inner.Log(summary.level, summary)
+	}
+}
+
+func (logger *sampledLogger) run() {
+	defer close(logger.done)
+
+	ticker := time.NewTicker(logger.policy.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			logger.flush()
+		case <-logger.stop:
+			return
+		}
+	}
+}
+
+func (logger *sampledLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages are never dropped. Summaries are logged first, since the program exits.
+	if level == quicklog.LevelFatal {
+		logger.flush()
+		logger.inner.Log(level, message)
+
+		return
+	}
+
+	logger.mu.Lock()
+	allowed := logger.allow(level, message)
+	logger.mu.Unlock()
+
+	if allowed {
+		logger.inner.Log(level, message)
+	}
+}
+
+func (logger *sampledLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	// Wait for the summaries being logged, if any.
+	logger.logMu.Lock()
+
+	logger.mu.Lock()
+	logger.animated = true
+	logger.mu.Unlock()
+
+	cleaner := logger.inner.LogAnimated(message)
+
+	logger.logMu.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		cleaner()
+
+		once.Do(func() {
+			logger.mu.Lock()
+			logger.animated = false
+			logger.mu.Unlock()
+		})
+	}
+}
+
+func (logger *sampledLogger) Interrupt(err error) {
+	if interruptible, ok := logger.inner.(quicklog.InterruptibleLogger); ok {
+		interruptible.Interrupt(err)
+
+		logger.mu.Lock()
+		logger.animated = false
+		logger.mu.Unlock()
+	}
+}
+
+func (logger *sampledLogger) Close() {
+	logger.closeOnce.Do(func() {
+		close(logger.stop)
+		<-logger.done
+
+		logger.flush()
+	})
+}
+
+func (logger *sampledLogger) Suppressed() uint64 {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	return logger.suppressed
+}
+
+type SamplingPolicy struct {
+	// Limits are token buckets, per level. Messages are dropped while the bucket of their level is empty. Levels
+	// without a limit are not rate limited.
+	Limits map[quicklog.Level]RateLimit
+
+	// First messages of each key are logged during a window, then one every Thereafter messages. If First is zero,
+	// messages are not sampled. If Thereafter is zero, every message after the first ones is dropped.
+	First      int
+	Thereafter int
+	// Window is the period after which the counts of messages are reset, and summaries are logged. Defaults to a
+	// minute.
+	Window time.Duration
+	// Key of messages. Defaults to SampleByContent.
+	Key SampleKeyFunc
+}
+
+var SamplingPolicyDefault = SamplingPolicy{
+	First:      10,
+	Thereafter: 100,
+	Window:     time.Minute,
+	Key:        SampleByContent,
+}
+
+// NewSampled wraps a Logger, and drops repetitive messages before they reach it. At the end of each window, a
+// summary is logged for every key with dropped messages, at the level of those messages:
+//
+//	Suppressed 37 similar messages.
+//	Connection refused, retrying...
+//
+// The JSON rendering of summaries has a "suppressed" key with the number of dropped messages. Fatal messages and
+// animated messages are never dropped.
+//
+// Close must be called before the program exits, so the summaries of the last window are logged.
+func NewSampled(inner quicklog.Logger, policy *SamplingPolicy) SampledLogger {
+	logger := &sampledLogger{
+		inner: inner,
+		policy: SamplingPolicy{
+			Limits:     policy.Limits,
+			First:      policy.First,
+			Thereafter: policy.Thereafter,
+			Window:     lo.CoalesceOrEmpty(policy.Window, SamplingPolicyDefault.Window),
+			Key:        lo.Ternary(policy.Key != nil, policy.Key, SamplingPolicyDefault.Key),
+		},
+		buckets: make(map[quicklog.Level]*tokenBucket),
+		keys:    make(map[string]*samplingKey),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for level, limit := range policy.Limits {
+		logger.buckets[level] = newTokenBucket(limit)
+	}
+
+	go logger.run()
+
+	return logger
+}
@@ -0,0 +1,271 @@
+package loggers_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func TestSampledLog(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{First: 2, Thereafter: 3, Window: time.Hour})
+
+	for range 10 {
+		logger.Log(quicklog.LevelWarning, messages.NewBase("Connection refused, retrying."))
+	}
+
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Disk is full."))
+
+	// Messages of the same content are sampled together, other messages are not affected.
+	require.Equal(t, []string{
+		"Connection refused, retrying.",
+		"Connection refused, retrying.",
+		"Connection refused, retrying.",
+		"Connection refused, retrying.",
+		"Disk is full.",
+	}, inner.messages())
+	require.Equal(t, uint64(6), logger.Suppressed())
+
+	// The summaries of the window are logged on close.
+	logger.Close()
+
+	require.Len(t, inner.logged, 6)
+	summary := inner.logged[5]
+
+	require.Equal(t, quicklog.LevelWarning, summary.level)
+	require.Equal(
+		t,
+		"Suppressed 6 similar messages.                                                  \n"+
+			"Connection refused, retrying.                                                   \n",
+		summary.message.RenderTerminal(),
+	)
+	require.Equal(t, map[string]interface{}{
+		"kind":           "base",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "Suppressed 6 similar messages.",
+		"suppressed":     uint64(6),
+		"data":           map[string]interface{}{"kind": "base", "message": "Connection refused, retrying."},
+	}, summary.message.RenderJSON())
+}
+
+func TestSampledRateLimit(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{
+		Limits: map[quicklog.Level]loggers.RateLimit{
+			quicklog.LevelWarning: {Rate: 0.001, Burst: 2},
+		},
+		Window: time.Hour,
+	})
+
+	for i := range 4 {
+		logger.Log(quicklog.LevelWarning, messages.NewBase(fmt.Sprintf("warning %d", i)))
+		logger.Log(quicklog.LevelInfo, messages.NewBase(fmt.Sprintf("info %d", i)))
+	}
+
+	// Levels without a limit are not rate limited.
+	require.Equal(t, []string{
+		"warning 0", "info 0", "warning 1", "info 1", "info 2", "info 3",
+	}, inner.messages())
+
+	logger.Close()
+
+	require.Equal(t, []string{
+		"warning 0", "info 0", "warning 1", "info 1", "info 2", "info 3",
+		"Suppressed 1 similar message.", "Suppressed 1 similar message.",
+	}, inner.messages())
+}
+
+func TestSampledKey(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{
+		First:  1,
+		Window: time.Hour,
+		Key: func(_ quicklog.Level, _ quicklog.Message) string {
+			return "retry"
+		},
+	})
+
+	for i := range 5 {
+		logger.Log(quicklog.LevelWarning, messages.NewBase(fmt.Sprintf("attempt %d failed", i)))
+	}
+
+	logger.Close()
+
+	require.Equal(t, []string{"attempt 0 failed", "Suppressed 4 similar messages."}, inner.messages())
+	// The last dropped message is used as a sample.
+	require.Equal(
+		t,
+		"attempt 4 failed",
+		inner.logged[1].message.RenderJSON()["data"].(map[string]interface{})["message"],
+	)
+}
+
+func TestSampledWindow(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{First: 1, Window: 50 * time.Millisecond})
+	defer logger.Close()
+
+	for range 3 {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+	}
+
+	require.Eventually(t, func() bool {
+		return len(inner.messages()) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"Polling.", "Suppressed 2 similar messages."}, inner.messages())
+
+	// Counts are reset with each window.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+	require.Equal(t, "Polling.", inner.messages()[2])
+}
+
+// lockedBuffer is a buffer that can be written by a background goroutine while it is read.
+type lockedBuffer struct {
+	buffer bytes.Buffer
+	mu     sync.Mutex
+}
+
+func (buffer *lockedBuffer) Write(data []byte) (int, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.buffer.Write(data)
+}
+
+func (buffer *lockedBuffer) String() string {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.buffer.String()
+}
+
+func TestSampledCaller(t *testing.T) {
+	output := new(lockedBuffer)
+	logger := loggers.NewSampled(
+		loggers.NewZerologWithConfig(zerolog.New(output), &loggers.ZerologConfig{Caller: true}),
+		&loggers.SamplingPolicy{First: 1, Window: 50 * time.Millisecond},
+	)
+	defer logger.Close()
+
+	for range 2 {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+	}
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "Suppressed")
+	}, time.Second, 10*time.Millisecond)
+
+	// Summaries are attributed to the code that logged the dropped message.
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Regexp(t, regexp.MustCompile(`"caller":"[^"]+/loggers/sampled_test\.go:\d+"`), lines[1])
+}
+
+func TestSampledAnimated(t *testing.T) {
+	inner := &fakeLogger{}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{First: 1, Window: 20 * time.Millisecond})
+	defer logger.Close()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+
+	// Summaries are not logged while an animated message is running.
+	cleaner := logger.LogAnimated(&fakeAnimated{})
+
+	require.Never(t, func() bool {
+		return len(inner.messages()) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	cleaner()
+
+	require.Eventually(t, func() bool {
+		return len(inner.messages()) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+// animatedFakeLogger is a fakeLogger that records whether an animated message was started.
+type animatedFakeLogger struct {
+	*fakeLogger
+
+	started atomic.Bool
+}
+
+func (fake *animatedFakeLogger) LogAnimated(_ quicklog.AnimatedMessage) func() {
+	fake.started.Store(true)
+
+	return func() {}
+}
+
+func TestSampledAnimatedDuringSummary(t *testing.T) {
+	gate := make(chan struct{})
+	inner := &animatedFakeLogger{fakeLogger: &fakeLogger{gate: gate}}
+	logger := loggers.NewSampled(inner, &loggers.SamplingPolicy{First: 1, Window: 20 * time.Millisecond})
+	defer logger.Close()
+	// Release the summary if the test fails, so the logger can be closed.
+	defer close(gate)
+
+	go func() { gate <- struct{}{} }()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Polling."))
+
+	// Let the window end: its summary waits for the gate.
+	time.Sleep(100 * time.Millisecond)
+
+	cleaners := make(chan func(), 1)
+
+	go func() { cleaners <- logger.LogAnimated(&fakeAnimated{}) }()
+
+	// Animated messages do not start while a summary is being logged.
+	require.Never(t, inner.started.Load, 100*time.Millisecond, 10*time.Millisecond)
+
+	gate <- struct{}{}
+
+	require.Eventually(t, inner.started.Load, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"Polling.", "Suppressed 1 similar message."}, inner.messages())
+
+	(<-cleaners)()
+}
+
+func TestSampledFatal(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewSampled(loggers.NewTerminal(), &loggers.SamplingPolicy{First: 1, Window: time.Hour})
+
+			logger.Log(quicklog.LevelError, messages.NewBase("Connection refused."))
+			logger.Log(quicklog.LevelError, messages.NewBase("Connection refused."))
+			logger.Log(quicklog.LevelFatal, messages.NewBase("Giving up."))
+
+			// Unreachable code.
+			os.Exit(0)
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			// Summaries are logged before the program exits.
+			require.Equal(
+				t,
+				"Connection refused.                                                             \n"+
+					"Suppressed 1 similar message.                                                   \n"+
+					"Connection refused.                                                             \n"+
+					"Giving up.                                                                      \n",
+				res.STDErr,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
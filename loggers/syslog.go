@@ -0,0 +1,417 @@
+package loggers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// SyslogFacility identifies the kind of program that sends a message, as defined by RFC 5424.
+type SyslogFacility int
+
+// The kernel facility (0) is reserved to the kernel, and cannot be used.
+const (
+	SyslogFacilityUser     SyslogFacility = 1
+	SyslogFacilityMail     SyslogFacility = 2
+	SyslogFacilityDaemon   SyslogFacility = 3
+	SyslogFacilityAuth     SyslogFacility = 4
+	SyslogFacilitySyslog   SyslogFacility = 5
+	SyslogFacilityLPR      SyslogFacility = 6
+	SyslogFacilityNews     SyslogFacility = 7
+	SyslogFacilityUUCP     SyslogFacility = 8
+	SyslogFacilityCron     SyslogFacility = 9
+	SyslogFacilityAuthPriv SyslogFacility = 10
+	SyslogFacilityFTP      SyslogFacility = 11
+	SyslogFacilityLocal0   SyslogFacility = 16
+	SyslogFacilityLocal1   SyslogFacility = 17
+	SyslogFacilityLocal2   SyslogFacility = 18
+	SyslogFacilityLocal3   SyslogFacility = 19
+	SyslogFacilityLocal4   SyslogFacility = 20
+	SyslogFacilityLocal5   SyslogFacility = 21
+	SyslogFacilityLocal6   SyslogFacility = 22
+	SyslogFacilityLocal7   SyslogFacility = 23
+)
+
+// Severities of RFC 5424, for the levels of quicklog.
+var syslogSeverities = map[quicklog.Level]int{
+	quicklog.LevelFatal:   2, // Critical.
+	quicklog.LevelError:   3, // Error.
+	quicklog.LevelWarning: 4, // Warning.
+	quicklog.LevelInfo:    6, // Informational.
+}
+
+// Sockets of the local syslog daemon, depending on the system.
+var syslogLocalSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// Layout of the TIMESTAMP field. RFC 5424 allows at most 6 digits for fractions of seconds.
+const syslogTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// SyslogLogger is a Logger that sends messages to a syslog server.
+type SyslogLogger interface {
+	quicklog.Logger
+
+	// Close the connection to the server. Messages logged after Close are ignored.
+	Close() error
+}
+
+// ==============================================================================================================
+// Formatting.
+// ==============================================================================================================
+
+// Restrict a header field to printable US-ASCII, and to its maximum length. Empty fields are replaced with the
+// NILVALUE.
+func syslogHeaderField(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	return lo.CoalesceOrEmpty(value, "-")
+}
+
+// Restrict a parameter name of the structured data to the characters allowed by RFC 5424.
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	if len(name) > 32 {
+		name = name[:32]
+	}
+
+	return name
+}
+
+var syslogParamValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// Render the JSON output of a message as an SD-ELEMENT. It returns the NILVALUE if the message has no JSON output, or
+// if no identifier is configured.
+func renderSyslogStructuredData(id string, rendered map[string]interface{}) string {
+	if id == "" {
+		return "-"
+	}
+
+	params := make(map[string]string)
+	for name, value := range flattenJSON(rendered) {
+		params[syslogParamName(name)] = value
+	}
+
+	if len(params) == 0 {
+		return "-"
+	}
+
+	names := lo.Keys(params)
+	sort.Strings(names)
+
+	output := "[" + id
+
+	for _, name := range names {
+		output += " " + name + `="` + syslogParamValueEscaper.Replace(params[name]) + `"`
+	}
+
+	return output + "]"
+}
+
+// ==============================================================================================================
+// Logger.
+// ==============================================================================================================
+
+type syslogLogger struct {
+	config SyslogConfig
+
+	conn   net.Conn
+	closed bool
+	mu     sync.Mutex
+
+	animation animationState
+
+	quicklog.Logger
+}
+
+// Dial the configured server, or the first local socket available.
+func (logger *syslogLogger) dial() (net.Conn, error) {
+	if logger.config.Network != "" {
+		conn, err := net.DialTimeout(logger.config.Network, logger.config.Address, logger.config.DialTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("dial syslog server: %w", err)
+		}
+
+		return conn, nil
+	}
+
+	var errs []error
+
+	for _, socket := range syslogLocalSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.DialTimeout(network, socket, logger.config.DialTimeout)
+			if err == nil {
+				return conn, nil
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	return nil, fmt.Errorf("dial local syslog socket: %w", errors.Join(errs...))
+}
+
+// Return the connection to the server, and open it again if it was lost. The server is dialed without holding the
+// lock, so a slow or unreachable server does not block the other goroutines that log. It returns nil if the logger
+// is closed, or if the server cannot be reached.
+func (logger *syslogLogger) connection() net.Conn {
+	logger.mu.Lock()
+	conn, closed := logger.conn, logger.closed
+	logger.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	if conn != nil {
+		return conn
+	}
+
+	conn, err := logger.dial()
+	if err != nil {
+		return nil
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	// The logger was closed, or another goroutine connected, while dialing.
+	if logger.closed || logger.conn != nil {
+		_ = conn.Close()
+
+		return lo.Ternary(logger.closed, nil, logger.conn)
+	}
+
+	logger.conn = conn
+
+	return conn
+}
+
+// Frame a message for the transport of a connection. Messages sent over TCP are prefixed with their length (octet
+// counting, RFC 6587). Local daemons read unix stream sockets line by line, so messages sent over them end with a
+// newline. Datagrams hold a single message, and are not framed.
+func syslogFrame(conn net.Conn, message string) string {
+	switch conn.LocalAddr().Network() {
+	case "tcp", "tcp4", "tcp6":
+		return strconv.Itoa(len(message)) + " " + message
+	case "unix":
+		return message + "\n"
+	default:
+		return message
+	}
+}
+
+func (logger *syslogLogger) format(level quicklog.Level, text string, rendered map[string]interface{}) string {
+	priority := int(logger.config.Facility)*8 + lo.ValueOr(syslogSeverities, level, 6)
+
+	output := fmt.Sprintf(
+		"<%d>1 %s %s %s %s - %s",
+		priority,
+		time.Now().Format(syslogTimeLayout),
+		logger.config.Hostname,
+		logger.config.AppName,
+		logger.config.ProcID,
+		renderSyslogStructuredData(logger.config.StructuredDataID, rendered),
+	)
+
+	if text != "" {
+		output += " " + text
+	}
+
+	return output
+}
+
+// Send a message to the server. If the connection was lost, it is opened again once, before giving up on the
+// message.
+func (logger *syslogLogger) send(message string) {
+	for attempt := 0; attempt < 2; attempt++ {
+		conn := logger.connection()
+		if conn == nil {
+			return
+		}
+
+		// Writes are serialized, so messages sent over a stream are not interleaved. A server that stops reading
+		// must not block the program: the write gives up after DialTimeout, and the connection is opened again.
+		logger.mu.Lock()
+
+		err := conn.SetWriteDeadline(time.Now().Add(logger.config.DialTimeout))
+		if err == nil {
+			_, err = conn.Write([]byte(syslogFrame(conn, message)))
+		}
+
+		if err != nil && logger.conn == conn {
+			_ = conn.Close()
+			logger.conn = nil
+		}
+		logger.mu.Unlock()
+
+		if err == nil {
+			return
+		}
+	}
+}
+
+func (logger *syslogLogger) write(level quicklog.Level, message quicklog.Message) {
+	text := renderPlainText(message.RenderTerminal())
+	rendered := message.RenderJSON()
+
+	if text == "" && rendered == nil {
+		return
+	}
+
+	logger.send(logger.format(level, text, rendered))
+}
+
+func (logger *syslogLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so its final state is logged.
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
+
+	logger.write(level, message)
+
+	if level == quicklog.LevelFatal {
+		_ = logger.Close()
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
+}
+
+func (logger *syslogLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
+
+	// The JSON output of animated messages only changes on meaningful transitions.
+	output := message.RunJSON()
+
+	return logger.animation.start(message, func() {
+		for frame := range output {
+			if frame == nil {
+				continue
+			}
+
+			// The message field is the closest to a text version of the frame.
+			text, _ := frame["message"].(string)
+			logger.send(logger.format(quicklog.LevelInfo, text, frame))
+		}
+	})
+}
+
+func (logger *syslogLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *syslogLogger) Close() error {
+	logger.animation.interrupt(nil)
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.closed {
+		return nil
+	}
+
+	logger.closed = true
+
+	if logger.conn == nil {
+		return nil
+	}
+
+	return logger.conn.Close()
+}
+
+type SyslogConfig struct {
+	// Network is the transport used to reach the server: "udp", "tcp", "unix" or "unixgram". If empty, messages are
+	// sent to the local syslog daemon, and Address is ignored.
+	Network string
+	// Address of the server, for example "localhost:514", or the path of a unix socket.
+	Address string
+	// Facility of the messages. Defaults to SyslogFacilityUser.
+	Facility SyslogFacility
+
+	// Hostname of the messages. Defaults to the name of the host.
+	Hostname string
+	// AppName of the messages. Defaults to the name of the executable.
+	AppName string
+	// ProcID of the messages. Defaults to the process ID.
+	ProcID string
+	// StructuredDataID is the identifier of the structured data element, which holds the JSON output of messages.
+	// RFC 5424 reserves identifiers without an "@" to IANA: custom identifiers have the form "name@<enterprise
+	// number>", with the private enterprise number of your organization. If empty, messages have no structured data.
+	StructuredDataID string
+
+	// DialTimeout is the maximum time to wait for a connection, or for a message to be written. Defaults to 5
+	// seconds.
+	DialTimeout time.Duration
+}
+
+var SyslogConfigDefault = SyslogConfig{
+	Facility:    SyslogFacilityUser,
+	DialTimeout: 5 * time.Second,
+}
+
+// NewSyslog creates a new Logger that sends RFC 5424 messages to a syslog server.
+//
+// The text of each message is its terminal rendering, without styles. If a StructuredDataID is configured, the JSON
+// output of the message is attached as structured data, with nested keys flattened (data.message). Levels map to the
+// critical, error, warning and informational severities.
+//
+// If the connection is lost, it is opened again on the next message.
+func NewSyslog(config *SyslogConfig) (SyslogLogger, error) {
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	logger := &syslogLogger{
+		config: SyslogConfig{
+			Network:          config.Network,
+			Address:          config.Address,
+			Facility:         lo.CoalesceOrEmpty(config.Facility, SyslogConfigDefault.Facility),
+			Hostname:         syslogHeaderField(hostname, 255),
+			AppName:          syslogHeaderField(lo.CoalesceOrEmpty(config.AppName, filepath.Base(os.Args[0])), 48),
+			ProcID:           syslogHeaderField(lo.CoalesceOrEmpty(config.ProcID, strconv.Itoa(os.Getpid())), 128),
+			StructuredDataID: syslogParamName(config.StructuredDataID),
+			DialTimeout:      lo.CoalesceOrEmpty(config.DialTimeout, SyslogConfigDefault.DialTimeout),
+		},
+	}
+
+	conn, err := logger.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.conn = conn
+
+	return logger, nil
+}
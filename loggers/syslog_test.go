@@ -0,0 +1,434 @@
+package loggers_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+var syslogConfig = loggers.SyslogConfig{
+	Hostname: "host",
+	AppName:  "app",
+	ProcID:   "42",
+	// Enterprise number reserved for documentation (RFC 5612).
+	StructuredDataID: "quicklog@32473",
+}
+
+// Receive the datagrams sent to a local listener.
+func listenSyslogPackets(t *testing.T, network, address string) (net.Addr, <-chan string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	received := make(chan string, 16)
+
+	go func() {
+		buffer := make([]byte, 64*1024)
+
+		for {
+			n, _, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			received <- string(buffer[:n])
+		}
+	}()
+
+	return conn.LocalAddr(), received
+}
+
+func receiveSyslog(t *testing.T, received <-chan string) string {
+	t.Helper()
+
+	select {
+	case message := <-received:
+		return message
+	case <-time.After(time.Second):
+		require.FailNow(t, "no message received")
+		return ""
+	}
+}
+
+// Match the header of a message, followed by the structured data and the text.
+func syslogPattern(priority int, rest string) *regexp.Regexp {
+	return regexp.MustCompile(
+		`^<` + strconv.Itoa(priority) + `>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}(Z|[+-]\d{2}:\d{2}) ` +
+			`host app 42 - ` + rest + `$`,
+	)
+}
+
+func TestSyslogLevels(t *testing.T) {
+	addr, received := listenSyslogPackets(t, "udp", "127.0.0.1:0")
+
+	config := syslogConfig
+	config.Network = "udp"
+	config.Address = addr.String()
+	config.Facility = loggers.SyslogFacilityLocal0
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	testCases := []struct {
+		name string
+
+		level    quicklog.Level
+		priority int
+	}{
+		{name: "Info", level: quicklog.LevelInfo, priority: 16*8 + 6},
+		{name: "Warning", level: quicklog.LevelWarning, priority: 16*8 + 4},
+		{name: "Error", level: quicklog.LevelError, priority: 16*8 + 3},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logger.Log(testCase.level, messages.NewBase("Hello world"))
+
+			require.Regexp(
+				t,
+				syslogPattern(testCase.priority, regexp.QuoteMeta(
+					`[quicklog@32473 kind="base" message="Hello world" schema_version="1"] Hello world`,
+				)),
+				receiveSyslog(t, received),
+			)
+		})
+	}
+}
+
+func TestSyslogStructuredData(t *testing.T) {
+	addr, received := listenSyslogPackets(t, "udp", "127.0.0.1:0")
+
+	config := syslogConfig
+	config.Network = "udp"
+	config.Address = addr.String()
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	t.Run("Nested", func(t *testing.T) {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(
+			`Say "hello" [world] \o/`,
+			messages.NewBase("First child"),
+			messages.NewTitle("Second child", "description"),
+		))
+
+		require.Regexp(t, syslogPattern(14, regexp.QuoteMeta(
+			`[quicklog@32473`+
+				` data.0.kind="base"`+
+				` data.0.message="First child"`+
+				` data.1.content="description"`+
+				` data.1.kind="title"`+
+				` data.1.message="Second child"`+
+				` kind="base"`+
+				` message="Say \"hello\" [world\] \\o/"`+
+				` schema_version="1"]`,
+		)+` (?s:.+)`), receiveSyslog(t, received))
+	})
+
+	t.Run("Multiline", func(t *testing.T) {
+		logger.Log(quicklog.LevelError, messages.NewError(errors.New("out of memory"), "Deploy failed"))
+
+		message := receiveSyslog(t, received)
+		require.Regexp(t, syslogPattern(11, regexp.QuoteMeta(
+			`[quicklog@32473`+
+				` error.chain.0.message="out of memory"`+
+				` error.chain.0.type="*errors.errorString"`+
+				` error.type="*errors.errorString"`+
+				` kind="error"`+
+				` message="Deploy failed"`+
+				` schema_version="1"]`,
+		)+` (?s:.+)`), message)
+		require.Contains(t, message, "Deploy failed\n")
+		require.Contains(t, message, "out of memory")
+		require.NotContains(t, message, "\x1b")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		logger.Log(quicklog.LevelInfo, messages.NewBase(""))
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Not empty"))
+
+		require.Contains(t, receiveSyslog(t, received), "Not empty")
+	})
+
+	t.Run("NoIdentifier", func(t *testing.T) {
+		noIDConfig := config
+		noIDConfig.StructuredDataID = ""
+
+		noIDLogger, err := loggers.NewSyslog(&noIDConfig)
+		require.NoError(t, err)
+
+		defer noIDLogger.Close()
+
+		noIDLogger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+		require.Regexp(t, syslogPattern(14, `- Hello world`), receiveSyslog(t, received))
+	})
+}
+
+func TestSyslogTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	config := syslogConfig
+	config.Network = "tcp"
+	config.Address = listener.Addr().String()
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("First message"))
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Second message"))
+	require.NoError(t, logger.Close())
+
+	// Messages logged after Close are ignored.
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Closed"))
+
+	reader := bufio.NewReader(conn)
+
+	// Each message is prefixed with its length, and a space.
+	for _, expect := range []string{"First message", "Second message"} {
+		length, err := reader.ReadString(' ')
+		require.NoError(t, err)
+
+		size, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+		require.NoError(t, err)
+
+		message := make([]byte, size)
+		_, err = io.ReadFull(reader, message)
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(string(message), "] "+expect), string(message))
+	}
+
+	_, err = reader.ReadByte()
+	require.Error(t, err)
+}
+
+// syslogLargeMessage is a message with a large text, that is cheap to render.
+type syslogLargeMessage string
+
+func (message syslogLargeMessage) RenderTerminal() string {
+	return string(message)
+}
+
+func (message syslogLargeMessage) RenderJSON() map[string]interface{} {
+	return nil
+}
+
+func TestSyslogWriteTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	config := syslogConfig
+	config.Network = "tcp"
+	config.Address = listener.Addr().String()
+	config.DialTimeout = 50 * time.Millisecond
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	// The server never reads: once the buffers of the connection are full, writes stall.
+	message := syslogLargeMessage(strings.Repeat("x", 1<<20))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 10 {
+			logger.Log(quicklog.LevelInfo, message)
+		}
+
+		_ = logger.Close()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "logging blocked on a stalled server")
+	}
+}
+
+func TestSyslogReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	config := syslogConfig
+	config.Network = "tcp"
+	config.Address = listener.Addr().String()
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	accepted := make(chan net.Conn, 1)
+
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	// The connection is opened again once a write fails.
+	require.Eventually(t, func() bool {
+		logger.Log(quicklog.LevelInfo, messages.NewBase("Reconnect"))
+		return len(accepted) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	reconnected := <-accepted
+	defer reconnected.Close()
+
+	require.NoError(t, reconnected.SetReadDeadline(time.Now().Add(time.Second)))
+
+	buffer := make([]byte, 1024)
+	n, err := reconnected.Read(buffer)
+	require.NoError(t, err)
+	require.Contains(t, string(buffer[:n]), "Reconnect")
+}
+
+func TestSyslogUnixgram(t *testing.T) {
+	// Unix socket paths are limited in length, so the default test directory may be too long.
+	dir, err := os.MkdirTemp("", "quicklog")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "log.sock")
+	_, received := listenSyslogPackets(t, "unixgram", socket)
+
+	config := syslogConfig
+	config.Network = "unixgram"
+	config.Address = socket
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world"))
+
+	require.Regexp(
+		t,
+		syslogPattern(14, regexp.QuoteMeta(
+			`[quicklog@32473 kind="base" message="Hello world" schema_version="1"] Hello world`,
+		)),
+		receiveSyslog(t, received),
+	)
+}
+
+func TestSyslogUnix(t *testing.T) {
+	dir, err := os.MkdirTemp("", "quicklog")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "log.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	config := syslogConfig
+	config.Network = "unix"
+	config.Address = socket
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("First message"))
+	logger.Log(quicklog.LevelWarning, messages.NewBase("Second message"))
+	require.NoError(t, logger.Close())
+
+	reader := bufio.NewReader(conn)
+
+	// Local daemons read one message per line.
+	for _, expect := range []string{"First message", "Second message"} {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.Regexp(t, regexp.MustCompile(`^<\d+>1 .+\] `+expect+"\n$"), line)
+	}
+}
+
+func TestSyslogAnimated(t *testing.T) {
+	addr, received := listenSyslogPackets(t, "udp", "127.0.0.1:0")
+
+	config := syslogConfig
+	config.Network = "udp"
+	config.Address = addr.String()
+
+	logger, err := loggers.NewSyslog(&config)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	logChan := make(chan map[string]interface{})
+	animated := &fakeAnimated{outJSON: logChan}
+
+	cleaner := logger.LogAnimated(animated)
+
+	logChan <- messages.NewBase("This is an animated message.").RenderJSON()
+	// Ignore empty renders.
+	logChan <- nil
+	logChan <- map[string]interface{}{"status": "running"}
+
+	cleaner()
+
+	require.Regexp(t, syslogPattern(14, regexp.QuoteMeta(
+		`[quicklog@32473 kind="base" message="This is an animated message." schema_version="1"]`+
+			` This is an animated message.`,
+	)), receiveSyslog(t, received))
+	require.Regexp(t, syslogPattern(14, regexp.QuoteMeta(
+		`[quicklog@32473 status="running"]`,
+	)), receiveSyslog(t, received))
+}
+
+func TestSyslogDialError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	// Nothing listens on this address anymore.
+	require.NoError(t, listener.Close())
+
+	config := syslogConfig
+	config.Network = "tcp"
+	config.Address = listener.Addr().String()
+
+	_, err = loggers.NewSyslog(&config)
+	require.Error(t, err)
+}
@@ -4,86 +4,296 @@ import (
 	"io"
 	"log"
 	"os"
-	"sync"
+	"time"
 
 	"github.com/a-novel-kit/quicklog"
 )
 
-const CIEnv = "CI"
+const CIEnv = quicklog.CIEnv
+
+// TerminalLogger is a Logger that prints messages to the terminal.
+type TerminalLogger interface {
+	quicklog.Logger
+
+	// Flush prints the summary of the repetitions of the last message, if any. With DedupSummary, it must be called
+	// before the program exits, or the summary of a trailing run of repeats is lost.
+	Flush()
+}
 
 type terminalLogger struct {
 	ci bool
+	// The CI service running the program, if it supports folding sections or annotations.
+	provider ciProvider
+
+	// Prints the prefixes of messages, if any is enabled.
+	gutter *gutter
+	// Collapses consecutive identical messages, if enabled.
+	dedup *dedupState
 
-	// True while an animated log is running. Prevents concurrent logs that cause management issues.
-	animated bool
+	animation animationState
 
 	quicklog.Logger
 }
 
+// Width available to messages, once the gutter is printed.
+func (logger *terminalLogger) width() int {
+	if logger.gutter == nil {
+		return quicklog.TermWidth
+	}
+
+	return quicklog.TermWidth - logger.gutter.width()
+}
+
+// Render a message for the terminal, within the space left by the gutter.
+func (logger *terminalLogger) render(message quicklog.Message) string {
+	if logger.gutter == nil {
+		return message.RenderTerminal()
+	}
+
+	return quicklog.RenderTerminalWidth(message, logger.width())
+}
+
+// Print the prefixes of a rendering, if any is enabled.
+func (logger *terminalLogger) decorate(level quicklog.Level, message quicklog.Message, rendered string) string {
+	if logger.gutter == nil {
+		return rendered
+	}
+
+	return logger.gutter.apply(
+		logger.gutter.prefix(level, time.Now(), logger.findCaller(message), logger.jobName()), rendered,
+	)
+}
+
+// Look for the caller of a message, only if it is printed.
+func (logger *terminalLogger) findCaller(message quicklog.Message) *callerFrame {
+	if logger.gutter == nil || !logger.gutter.config.Caller {
+		return nil
+	}
+
+	return findMessageCaller(message)
+}
+
+// Name the job logging a message, only if it is printed.
+func (logger *terminalLogger) jobName() string {
+	if logger.gutter == nil || logger.gutter.config.Job == nil {
+		return ""
+	}
+
+	return logger.gutter.config.Job()
+}
+
 func (logger *terminalLogger) getDestination(level quicklog.Level) io.Writer {
-	if level == quicklog.LevelError {
+	if level == quicklog.LevelError || level == quicklog.LevelFatal {
 		return os.Stderr
 	}
 
 	return os.Stdout
 }
 
-func (logger *terminalLogger) checkAnimationLock() {
-	if logger.animated {
-		log.New(os.Stderr, "", 0).Fatal("cannot log while an animated message is running")
+// Print the summary of the repetitions of the last message, if any, and stop tracking it. Caller must hold the lock
+// of the deduplication state.
+func (logger *terminalLogger) flushRepeats() {
+	if logger.dedup.mode == DedupSummary && logger.dedup.count > 1 {
+		log.New(logger.getDestination(logger.dedup.level), "", 0).Print(
+			logger.decorate(
+				logger.dedup.level, logger.dedup.message, renderRepeatSummary(logger.dedup.count, logger.width()),
+			),
+		)
 	}
+
+	logger.dedup.count = 0
 }
 
-func (logger *terminalLogger) Log(level quicklog.Level, message quicklog.Message) {
-	logger.checkAnimationLock()
+// Print a repetition of the last message, if the message is one. Caller must hold the lock of the deduplication
+// state.
+func (logger *terminalLogger) printRepeat(level quicklog.Level, message quicklog.Message, rendered string) bool {
+	// Fatal messages are always printed, as they explain why the program exits.
+	if level == quicklog.LevelFatal || !logger.dedup.repeats(level, rendered) {
+		return false
+	}
+
+	logger.dedup.count++
+	logger.dedup.message = message
+
+	if logger.dedup.mode == DedupLive {
+		output := logger.decorate(level, message, withRepeatCounter(rendered, logger.dedup.count, logger.width()))
+		log.New(logger.getDestination(level), "", 0).Print(logger.dedup.erase() + output)
+		logger.dedup.printed(output)
+	}
+
+	return true
+}
 
-	rendered := message.RenderTerminal()
+// Print a message. It returns false if the message has an empty rendering.
+func (logger *terminalLogger) print(level quicklog.Level, message quicklog.Message) bool {
+	rendered := logger.render(message)
 	if rendered == "" {
-		return
+		return false
+	}
+
+	if logger.dedup != nil {
+		logger.dedup.mu.Lock()
+		defer logger.dedup.mu.Unlock()
+
+		if logger.printRepeat(level, message, rendered) {
+			return true
+		}
+
+		logger.flushRepeats()
 	}
 
+	output := logger.decorate(level, message, rendered)
+	stdLogger := log.New(logger.getDestination(level), "", 0)
+
+	section, sectionStart := logger.provider.openSection(message)
+	if section != nil {
+		stdLogger.Print(sectionStart)
+	}
+
+	stdLogger.Print(output)
+
+	if section != nil {
+		stdLogger.Print(section.close())
+	}
+
+	// Annotations are read from the standard output.
+	if annotation := logger.provider.annotation(level, rendered); annotation != "" {
+		log.New(os.Stdout, "", 0).Print(annotation)
+	}
+
+	if logger.dedup != nil {
+		logger.dedup.track(level, message, rendered, output)
+	}
+
+	return true
+}
+
+func (logger *terminalLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so it is not left half-rendered.
 	if level == quicklog.LevelFatal {
-		log.New(os.Stderr, "", 0).Fatal(rendered)
+		logger.animation.interrupt(quicklog.ErrFatal)
+	}
+
+	if !logger.animation.checkAnimationLock() {
 		return
 	}
 
-	log.New(logger.getDestination(level), "", 0).Print(rendered)
+	if !logger.print(level, message) {
+		return
+	}
+
+	if level == quicklog.LevelFatal {
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
 }
 
 func (logger *terminalLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
-	logger.checkAnimationLock()
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
+	}
 
-	logger.animated = true
+	// Animated messages are never repeats of the last message.
+	if logger.dedup != nil {
+		logger.dedup.mu.Lock()
+		logger.flushRepeats()
+		logger.dedup.mu.Unlock()
+	}
 
-	waitGroup := sync.WaitGroup{}
-	waitGroup.Add(1)
+	// Subscribe before returning, so updates made right after this call are not missed.
+	output := message.RunTerminal(logger.ci)
 
-	cleaner := func() {
-		logger.animated = false
-		message.Close()
-		waitGroup.Wait()
-	}
+	// The section is titled after the initial state of the message.
+	section, sectionStart := logger.provider.openSection(message)
 
-	go func() {
-		defer waitGroup.Done()
+	// Frames are printed from a background goroutine: they are attributed to the code that started the animation.
+	caller, job := logger.findCaller(nil), logger.jobName()
 
+	return logger.animation.start(message, func() {
 		stdLogger := log.New(os.Stdout, "", 0)
 
-		for logMessage := range message.RunTerminal(logger.ci) {
+		if section != nil {
+			stdLogger.Print(sectionStart)
+		}
+
+		for logMessage := range output {
 			if logMessage == "" {
 				continue
 			}
 
+			if logger.gutter != nil {
+				logMessage = logger.gutter.apply(
+					logger.gutter.prefix(quicklog.LevelInfo, time.Now(), caller, job), logMessage,
+				)
+			}
+
 			stdLogger.Print(logMessage)
 		}
-	}()
 
-	return cleaner
+		if section != nil {
+			stdLogger.Print(section.close())
+		}
+	})
+}
+
+func (logger *terminalLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
+
+func (logger *terminalLogger) Flush() {
+	if logger.dedup == nil {
+		return
+	}
+
+	logger.dedup.mu.Lock()
+	defer logger.dedup.mu.Unlock()
+
+	logger.flushRepeats()
 }
 
+// TerminalConfig configures the terminal logger.
+//
+// Prefixes are printed in a left gutter of constant width, in the order of the fields below, so the lines of messages
+// stay aligned.
+type TerminalConfig struct {
+	// Timestamp prints the time each message was logged at.
+	Timestamp TimestampFormat
+	// Level prints the level of each message as a colored badge.
+	Level bool
+	// Caller prints the file and line of the code that logged each message.
+	Caller bool
+	// Job returns the name of the job or goroutine logging a message. GoroutineJob names jobs after their goroutine.
+	Job func() string
+
+	// Dedup collapses consecutive identical messages of the same level. Messages are compared on their rendering,
+	// without prefixes.
+	Dedup DedupMode
+}
+
+// TerminalConfigDefault prints every message, without prefixes.
+var TerminalConfigDefault = TerminalConfig{}
+
 // NewTerminal creates a new Logger that logs to the terminal.
+//
+// Under GitHub Actions and GitLab CI, titled sections and loaders are folded in the job logs. GitHub Actions also
+// surfaces errors and warnings as annotations of the job.
 func NewTerminal() quicklog.Logger {
-	return &terminalLogger{
-		ci: os.Getenv(CIEnv) == "true",
+	return NewTerminalWithConfig(&TerminalConfigDefault)
+}
+
+// NewTerminalWithConfig creates a new terminal logger, with a custom configuration.
+func NewTerminalWithConfig(config *TerminalConfig) TerminalLogger {
+	provider := detectCIProvider()
+
+	logger := &terminalLogger{
+		// Runners of the supported CI services do not always set the generic variable.
+		ci:       os.Getenv(CIEnv) == "true" || provider != ciProviderNone,
+		provider: provider,
+		gutter:   newGutter(*config),
 	}
+
+	if mode := resolveDedupMode(config.Dedup, logger.ci, provider); mode != DedupOff {
+		logger.dedup = &dedupState{mode: mode}
+	}
+
+	return logger
 }
@@ -1,6 +1,10 @@
 package loggers_test
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,7 +46,7 @@ func TestTerminalLog(t *testing.T) {
 				res.STDErr,
 			)
 		},
-		Env: []string{"CI=true"},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
 	})
 }
 
@@ -68,7 +72,7 @@ func TestTerminalLogAnimated(t *testing.T) {
 			// https://pkg.go.dev/log#Logger.Output
 			require.Equal(t, "This is an animated message.\nThis is another animated message.\n", res.STDOut)
 		},
-		Env: []string{"CI=true"},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
 	})
 }
 
@@ -97,6 +101,371 @@ func TestTerminalLogAnimatedNoConcurrentLog(t *testing.T) {
 			require.Equal(t, "This is an animated message.\n", res.STDOut)
 			require.Equal(t, "cannot log while an animated message is running\n", res.STDErr)
 		},
-		Env: []string{"CI=true"},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalInterrupt(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+
+			// No-op when no animated message is running.
+			logger.(quicklog.InterruptibleLogger).Interrupt(errors.New("interrupted"))
+
+			loader := messages.NewLoader("running", &messages.LoaderConfigDefault)
+			logger.LogAnimated(loader)
+
+			logger.(quicklog.InterruptibleLogger).Interrupt(errors.New("interrupted"))
+
+			// The animation lock is released.
+			logger.Log(quicklog.LevelInfo, messages.NewBase("This is an info message.", nil))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			require.Regexp(
+				t,
+				regexp.MustCompile(`✗ interrupted\s+.+\nThis is an info message.\s+\n$`),
+				res.STDOut,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalLogFatalHooks(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+
+			quicklog.OnFatal(func() {
+				fmt.Println("cleanup")
+			})
+
+			loader := messages.NewLoader("running", &messages.LoaderConfigDefault)
+			logger.LogAnimated(loader)
+
+			logger.Log(quicklog.LevelFatal, messages.NewBase("This is a fatal message.", nil))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			// The loader is closed in an error state, then the hooks run after the fatal message is printed.
+			require.Regexp(
+				t,
+				regexp.MustCompile(`✗ interrupted by a fatal error\s+.+\ncleanup\n$`),
+				res.STDOut,
+			)
+			require.Equal(
+				t,
+				"This is a fatal message.                                                        \n",
+				res.STDErr,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalGitHubActions(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+
+			logger.Log(quicklog.LevelInfo, messages.NewTitle("Deploy", "", messages.NewBase("Deploying the app")))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Not a section."))
+			logger.Log(quicklog.LevelWarning, messages.NewBase("Disk is 90% full."))
+			logger.Log(quicklog.LevelError, messages.NewError(fmt.Errorf("deploy: %w", errors.New("timeout")), ""))
+
+			loader := messages.NewLoader("Building", &messages.LoaderConfigDefault)
+			cleaner := logger.LogAnimated(loader)
+			loader.Success("Built")
+			cleaner()
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			require.Regexp(
+				t,
+				regexp.MustCompile(
+					`^::group::Deploy\n`+
+						`╭─+╮\n│ Deploy +│\n╰─+╯\nDeploying the app +\n`+
+						`::endgroup::\n`+
+						`Not a section\. +\n`+
+						`Disk is 90% full\. +\n`+
+						`::warning::Disk is 90%25 full\.\n`+
+						`::error::deploy%0A└── timeout\n`+
+						`::group::Building\n`+
+						`(.*\n)*`+
+						`::endgroup::\n$`,
+				),
+				res.STDOut,
+			)
+			require.Equal(
+				t,
+				"deploy                                                                          \n"+
+					"└── timeout                                                                     \n",
+				res.STDErr,
+			)
+		},
+		Env: []string{"CI=", "GITHUB_ACTIONS=true", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalGitLabCI(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+
+			logger.Log(quicklog.LevelInfo, messages.NewTitle("Deploy", "", messages.NewBase("Deploying the app")))
+			logger.Log(quicklog.LevelWarning, messages.NewBase("Disk is full."))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			// GitLab does not support annotations.
+			require.Regexp(
+				t,
+				regexp.MustCompile(
+					`^\x1b\[0Ksection_start:\d+:quicklog_section_1\[collapsed=true\]\r\x1b\[0KDeploy\n`+
+						`╭─+╮\n│ Deploy +│\n╰─+╯\nDeploying the app +\n`+
+						`\x1b\[0Ksection_end:\d+:quicklog_section_1\r\x1b\[0K\n`+
+						`Disk is full\. +\n$`,
+				),
+				res.STDOut,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI=true"},
+	})
+}
+
+func TestTerminalGutter(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{
+				Timestamp: loggers.TimestampRelative,
+				Level:     true,
+				Caller:    true,
+				Job:       func() string { return "build" },
+			})
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("This is an info message."))
+			logger.Log(quicklog.LevelError, messages.NewError(fmt.Errorf("deploy: %w", errors.New("timeout")), ""))
+
+			logChan := make(chan string)
+			animated := &fakeAnimated{outTerm: logChan}
+
+			cleaner := logger.LogAnimated(animated)
+			logChan <- "This is an animated message."
+			cleaner()
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			require.Regexp(
+				t,
+				regexp.MustCompile(
+					`^ +0\.\d{3}s  INFO   terminal_test\.go:\d+ +build        This is an info message\. +\n`+
+						` +0\.\d{3}s  INFO   terminal_test\.go:\d+ +build        This is an animated message\.\n$`,
+				),
+				res.STDOut,
+			)
+			// Lines of multi-line messages are aligned after the gutter, and fit in the terminal.
+			require.Regexp(
+				t,
+				regexp.MustCompile(
+					`^ +0\.\d{3}s  ERROR  terminal_test\.go:\d+ +build        deploy {21}\n`+
+						` {53}└── timeout {16}\n$`,
+				),
+				res.STDErr,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalGutterTimestamp(t *testing.T) {
+	testData := []struct {
+		name string
+
+		timestamp loggers.TimestampFormat
+
+		expect *regexp.Regexp
+	}{
+		{
+			name:      "Relative",
+			timestamp: loggers.TimestampRelative,
+			expect:    regexp.MustCompile(`^ {4}0\.\d{3}s Hello world! +\n$`),
+		},
+		{
+			name:      "WallClock",
+			timestamp: loggers.TimestampWallClock,
+			expect:    regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d{3} Hello world! +\n$`),
+		},
+		{
+			name:      "RFC3339",
+			timestamp: loggers.TimestampRFC3339,
+			expect: regexp.MustCompile(
+				`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z {5}|[+-]\d{2}:\d{2}) Hello world! +\n$`,
+			),
+		},
+		{
+			name:   "None",
+			expect: regexp.MustCompile(`^Hello world! +\n$`),
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Run(testCase.name, func(t *testing.T) {
+			testutils.RunCMD(t, &testutils.CMDConfig{
+				CmdFn: func(t *testing.T) {
+					logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{Timestamp: testCase.timestamp})
+					logger.Log(quicklog.LevelInfo, messages.NewBase("Hello world!"))
+				},
+				MainFn: func(t *testing.T, res *testutils.CMDResult) {
+					require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+					require.Regexp(t, testCase.expect, res.STDOut)
+					// The gutter is part of the terminal width.
+					require.Len(t, []rune(res.STDOut), quicklog.TermWidth+1)
+				},
+				Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+			})
+		})
+	}
+}
+
+func TestGoroutineJob(t *testing.T) {
+	require.Regexp(t, regexp.MustCompile(`^g\d+$`), loggers.GoroutineJob())
+
+	jobs := make(chan string)
+	go func() {
+		jobs <- loggers.GoroutineJob()
+	}()
+
+	require.NotEqual(t, loggers.GoroutineJob(), <-jobs)
+}
+
+func TestTerminalDedupSummary(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{Dedup: loggers.DedupAuto})
+
+			for range 3 {
+				logger.Log(quicklog.LevelInfo, messages.NewBase("Waiting for the database."))
+			}
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Connected."))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Connected."))
+			// Messages of different levels are not repeats.
+			logger.Log(quicklog.LevelWarning, messages.NewBase("Connected."))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Migrating."))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Migrating."))
+
+			// Animated messages print the pending summary.
+			logChan := make(chan string)
+			cleaner := logger.LogAnimated(&fakeAnimated{outTerm: logChan})
+			logChan <- "Migrated."
+			cleaner()
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			require.Equal(
+				t,
+				"Waiting for the database.                                                       \n"+
+					"(×3) Previous message repeated 2 more times.                                    \n"+
+					"Connected.                                                                      \n"+
+					"(×2) Previous message repeated 1 more time.                                     \n"+
+					"Connected.                                                                      \n"+
+					"Migrating.                                                                      \n"+
+					"(×2) Previous message repeated 1 more time.                                     \n"+
+					"Migrated.\n",
+				res.STDOut,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalDedupFlush(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{Dedup: loggers.DedupSummary})
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Done."))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Done."))
+
+			// The program ends on a repeated message.
+			logger.Flush()
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+			require.Equal(
+				t,
+				"Done.                                                                           \n"+
+					"(×2) Previous message repeated 1 more time.                                     \n",
+				res.STDOut,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalDedupLive(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{
+				Dedup: loggers.DedupLive,
+				Level: true,
+			})
+
+			for range 3 {
+				logger.Log(quicklog.LevelInfo, messages.NewTitle("Waiting", "for the database"))
+			}
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Connected."))
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Connected."))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+
+			title := quicklog.RenderTerminalWidth(messages.NewTitle("Waiting", "for the database"), quicklog.TermWidth-8)
+			lines := strings.Split(strings.TrimSuffix(title, "\n"), "\n")
+
+			// The title has no room left for the counter, that is printed on a new line.
+			box := func(counter string) string {
+				return " INFO   " + strings.Join(append(lines, counter), "\n        ") + "\n"
+			}
+
+			// The message is printed again in place, with a counter.
+			require.Equal(
+				t,
+				" INFO   "+strings.Join(lines, "\n        ")+"\n"+
+					strings.Repeat(messages.EraseLineSequence, 4)+box("(×2)")+
+					strings.Repeat(messages.EraseLineSequence, 5)+box("(×3)")+
+					" INFO   Connected."+strings.Repeat(" ", 62)+"\n"+
+					// The counter replaces the padding of messages.
+					messages.EraseLineSequence+" INFO   Connected."+strings.Repeat(" ", 58)+"(×2)\n",
+				res.STDOut,
+			)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestTerminalDedupLiveCI(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminalWithConfig(&loggers.TerminalConfig{Dedup: loggers.DedupLive})
+
+			for range 2 {
+				logger.Log(quicklog.LevelWarning, messages.NewTitle("Retrying", "the request"))
+			}
+
+			logger.Log(quicklog.LevelInfo, messages.NewBase("Done."))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.Truef(t, res.Success, "stdout: %s\nstderr: %s", res.STDOut, res.STDErr)
+
+			// Messages are wrapped in folding and annotation lines: repetitions are summarized, and nothing is erased.
+			require.NotContains(t, res.STDOut, messages.EraseLineSequence)
+			require.Equal(t, 1, strings.Count(res.STDOut, "::group::Retrying"))
+			require.Contains(t, res.STDOut, "(×2) Previous message repeated 1 more time.")
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=true", "GITLAB_CI="},
 	})
 }
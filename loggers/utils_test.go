@@ -1,5 +1,11 @@
 package loggers_test
 
+import (
+	"sync"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
 type fakeAnimated struct {
 	outTerm chan string
 	outJSON chan map[string]interface{}
@@ -21,3 +27,49 @@ func (fake *fakeAnimated) Close() {
 		close(fake.outJSON)
 	}
 }
+
+type fakeLoggedMessage struct {
+	level   quicklog.Level
+	message quicklog.Message
+}
+
+// fakeLogger records the messages it receives. If gate is set, each Log call waits for a value from it.
+type fakeLogger struct {
+	gate chan struct{}
+	// If set, receives a signal each time Log is called, before Log waits for the gate.
+	calls chan struct{}
+
+	logged []fakeLoggedMessage
+	mu     sync.Mutex
+}
+
+func (fake *fakeLogger) Log(level quicklog.Level, message quicklog.Message) {
+	if fake.calls != nil {
+		fake.calls <- struct{}{}
+	}
+
+	if fake.gate != nil {
+		<-fake.gate
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	fake.logged = append(fake.logged, fakeLoggedMessage{level: level, message: message})
+}
+
+func (fake *fakeLogger) LogAnimated(_ quicklog.AnimatedMessage) func() {
+	return func() {}
+}
+
+func (fake *fakeLogger) messages() []string {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	output := make([]string, len(fake.logged))
+	for i, logged := range fake.logged {
+		output[i] = logged.message.RenderJSON()["message"].(string)
+	}
+
+	return output
+}
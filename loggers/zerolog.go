@@ -1,87 +1,121 @@
 package loggers
 
 import (
-	"log"
-	"os"
-	"sync"
-
 	"github.com/rs/zerolog"
 
 	"github.com/a-novel-kit/quicklog"
 )
 
 type zerologLogger struct {
-	// True while an animated log is running. Prevents concurrent logs that cause management issues.
-	animated bool
+	animation animationState
 
 	logger zerolog.Logger
+	config ZerologConfig
 
 	quicklog.Logger
 }
 
-func (logger *zerologLogger) getEvent(level quicklog.Level) *zerolog.Event {
+// Add the caller of the logger to an event, if enabled. The caller is formatted by zerolog.CallerMarshalFunc, like
+// the callers zerolog adds itself.
+func (logger *zerologLogger) withCaller(event *zerolog.Event, caller *callerFrame) *zerolog.Event {
+	if caller == nil {
+		return event
+	}
+
+	return event.Str(zerolog.CallerFieldName, zerolog.CallerMarshalFunc(caller.pc, caller.file, caller.line))
+}
+
+// Look for the caller of a message, only if it is added to events.
+func (logger *zerologLogger) findCaller(message quicklog.Message) *callerFrame {
+	if !logger.config.Caller {
+		return nil
+	}
+
+	return findMessageCaller(message)
+}
+
+// Return an event of the zerolog level matching a quicklog level.
+func zerologEvent(logger zerolog.Logger, level quicklog.Level) *zerolog.Event {
 	switch level {
 	case quicklog.LevelError:
-		return logger.logger.Error()
+		return logger.Error()
 	case quicklog.LevelWarning:
-		return logger.logger.Warn()
+		return logger.Warn()
 	case quicklog.LevelFatal:
-		return logger.logger.Fatal()
+		// Fatal() exits the program right away, without running the exit hooks.
+		return logger.WithLevel(zerolog.FatalLevel)
 	default:
-		return logger.logger.Info()
+		return logger.Info()
 	}
 }
 
-func (logger *zerologLogger) checkAnimationLock() {
-	if logger.animated {
-		log.New(os.Stderr, "", 0).Fatal("cannot log while an animated message is running")
+func (logger *zerologLogger) Log(level quicklog.Level, message quicklog.Message) {
+	// Fatal messages terminate the program: close the running animation, so its final state is logged.
+	if level == quicklog.LevelFatal {
+		logger.animation.interrupt(quicklog.ErrFatal)
 	}
-}
 
-func (logger *zerologLogger) Log(level quicklog.Level, message quicklog.Message) {
-	logger.checkAnimationLock()
+	if !logger.animation.checkAnimationLock() {
+		return
+	}
 
 	rendered := message.RenderJSON()
 	if rendered == nil {
 		return
 	}
 
-	event := logger.getEvent(level)
-	event.Fields(rendered).Msg("")
+	logger.withCaller(zerologEvent(logger.logger, level), logger.findCaller(message)).Fields(rendered).Msg("")
+
+	if level == quicklog.LevelFatal {
+		quicklog.Exit(quicklog.FatalExitCode)
+	}
 }
 
 func (logger *zerologLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
-	logger.checkAnimationLock()
-
-	logger.animated = true
-
-	waitGroup := sync.WaitGroup{}
-	waitGroup.Add(1)
-
-	cleaner := func() {
-		logger.animated = false
-		message.Close()
-		waitGroup.Wait()
+	if !logger.animation.checkAnimationLock() {
+		return func() {}
 	}
 
-	go func() {
-		defer waitGroup.Done()
+	// Subscribe before returning, so updates made right after this call are not missed.
+	output := message.RunJSON()
+
+	// Frames are logged from a background goroutine: they are attributed to the code that started the animation.
+	caller := logger.findCaller(nil)
 
-		for logMessage := range message.RunJSON() {
+	return logger.animation.start(message, func() {
+		for logMessage := range output {
 			if logMessage == nil {
 				continue
 			}
 
-			logger.logger.Info().Fields(logMessage).Msg("")
+			logger.withCaller(logger.logger.Info(), caller).Fields(logMessage).Msg("")
 		}
-	}()
+	})
+}
+
+func (logger *zerologLogger) Interrupt(err error) {
+	logger.animation.interrupt(err)
+}
 
-	return cleaner
+type ZerologConfig struct {
+	// Caller adds the file and line of the code that logged each message, under zerolog.CallerFieldName.
+	//
+	// Unlike zerolog.Logger.With().Caller(), the caller does not depend on a number of frames to skip: frames of
+	// quicklog are skipped, so the caller stays correct when loggers are wrapped.
+	Caller bool
 }
 
+var ZerologConfigDefault = ZerologConfig{}
+
 // NewZerolog creates a new logger using the zerolog library.
 func NewZerolog(logger zerolog.Logger) quicklog.Logger {
+	return NewZerologWithConfig(logger, &ZerologConfigDefault)
+}
+
+// NewZerologWithConfig creates a new zerolog logger, with a custom configuration.
+func NewZerologWithConfig(logger zerolog.Logger, config *ZerologConfig) quicklog.Logger {
 	return &zerologLogger{
 		logger: logger,
+		config: *config,
 	}
 }
@@ -1,7 +1,9 @@
 package loggers_test
 
 import (
+	"bytes"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -33,10 +35,10 @@ func TestZerologLog(t *testing.T) {
 			require.False(t, res.Success)
 			require.Equal(
 				t,
-				"{\"level\":\"info\",\"message\":\"This is an info message.\"}\n"+
-					"{\"level\":\"warn\",\"message\":\"This is a warning message.\"}\n"+
-					"{\"level\":\"error\",\"message\":\"This is an error message.\"}\n"+
-					"{\"level\":\"fatal\",\"message\":\"This is a fatal message.\"}\n",
+				"{\"level\":\"info\",\"kind\":\"base\",\"message\":\"This is an info message.\",\"schema_version\":1}\n"+
+					"{\"level\":\"warn\",\"kind\":\"base\",\"message\":\"This is a warning message.\",\"schema_version\":1}\n"+
+					"{\"level\":\"error\",\"kind\":\"base\",\"message\":\"This is an error message.\",\"schema_version\":1}\n"+
+					"{\"level\":\"fatal\",\"kind\":\"base\",\"message\":\"This is a fatal message.\",\"schema_version\":1}\n",
 				res.STDOut,
 			)
 		},
@@ -63,8 +65,9 @@ func TestZerologLogAnimated(t *testing.T) {
 			require.True(t, res.Success)
 			require.Equal(
 				t,
-				"{\"level\":\"info\",\"message\":\"This is an animated message.\"}\n"+
-					"{\"level\":\"info\",\"message\":\"This is another animated message.\"}\n",
+				"{\"level\":\"info\",\"kind\":\"base\",\"message\":\"This is an animated message.\",\"schema_version\":1}\n"+
+					"{\"level\":\"info\",\"kind\":\"base\","+
+					"\"message\":\"This is another animated message.\",\"schema_version\":1}\n",
 				res.STDOut,
 			)
 		},
@@ -94,10 +97,74 @@ func TestZerologLogAnimatedNoConcurrentLog(t *testing.T) {
 			require.False(t, res.Success)
 			require.Equal(
 				t,
-				"{\"level\":\"info\",\"message\":\"This is an animated message.\"}\n",
+				"{\"level\":\"info\",\"kind\":\"base\",\"message\":\"This is an animated message.\",\"schema_version\":1}\n",
 				res.STDOut,
 			)
 			require.Equal(t, "cannot log while an animated message is running\n", res.STDErr)
 		},
 	})
 }
+
+func TestZerologLogFatalInProcess(t *testing.T) {
+	originalExitFunc := quicklog.ExitFunc
+	defer func() {
+		quicklog.ExitFunc = originalExitFunc
+	}()
+
+	var exitCode int
+
+	quicklog.ExitFunc = func(code int) {
+		exitCode = code
+	}
+
+	var hookCalled bool
+
+	removeHook := quicklog.OnFatal(func() {
+		hookCalled = true
+	})
+	defer removeHook()
+
+	output := new(bytes.Buffer)
+	logger := loggers.NewZerolog(zerolog.New(output))
+
+	logger.Log(quicklog.LevelFatal, messages.NewBase("This is a fatal message.", nil))
+
+	require.Equal(t, 1, exitCode)
+	require.True(t, hookCalled)
+	require.Equal(
+		t,
+		"{\"level\":\"fatal\",\"kind\":\"base\",\"message\":\"This is a fatal message.\",\"schema_version\":1}\n",
+		output.String(),
+	)
+}
+
+func TestZerologCaller(t *testing.T) {
+	output := new(bytes.Buffer)
+	logger := loggers.NewZerologWithConfig(zerolog.New(output), &loggers.ZerologConfig{Caller: true})
+
+	// The caller is found through wrapping loggers.
+	wrapped := loggers.NewRecorder(logger, new(bytes.Buffer), &loggers.RecorderConfigDefault)
+
+	logger.Log(quicklog.LevelInfo, messages.NewBase("This is an info message."))
+	wrapped.Log(quicklog.LevelWarning, messages.NewBase("This is a warning message."))
+
+	logChan := make(chan map[string]interface{})
+	animated := &fakeAnimated{outJSON: logChan}
+
+	cleaner := logger.LogAnimated(animated)
+	logChan <- messages.NewBase("This is an animated message.").RenderJSON()
+	cleaner()
+
+	require.Regexp(
+		t,
+		regexp.MustCompile(
+			`^\{"level":"info","caller":"[^"]+/loggers/zerolog_test\.go:\d+","kind":"base",`+
+				`"message":"This is an info message\.","schema_version":1}\n`+
+				`\{"level":"warn","caller":"[^"]+/loggers/zerolog_test\.go:\d+","kind":"base",`+
+				`"message":"This is a warning message\.","schema_version":1}\n`+
+				`\{"level":"info","caller":"[^"]+/loggers/zerolog_test\.go:\d+","kind":"base",`+
+				`"message":"This is an animated message\.","schema_version":1}\n$`,
+		),
+		output.String(),
+	)
+}
@@ -1,5 +1,14 @@
 package quicklog
 
+import (
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+)
+
 // Message is a generic representation of a data that supports rendering under different formats.
 type Message interface {
 	// RenderTerminal renders a message in a format that is suitable for terminal output.
@@ -39,6 +48,40 @@ func RenderWithChildTerminal(parent string, child Message) string {
 	return parent + child.RenderTerminal()
 }
 
+// SchemaVersion is the version of the JSON renderings of messages. It is increased whenever a change may break
+// existing consumers, such as a key being renamed or removed. New keys may be added without a new version.
+const SchemaVersion = 1
+
+const (
+	// KindKey is the key of JSON renderings that holds the kind of message (title, base, error, loader...), so
+	// consumers can tell messages apart without guessing from their other keys.
+	KindKey = "kind"
+	// SchemaVersionKey is the key of JSON renderings that holds the SchemaVersion they comply with. It is only set
+	// at the top level: nested messages share the version of their parent.
+	SchemaVersionKey = "schema_version"
+)
+
+// RenderKindJSON sets the kind of a JSON rendering, along with the version of its schema.
+func RenderKindJSON(kind string, rendered map[string]interface{}) map[string]interface{} {
+	if rendered == nil {
+		return nil
+	}
+
+	rendered[KindKey] = kind
+	rendered[SchemaVersionKey] = SchemaVersion
+
+	return rendered
+}
+
+// RenderChildJSON renders a message nested under another one in JSON format. The schema version is omitted, since
+// it is already set by the parent.
+func RenderChildJSON(child Message) map[string]interface{} {
+	rendered := child.RenderJSON()
+	delete(rendered, SchemaVersionKey)
+
+	return rendered
+}
+
 // RenderWithChildJSON automatically renders a parent with its child in JSON format.
 func RenderWithChildJSON(parent map[string]interface{}, child Message) map[string]interface{} {
 	// If there is no parent message, then act as if nothing is logged. Child is an addon, not a replacement.
@@ -48,7 +91,221 @@ func RenderWithChildJSON(parent map[string]interface{}, child Message) map[strin
 
 	// No child = no change.
 	if child != nil {
-		parent["data"] = child.RenderJSON()
+		parent["data"] = RenderChildJSON(child)
+	}
+
+	return parent
+}
+
+// Return the children that are not nil.
+func presentChildren(children []Message) []Message {
+	var output []Message
+
+	for _, child := range children {
+		if child != nil {
+			output = append(output, child)
+		}
+	}
+
+	return output
+}
+
+// RenderWithChildrenTerminal automatically renders a parent with its children in terminal format. Children are
+// rendered one after the other, in order.
+func RenderWithChildrenTerminal(parent string, children ...Message) string {
+	return RenderWithChildrenTerminalWidth(parent, TermWidth, children...)
+}
+
+// RenderWithChildrenJSON automatically renders a parent with its children in JSON format. A single child is
+// rendered under the data key, as with RenderWithChildJSON. Multiple children are rendered as an array under the
+// same key.
+func RenderWithChildrenJSON(parent map[string]interface{}, children ...Message) map[string]interface{} {
+	children = presentChildren(children)
+
+	if len(children) <= 1 {
+		return RenderWithChildJSON(parent, lo.FirstOr(children, nil))
+	}
+
+	// If there is no parent message, then act as if nothing is logged. Child is an addon, not a replacement.
+	if parent == nil {
+		return nil
+	}
+
+	parent["data"] = lo.Map(children, func(child Message, _ int) map[string]interface{} {
+		return RenderChildJSON(child)
+	})
+
+	return parent
+}
+
+// WidthAwareMessage is implemented by messages that can render in the terminal within a given width, rather than
+// TermWidth. This allows messages to be nested under an indentation, without breaking their layout.
+type WidthAwareMessage interface {
+	// RenderTerminalWidth renders a message for the terminal, with lines no wider than width.
+	RenderTerminalWidth(width int) string
+}
+
+// RenderTerminalWidth renders a message for the terminal, within the given width. Messages that do not implement
+// WidthAwareMessage are rendered for TermWidth, then wrapped again within width.
+func RenderTerminalWidth(message Message, width int) string {
+	if message == nil {
+		return ""
+	}
+
+	if widthAwareMessage, ok := message.(WidthAwareMessage); ok {
+		return widthAwareMessage.RenderTerminalWidth(width)
+	}
+
+	rendered := message.RenderTerminal()
+	if rendered == "" || width >= TermWidth {
+		return rendered
+	}
+
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	for i, line := range lines {
+		// Remove the padding of the line, so it is not wrapped as a blank line.
+		lines[i] = lipgloss.NewStyle().Width(width).Render(strings.TrimRight(line, " "))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// RenderWithChildTerminalWidth automatically renders a parent with its child in terminal format, within the
+// given width.
+func RenderWithChildTerminalWidth(parent string, child Message, width int) string {
+	// If there is no parent message, then act as if nothing is logged. Child is an addon, not a replacement.
+	if parent == "" {
+		return ""
+	}
+
+	return parent + RenderTerminalWidth(child, width)
+}
+
+// RenderWithChildrenTerminalWidth automatically renders a parent with its children in terminal format, within
+// the given width.
+func RenderWithChildrenTerminalWidth(parent string, width int, children ...Message) string {
+	// If there is no parent message, then act as if nothing is logged. Children are addons, not replacements.
+	if parent == "" {
+		return ""
+	}
+
+	for _, child := range children {
+		parent += RenderTerminalWidth(child, width)
+	}
+
+	return parent
+}
+
+// SectionMessage is implemented by messages that introduce a section, such as titles or loaders. Loggers writing
+// to CI environments may fold the message under its title.
+type SectionMessage interface {
+	// SectionTitle returns the title of the section, as plain text. An empty title disables folding.
+	SectionTitle() string
+}
+
+// MarkdownMessage is implemented by messages that support rendering as Markdown, for reports.
+type MarkdownMessage interface {
+	// RenderMarkdown renders a message as a Markdown block.
+	RenderMarkdown() string
+}
+
+// HTMLMessage is implemented by messages that support rendering as HTML, for reports.
+type HTMLMessage interface {
+	// RenderHTML renders a message as an HTML fragment.
+	RenderHTML() string
+}
+
+// Return the terminal rendering of a message, without styles and trailing spaces.
+func renderPlainText(message Message) string {
+	lines := strings.Split(ansi.Strip(message.RenderTerminal()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// RenderMarkdown renders a message as Markdown. Messages that do not implement MarkdownMessage are rendered as a
+// code block, containing their terminal output without styles.
+func RenderMarkdown(message Message) string {
+	if message == nil {
+		return ""
+	}
+
+	if markdownMessage, ok := message.(MarkdownMessage); ok {
+		return markdownMessage.RenderMarkdown()
+	}
+
+	text := renderPlainText(message)
+	if text == "" {
+		return ""
+	}
+
+	return "```\n" + text + "\n```\n\n"
+}
+
+// RenderHTML renders a message as HTML. Messages that do not implement HTMLMessage are rendered as a preformatted
+// block, containing their terminal output without styles.
+func RenderHTML(message Message) string {
+	if message == nil {
+		return ""
+	}
+
+	if htmlMessage, ok := message.(HTMLMessage); ok {
+		return htmlMessage.RenderHTML()
+	}
+
+	text := renderPlainText(message)
+	if text == "" {
+		return ""
+	}
+
+	return "<pre>" + html.EscapeString(text) + "</pre>\n"
+}
+
+// RenderWithChildMarkdown automatically renders a parent with its child in Markdown format.
+func RenderWithChildMarkdown(parent string, child Message) string {
+	// If there is no parent message, then act as if nothing is logged. Child is an addon, not a replacement.
+	if parent == "" {
+		return ""
+	}
+
+	return parent + RenderMarkdown(child)
+}
+
+// RenderWithChildHTML automatically renders a parent with its child in HTML format.
+func RenderWithChildHTML(parent string, child Message) string {
+	// If there is no parent message, then act as if nothing is logged. Child is an addon, not a replacement.
+	if parent == "" {
+		return ""
+	}
+
+	return parent + RenderHTML(child)
+}
+
+// RenderWithChildrenMarkdown automatically renders a parent with its children in Markdown format.
+func RenderWithChildrenMarkdown(parent string, children ...Message) string {
+	// If there is no parent message, then act as if nothing is logged. Children are addons, not replacements.
+	if parent == "" {
+		return ""
+	}
+
+	for _, child := range children {
+		parent += RenderMarkdown(child)
+	}
+
+	return parent
+}
+
+// RenderWithChildrenHTML automatically renders a parent with its children in HTML format.
+func RenderWithChildrenHTML(parent string, children ...Message) string {
+	// If there is no parent message, then act as if nothing is logged. Children are addons, not replacements.
+	if parent == "" {
+		return ""
+	}
+
+	for _, child := range children {
+		parent += RenderHTML(child)
 	}
 
 	return parent
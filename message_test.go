@@ -1,6 +1,7 @@
 package quicklog_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -106,3 +107,243 @@ func TestRenderWithChildJSON(t *testing.T) {
 		})
 	}
 }
+
+type dummyMarkdownMessage struct {
+	dummyMessage
+}
+
+func (d *dummyMarkdownMessage) RenderMarkdown() string {
+	return "**dummy**\n\n"
+}
+
+func (d *dummyMarkdownMessage) RenderHTML() string {
+	return "<p>dummy</p>\n"
+}
+
+type styledMessage struct {
+	dummyMessage
+}
+
+func (d *styledMessage) RenderTerminal() string {
+	return "\x1b[31mstyled <text>   \x1b[0m\n"
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		message quicklog.Message
+
+		expect string
+	}{
+		{
+			name: "Nil",
+
+			expect: "",
+		},
+		{
+			name: "MarkdownMessage",
+
+			message: &dummyMarkdownMessage{},
+
+			expect: "**dummy**\n\n",
+		},
+		{
+			name: "Fallback",
+
+			message: &styledMessage{},
+
+			expect: "```\nstyled <text>\n```\n\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, quicklog.RenderMarkdown(testCase.message))
+		})
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		message quicklog.Message
+
+		expect string
+	}{
+		{
+			name: "Nil",
+
+			expect: "",
+		},
+		{
+			name: "HTMLMessage",
+
+			message: &dummyMarkdownMessage{},
+
+			expect: "<p>dummy</p>\n",
+		},
+		{
+			name: "Fallback",
+
+			message: &styledMessage{},
+
+			expect: "<pre>styled &lt;text&gt;</pre>\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, quicklog.RenderHTML(testCase.message))
+		})
+	}
+}
+
+func TestRenderWithChildMarkdown(t *testing.T) {
+	require.Equal(t, "", quicklog.RenderWithChildMarkdown("", &dummyMarkdownMessage{}))
+	require.Equal(t, "parent\n\n", quicklog.RenderWithChildMarkdown("parent\n\n", nil))
+	require.Equal(t, "parent\n\n**dummy**\n\n", quicklog.RenderWithChildMarkdown("parent\n\n", &dummyMarkdownMessage{}))
+}
+
+func TestRenderWithChildHTML(t *testing.T) {
+	require.Equal(t, "", quicklog.RenderWithChildHTML("", &dummyMarkdownMessage{}))
+	require.Equal(t, "<p>parent</p>\n", quicklog.RenderWithChildHTML("<p>parent</p>\n", nil))
+	require.Equal(
+		t, "<p>parent</p>\n<p>dummy</p>\n", quicklog.RenderWithChildHTML("<p>parent</p>\n", &dummyMarkdownMessage{}),
+	)
+}
+
+type widthAwareMessage struct {
+	dummyMessage
+}
+
+func (d *widthAwareMessage) RenderTerminalWidth(width int) string {
+	return strings.Repeat("x", width) + "\n"
+}
+
+type paddedMessage struct {
+	dummyMessage
+}
+
+func (d *paddedMessage) RenderTerminal() string {
+	return "hello world" + strings.Repeat(" ", quicklog.TermWidth-11) + "\n"
+}
+
+func TestRenderTerminalWidth(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		message quicklog.Message
+		width   int
+
+		expect string
+	}{
+		{
+			name: "Nil",
+
+			width: 10,
+
+			expect: "",
+		},
+		{
+			name: "WidthAwareMessage",
+
+			message: &widthAwareMessage{},
+			width:   5,
+
+			expect: "xxxxx\n",
+		},
+		{
+			name: "Fallback",
+
+			message: &paddedMessage{},
+			width:   8,
+
+			expect: "hello   \nworld   \n",
+		},
+		{
+			name: "FallbackFullWidth",
+
+			message: &paddedMessage{},
+			width:   quicklog.TermWidth,
+
+			expect: "hello world" + strings.Repeat(" ", quicklog.TermWidth-11) + "\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, quicklog.RenderTerminalWidth(testCase.message, testCase.width))
+		})
+	}
+}
+
+func TestRenderWithChildTerminalWidth(t *testing.T) {
+	require.Equal(t, "", quicklog.RenderWithChildTerminalWidth("", &widthAwareMessage{}, 3))
+	require.Equal(t, "parent\n", quicklog.RenderWithChildTerminalWidth("parent\n", nil, 3))
+	require.Equal(t, "parent\nxxx\n", quicklog.RenderWithChildTerminalWidth("parent\n", &widthAwareMessage{}, 3))
+}
+
+func TestRenderWithChildrenJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		parent   map[string]interface{}
+		children []quicklog.Message
+
+		expect map[string]interface{}
+	}{
+		{
+			name: "ParentEmpty",
+
+			children: []quicklog.Message{&dummyMessage{}, &dummyMessage{}},
+
+			expect: nil,
+		},
+		{
+			name: "NoChildren",
+
+			parent:   map[string]interface{}{"parent": true},
+			children: []quicklog.Message{nil},
+
+			expect: map[string]interface{}{"parent": true},
+		},
+		{
+			// A single child keeps the rendering of RenderWithChildJSON.
+			name: "SingleChild",
+
+			parent:   map[string]interface{}{"parent": true},
+			children: []quicklog.Message{nil, &dummyMessage{}},
+
+			expect: map[string]interface{}{"parent": true, "data": map[string]interface{}{"dummy": true}},
+		},
+		{
+			name: "Children",
+
+			parent:   map[string]interface{}{"parent": true},
+			children: []quicklog.Message{&dummyMessage{}, nil, &dummyMessage{}},
+
+			expect: map[string]interface{}{
+				"parent": true,
+				"data":   []map[string]interface{}{{"dummy": true}, {"dummy": true}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, quicklog.RenderWithChildrenJSON(testCase.parent, testCase.children...))
+		})
+	}
+}
+
+func TestRenderWithChildrenTerminal(t *testing.T) {
+	require.Equal(t, "", quicklog.RenderWithChildrenTerminal("", &dummyMessage{}))
+	require.Equal(t, "parent", quicklog.RenderWithChildrenTerminal("parent"))
+	require.Equal(
+		t,
+		"parentdummydummy",
+		quicklog.RenderWithChildrenTerminal("parent", &dummyMessage{}, nil, &dummyMessage{}),
+	)
+}
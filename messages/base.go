@@ -9,22 +9,26 @@ import (
 type baseMessage struct {
 	message string
 
-	child quicklog.Message
+	children []quicklog.Message
 
 	quicklog.Message
 }
 
 func (base *baseMessage) RenderTerminal() string {
+	return base.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (base *baseMessage) RenderTerminalWidth(width int) string {
 	if base.message == "" {
 		return ""
 	}
 
 	content := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("15")).
-		Width(quicklog.TermWidth).
-		Render(base.message)
+		Width(width).
+		Render(renderLinksTerminal(base.message))
 
-	return quicklog.RenderWithChildTerminal(content+"\n", base.child)
+	return quicklog.RenderWithChildrenTerminalWidth(splitHyperlinkLines(content)+"\n", width, base.children...)
 }
 
 func (base *baseMessage) RenderJSON() map[string]interface{} {
@@ -33,16 +37,38 @@ func (base *baseMessage) RenderJSON() map[string]interface{} {
 	}
 
 	content := map[string]interface{}{
-		"message": base.message,
+		"message": stripLinks(base.message),
+	}
+
+	if links := renderLinksJSON(linkedText{key: "message", text: base.message}); len(links) > 0 {
+		content["links"] = links
+	}
+
+	return quicklog.RenderWithChildrenJSON(quicklog.RenderKindJSON(KindBase, content), base.children...)
+}
+
+func (base *baseMessage) RenderMarkdown() string {
+	if base.message == "" {
+		return ""
+	}
+
+	return quicklog.RenderWithChildrenMarkdown(markdownLinksParagraph(base.message), base.children...)
+}
+
+func (base *baseMessage) RenderHTML() string {
+	if base.message == "" {
+		return ""
 	}
 
-	return quicklog.RenderWithChildJSON(content, base.child)
+	return quicklog.RenderWithChildrenHTML(htmlLinksParagraph("quicklog-base", base.message), base.children...)
 }
 
-// NewBase groups together important logs under a section. Description and child are optional.
-func NewBase(message string, child quicklog.Message) quicklog.Message {
+// NewBase groups together important logs under a section. Children are optional, and rendered after the message.
+//
+// The message may contain links, using the [text](url) syntax (see NewLink).
+func NewBase(message string, children ...quicklog.Message) quicklog.Message {
 	return &baseMessage{
-		message: message,
-		child:   child,
+		message:  message,
+		children: children,
 	}
 }
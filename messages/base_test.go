@@ -64,7 +64,9 @@ func TestBaseMessageJSON(t *testing.T) {
 			message: "Hello, world!",
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
+				"kind":           "base",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
 			},
 		},
 		{
@@ -79,8 +81,11 @@ func TestBaseMessageJSON(t *testing.T) {
 			child:   messages.NewBase("Child message", nil),
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
+				"kind":           "base",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
 				"data": map[string]interface{}{
+					"kind":    "base",
 					"message": "Child message",
 				},
 			},
@@ -94,3 +99,75 @@ func TestBaseMessageJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseMessageMarkdown(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		message string
+		child   quicklog.Message
+
+		expectMarkdown string
+		expectHTML     string
+	}{
+		{
+			name: "SimpleMessage",
+
+			message: "Hello, *world*!\nBye <world>.",
+
+			expectMarkdown: "Hello, \\*world\\*!\\\nBye \\<world\\>.\n\n",
+			expectHTML:     "<p class=\"quicklog-base\">Hello, *world*!<br>\nBye &lt;world&gt;.</p>\n",
+		},
+		{
+			name: "NoMessage",
+
+			expectMarkdown: "",
+			expectHTML:     "",
+		},
+		{
+			name: "WithChild",
+
+			message: "Hello, world!",
+			child:   messages.NewBase("Child message", nil),
+
+			expectMarkdown: "Hello, world!\n\nChild message\n\n",
+			expectHTML:     "<p class=\"quicklog-base\">Hello, world!</p>\n<p class=\"quicklog-base\">Child message</p>\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewBase(testCase.message, testCase.child)
+			require.Equal(t, testCase.expectMarkdown, quicklog.RenderMarkdown(message))
+			require.Equal(t, testCase.expectHTML, quicklog.RenderHTML(message))
+		})
+	}
+}
+
+func TestBaseMessageTerminalWidth(t *testing.T) {
+	message := messages.NewBase("Hello, world!", messages.NewBase("Child message", nil))
+
+	require.Equal(t, "Hello,    \nworld!    \nChild     \nmessage   \n", quicklog.RenderTerminalWidth(message, 10))
+}
+
+func TestBaseMessageChildren(t *testing.T) {
+	message := messages.NewBase("Hello, world!", messages.NewBase("First child"), nil, messages.NewBase("Second child"))
+
+	require.Equal(
+		t,
+		"Hello, world!                                                                   \n"+
+			"First child                                                                     \n"+
+			"Second child                                                                    \n",
+		message.RenderTerminal(),
+	)
+	require.Equal(t, map[string]interface{}{
+		"kind":           "base",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "Hello, world!",
+		"data": []map[string]interface{}{
+			{"kind": "base", "message": "First child"},
+			{"kind": "base", "message": "Second child"},
+		},
+	}, message.RenderJSON())
+	require.Equal(t, "Hello, world!\n\nFirst child\n\nSecond child\n\n", quicklog.RenderMarkdown(message))
+}
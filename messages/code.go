@@ -0,0 +1,269 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// ==============================================================================================================
+// Syntax coloring.
+// ==============================================================================================================
+
+// Names of the token types recognized by the highlighters. They are used as capture group names in the syntax
+// patterns.
+const (
+	tokenComment = "comment"
+	tokenKey     = "key"
+	tokenString  = "string"
+	tokenNumber  = "number"
+	tokenLiteral = "literal"
+	tokenKeyword = "keyword"
+)
+
+var tokenStyles = map[string]lipgloss.Style{
+	tokenComment: lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true),
+	tokenKey:     lipgloss.NewStyle().Foreground(lipgloss.Color("33")),
+	tokenString:  lipgloss.NewStyle().Foreground(lipgloss.Color("46")),
+	tokenNumber:  lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+	tokenLiteral: lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+	tokenKeyword: lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true),
+}
+
+var codeTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+
+func renderToken(style lipgloss.Style, text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return style.Render(text)
+}
+
+// Syntax patterns, by language. Each pattern is an alternation of named groups, one per token type. Highlighting is
+// performed line by line, so tokens spanning multiple lines (such as block comments) are not recognized.
+var syntaxPatterns = map[string]*regexp.Regexp{
+	"json": regexp.MustCompile(
+		`(?P<key>"(?:[^"\\]|\\.)*")\s*:` +
+			`|(?P<string>"(?:[^"\\]|\\.)*")` +
+			`|(?P<number>-?\b\d+(?:\.\d+)?(?:[eE][+-]?\d+)?\b)` +
+			`|(?P<literal>\b(?:true|false|null)\b)`,
+	),
+	"yaml": regexp.MustCompile(
+		`(?:^|\s)(?P<comment>#.*$)` +
+			`|^\s*(?:- )?(?P<key>[\w.\-/]+)\s*:` +
+			`|(?P<string>"(?:[^"\\]|\\.)*"|'[^']*')` +
+			`|(?P<number>\b\d+(?:\.\d+)?\b)` +
+			`|(?P<literal>\b(?:true|false|yes|no|null)\b|~)`,
+	),
+	"sql": regexp.MustCompile(
+		`(?P<comment>--.*$)` +
+			`|(?P<string>'(?:[^']|'')*')` +
+			`|(?P<number>\b\d+(?:\.\d+)?\b)` +
+			`|(?P<literal>(?i:\b(?:true|false|null)\b))` +
+			`|(?P<keyword>(?i:\b(?:select|from|where|and|or|not|insert|into|values|update|set|delete|create|alter|` +
+			`drop|table|index|on|join|left|right|inner|outer|full|cross|group|by|order|having|limit|offset|as|in|is|` +
+			`like|between|exists|distinct|union|all|case|when|then|else|end|returning|with|primary|key|foreign|` +
+			`references|default|unique|asc|desc)\b))`,
+	),
+	"go": regexp.MustCompile(
+		`(?P<comment>//.*$)` +
+			"|(?P<string>\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`|'(?:[^'\\\\]|\\\\.)*')" +
+			`|(?P<number>\b\d+(?:\.\d+)?\b)` +
+			`|(?P<literal>\b(?:true|false|nil|iota)\b)` +
+			`|(?P<keyword>\b(?:break|case|chan|const|continue|default|defer|else|fallthrough|for|func|go|goto|if|` +
+			`import|interface|map|package|range|return|select|struct|switch|type|var)\b)`,
+	),
+}
+
+// Aliases of the supported languages.
+var syntaxAliases = map[string]string{
+	"yml":        "yaml",
+	"golang":     "go",
+	"postgres":   "sql",
+	"postgresql": "sql",
+}
+
+// Color a single line of source code. Languages without a syntax pattern are rendered without coloring.
+func highlightLine(language, line string) string {
+	pattern, ok := syntaxPatterns[lo.CoalesceOrEmpty(syntaxAliases[language], language)]
+	if !ok {
+		return codeTextStyle.Render(line)
+	}
+
+	var output string
+
+	cursor := 0
+
+	for _, match := range pattern.FindAllStringSubmatchIndex(line, -1) {
+		// Find the token type that matched. Text of the match outside the group is rendered as plain text.
+		for group, name := range pattern.SubexpNames() {
+			start, end := match[2*group], match[2*group+1]
+			if name == "" || start < 0 {
+				continue
+			}
+
+			output += renderToken(codeTextStyle, line[cursor:start]) + renderToken(tokenStyles[name], line[start:end])
+			cursor = end
+
+			break
+		}
+	}
+
+	return output + renderToken(codeTextStyle, line[cursor:])
+}
+
+// ==============================================================================================================
+// Message.
+// ==============================================================================================================
+
+type codeMessage struct {
+	language string
+	source   string
+
+	config CodeConfig
+
+	quicklog.Message
+}
+
+// Return the lines of the source, with tabs expanded so their width is known.
+func (code *codeMessage) lines() []string {
+	source := strings.TrimRight(code.source, "\n")
+	source = strings.ReplaceAll(source, "\t", strings.Repeat(" ", code.config.TabWidth))
+
+	return strings.Split(source, "\n")
+}
+
+// Render the source in a block, whose content is blockWidth wide, including the padding. The border takes 1 more
+// column on each side.
+func (code *codeMessage) render(blockWidth int) string {
+	if code.source == "" {
+		return ""
+	}
+
+	lines := code.lines()
+
+	gutterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+	highlightedGutterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+
+	// The padding takes 1 column on each side.
+	contentWidth := lo.Max([]int{1, blockWidth - 2})
+	gutterWidth := len(strconv.Itoa(code.config.FirstLine + len(lines) - 1))
+
+	renderedLines := make([]string, len(lines))
+
+	for i, line := range lines {
+		lineNumber := code.config.FirstLine + i
+		highlighted := lo.Contains(code.config.Highlight, lineNumber)
+
+		var gutter string
+
+		switch {
+		case code.config.LineNumbers && highlighted:
+			gutter = highlightedGutterStyle.Render(fmt.Sprintf("%*d ▶ ", gutterWidth, lineNumber))
+		case code.config.LineNumbers:
+			gutter = gutterStyle.Render(fmt.Sprintf("%*d │ ", gutterWidth, lineNumber))
+		case len(code.config.Highlight) > 0:
+			gutter = highlightedGutterStyle.Render(lo.Ternary(highlighted, "▶ ", "  "))
+		}
+
+		// Long lines are truncated rather than wrapped, so the structure of the source is preserved.
+		renderedLines[i] = ansi.Truncate(
+			gutter+highlightLine(code.language, line), contentWidth, lipgloss.NewStyle().Faint(true).Render("…"),
+		)
+	}
+
+	blockStyle := lipgloss.NewStyle().
+		Width(blockWidth).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("15")).
+		Padding(0, 1)
+
+	return blockStyle.Render(strings.Join(renderedLines, "\n")) + "\n"
+}
+
+func (code *codeMessage) RenderTerminal() string {
+	return code.render(quicklog.TermWidth)
+}
+
+func (code *codeMessage) RenderTerminalWidth(width int) string {
+	return code.render(lo.Max([]int{1, width - 2}))
+}
+
+func (code *codeMessage) RenderJSON() map[string]interface{} {
+	if code.source == "" {
+		return nil
+	}
+
+	output := map[string]interface{}{
+		"code": code.source,
+	}
+
+	if code.language != "" {
+		output["language"] = code.language
+	}
+
+	return quicklog.RenderKindJSON(KindCode, output)
+}
+
+func (code *codeMessage) RenderMarkdown() string {
+	if code.source == "" {
+		return ""
+	}
+
+	return markdownCodeBlock(code.language, code.source) + "\n"
+}
+
+func (code *codeMessage) RenderHTML() string {
+	if code.source == "" {
+		return ""
+	}
+
+	codeTag := "<code>"
+	if code.language != "" {
+		codeTag = `<code class="language-` + html.EscapeString(code.language) + `">`
+	}
+
+	return `<pre class="quicklog-code">` + codeTag + html.EscapeString(strings.TrimRight(code.source, "\n")) +
+		"</code></pre>\n"
+}
+
+type CodeConfig struct {
+	// LineNumbers displays the number of each line in a gutter.
+	LineNumbers bool
+	// Highlight marks the given lines, by number.
+	Highlight []int
+	// FirstLine is the number of the first line of the source, for snippets extracted from a larger file.
+	// Defaults to 1.
+	FirstLine int
+	// TabWidth is the number of spaces tabs are expanded to. Defaults to 4.
+	TabWidth int
+}
+
+var CodeConfigDefault = CodeConfig{
+	FirstLine: 1,
+	TabWidth:  4,
+}
+
+// NewCode renders a snippet of source code in a block. Sources written in a supported language (json, yaml, sql and
+// go) are colored. Lines longer than the terminal are truncated.
+func NewCode(language, source string, config *CodeConfig) quicklog.Message {
+	return &codeMessage{
+		language: strings.ToLower(language),
+		source:   source,
+		config: CodeConfig{
+			LineNumbers: config.LineNumbers,
+			Highlight:   config.Highlight,
+			FirstLine:   lo.CoalesceOrEmpty(config.FirstLine, CodeConfigDefault.FirstLine),
+			TabWidth:    lo.CoalesceOrEmpty(config.TabWidth, CodeConfigDefault.TabWidth),
+		},
+	}
+}
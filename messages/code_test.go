@@ -0,0 +1,181 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func TestCodeTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		language string
+		source   string
+		config   *messages.CodeConfig
+
+		expect string
+	}{
+		{
+			name: "Simple",
+
+			language: "json",
+			source:   "{\"a\": 1}\n",
+			config:   &messages.CodeConfigDefault,
+
+			expect: "╭────────────────────────────────────────────────────────────────────────────────╮\n" +
+				"│ {\"a\": 1}                                                                       │\n" +
+				"╰────────────────────────────────────────────────────────────────────────────────╯\n",
+		},
+		{
+			name: "NoSource",
+
+			language: "json",
+			config:   &messages.CodeConfigDefault,
+
+			expect: "",
+		},
+		{
+			name: "LineNumbers",
+
+			language: "go",
+			source: "func main() {\n" +
+				"\tprintln(\"hello world, this is a very long line that will not fit in the terminal width\")\n" +
+				"}\n",
+			config: &messages.CodeConfig{LineNumbers: true, Highlight: []int{10}, FirstLine: 9},
+
+			expect: "╭────────────────────────────────────────────────────────────────────────────────╮\n" +
+				"│  9 │ func main() {                                                             │\n" +
+				"│ 10 ▶     println(\"hello world, this is a very long line that will not fit in … │\n" +
+				"│ 11 │ }                                                                         │\n" +
+				"╰────────────────────────────────────────────────────────────────────────────────╯\n",
+		},
+		{
+			name: "Highlight",
+
+			language: "sql",
+			source:   "SELECT 1;\nSELECT 2;",
+			config:   &messages.CodeConfig{Highlight: []int{2}},
+
+			expect: "╭────────────────────────────────────────────────────────────────────────────────╮\n" +
+				"│   SELECT 1;                                                                    │\n" +
+				"│ ▶ SELECT 2;                                                                    │\n" +
+				"╰────────────────────────────────────────────────────────────────────────────────╯\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewCode(testCase.language, testCase.source, testCase.config)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestCodeSyntaxColoring(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	testCases := []struct {
+		name string
+
+		language string
+		source   string
+
+		expectTokens []string
+	}{
+		{
+			name: "JSON",
+
+			language: "json",
+			source:   `{"name": "quicklog", "stars": 42, "archived": false}`,
+
+			expectTokens: []string{
+				"\x1b[38;5;33m\"name\"\x1b[0m",
+				"\x1b[38;5;46m\"quicklog\"\x1b[0m",
+				"\x1b[95m42\x1b[0m",
+				"\x1b[95mfalse\x1b[0m",
+			},
+		},
+		{
+			name: "YAML",
+
+			language: "yml",
+			source:   "name: 'quicklog' # comment",
+
+			expectTokens: []string{
+				"\x1b[38;5;33mname\x1b[0m",
+				"\x1b[38;5;46m'quicklog'\x1b[0m",
+				"\x1b[2;97m# comment\x1b[0m",
+			},
+		},
+		{
+			name: "SQL",
+
+			language: "SQL",
+			source:   "select * from users where name = 'it''s' -- comment",
+
+			expectTokens: []string{
+				"\x1b[1;38;5;33mselect\x1b[0m",
+				"\x1b[1;38;5;33mfrom\x1b[0m",
+				"\x1b[38;5;46m'it''s'\x1b[0m",
+				"\x1b[2;97m-- comment\x1b[0m",
+			},
+		},
+		{
+			name: "Go",
+
+			language: "go",
+			source:   "return nil, fmt.Errorf(\"oops\") // comment",
+
+			expectTokens: []string{
+				"\x1b[1;38;5;33mreturn\x1b[0m",
+				"\x1b[95mnil\x1b[0m",
+				"\x1b[38;5;46m\"oops\"\x1b[0m",
+				"\x1b[2;97m// comment\x1b[0m",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered := messages.NewCode(testCase.language, testCase.source, &messages.CodeConfigDefault).RenderTerminal()
+
+			for _, token := range testCase.expectTokens {
+				require.Contains(t, rendered, token)
+			}
+		})
+	}
+}
+
+func TestCodeJSON(t *testing.T) {
+	require.Equal(
+		t,
+		map[string]interface{}{
+			"kind": "code", "schema_version": quicklog.SchemaVersion, "code": "{\"a\": 1}\n", "language": "json",
+		},
+		messages.NewCode("json", "{\"a\": 1}\n", &messages.CodeConfigDefault).RenderJSON(),
+	)
+	require.Equal(
+		t,
+		map[string]interface{}{"kind": "code", "schema_version": quicklog.SchemaVersion, "code": "plain text"},
+		messages.NewCode("", "plain text", &messages.CodeConfigDefault).RenderJSON(),
+	)
+	require.Nil(t, messages.NewCode("json", "", &messages.CodeConfigDefault).RenderJSON())
+}
+
+func TestCodeMarkdown(t *testing.T) {
+	message := messages.NewCode("go", "fmt.Println(\"```\")\n", &messages.CodeConfigDefault)
+
+	require.Equal(t, "````go\nfmt.Println(\"```\")\n````\n\n", quicklog.RenderMarkdown(message))
+	require.Equal(
+		t,
+		"<pre class=\"quicklog-code\"><code class=\"language-go\">fmt.Println(&#34;```&#34;)</code></pre>\n",
+		quicklog.RenderHTML(message),
+	)
+}
@@ -0,0 +1,685 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+var (
+	ErrUnknownKind              = errors.New("unknown message kind")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+	ErrInvalidLink              = errors.New("invalid inline link")
+	ErrInvalidDiffHunk          = errors.New("invalid diff hunk")
+)
+
+// ==============================================================================================================
+// Errors.
+// ==============================================================================================================
+
+// decodedError rebuilds an error from its JSON chain, so it renders as the original error tree.
+type decodedError struct {
+	message string
+	errType string
+	fields  map[string]interface{}
+	stack   []runtime.Frame
+}
+
+func (err *decodedError) Error() string {
+	return err.message
+}
+
+func (err *decodedError) typeName() string {
+	return err.errType
+}
+
+func (err *decodedError) Fields() map[string]interface{} {
+	return err.fields
+}
+
+func (err *decodedError) StackTrace() []runtime.Frame {
+	return err.stack
+}
+
+type decodedWrapError struct {
+	decodedError
+	cause error
+}
+
+func (err *decodedWrapError) Unwrap() error {
+	return err.cause
+}
+
+type decodedJoinError struct {
+	decodedError
+	causes []error
+}
+
+func (err *decodedJoinError) Unwrap() []error {
+	return err.causes
+}
+
+// Rebuild an error from a chain, as rendered by NewError. The stack is attached to the innermost error.
+func decodeErrorChain(chain []ErrorChainEntryJSON, stack []runtime.Frame) error {
+	if len(chain) == 0 {
+		return nil
+	}
+
+	base := decodedError{message: chain[0].Message, errType: chain[0].Type, fields: chain[0].Fields}
+
+	if len(chain[0].Errors) > 0 {
+		joined := &decodedJoinError{decodedError: base}
+
+		for _, branch := range chain[0].Errors {
+			if cause := decodeErrorChain(branch, nil); cause != nil {
+				joined.causes = append(joined.causes, cause)
+			}
+		}
+
+		return joined
+	}
+
+	if len(chain) == 1 {
+		base.stack = stack
+		return &base
+	}
+
+	return &decodedWrapError{decodedError: base, cause: decodeErrorChain(chain[1:], stack)}
+}
+
+func decodeErrorDetails(details *ErrorDetailsJSON) error {
+	if details == nil {
+		return nil
+	}
+
+	stack := lo.Map(details.Stack, func(frame StackFrameJSON, _ int) runtime.Frame {
+		return runtime.Frame{Function: frame.Function, File: frame.File, Line: frame.Line}
+	})
+
+	return decodeErrorChain(details.Chain, stack)
+}
+
+// ==============================================================================================================
+// Loaders.
+// ==============================================================================================================
+
+// decodedLoader is a frame of a loader. It renders the state of the loader at the time of the frame, without
+// animation.
+type decodedLoader struct {
+	frame  LoaderJSON
+	nested quicklog.Message
+
+	quicklog.Message
+}
+
+func (loader *decodedLoader) elapsed() string {
+	if loader.frame.ElapsedNanos == 0 && loader.frame.Elapsed != "" {
+		return loader.frame.Elapsed
+	}
+
+	return renderLoaderElapsed(time.Duration(loader.frame.ElapsedNanos))
+}
+
+func (loader *decodedLoader) RenderTerminal() string {
+	status := loaderStatus(loader.frame.Status)
+
+	prefix := renderLoaderPrefix(status)
+	if status != loaderStatusSuccess && status != loaderStatusError {
+		prefix = loaderStatusSymbol(status)
+	}
+
+	return quicklog.RenderWithChildTerminal(
+		renderLoaderLine(status, prefix, loader.frame.Message, loader.elapsed()), loader.nested,
+	)
+}
+
+func (loader *decodedLoader) RenderJSON() map[string]interface{} {
+	output := quicklog.RenderKindJSON(KindLoader, map[string]interface{}{
+		"message":       loader.frame.Message,
+		"elapsed":       loader.frame.Elapsed,
+		"elapsed_nanos": loader.frame.ElapsedNanos,
+		"op_id":         loader.frame.OpID,
+		"status":        loader.frame.Status,
+	})
+
+	return quicklog.RenderWithChildJSON(output, loader.nested)
+}
+
+func (loader *decodedLoader) RenderMarkdown() string {
+	content := loaderStatusSymbol(loaderStatus(loader.frame.Status)) + " " + escapeMarkdown(loader.frame.Message) +
+		" *(" + loader.elapsed() + ")*\n\n"
+
+	return quicklog.RenderWithChildMarkdown(content, loader.nested)
+}
+
+func (loader *decodedLoader) RenderHTML() string {
+	content := fmt.Sprintf(
+		`<p class="quicklog-loader quicklog-loader-%s">%s %s <small>%s</small></p>`+"\n",
+		html.EscapeString(loader.frame.Status), loaderStatusSymbol(loaderStatus(loader.frame.Status)),
+		html.EscapeString(loader.frame.Message), loader.elapsed(),
+	)
+
+	return quicklog.RenderWithChildHTML(content, loader.nested)
+}
+
+func (loader *decodedLoader) SectionTitle() string {
+	return loader.frame.Message
+}
+
+// ==============================================================================================================
+// Decoding.
+// ==============================================================================================================
+
+// Convert a rendering to the type of its kind.
+func decodeAs[T any](rendered map[string]interface{}) (T, error) {
+	var output T
+
+	raw, err := json.Marshal(rendered)
+	if err != nil {
+		return output, err
+	}
+
+	if err = json.Unmarshal(raw, &output); err != nil {
+		return output, fmt.Errorf("decode %T: %w", output, err)
+	}
+
+	return output, nil
+}
+
+func decodeChildren(children []MessageJSON) ([]quicklog.Message, error) {
+	output := make([]quicklog.Message, 0, len(children))
+
+	for _, child := range children {
+		decoded, err := Decode(child)
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, decoded)
+	}
+
+	return output, nil
+}
+
+// decodedText is a text of a rendering that may contain inline links, with the key it is rendered under.
+type decodedText struct {
+	key  string
+	text *string
+}
+
+// Restore the inline links of texts, as rendered by NewBase and NewTitle. Links are restored from the last one, so
+// the offsets of the previous links remain valid.
+func restoreLinks(links []InlineLinkJSON, texts ...decodedText) error {
+	// Older versions did not render the position of links.
+	if len(links) > 0 && links[0].Field == "" {
+		restoreLegacyLinks(links, texts...)
+
+		return nil
+	}
+
+	sorted := slices.Clone(links)
+	slices.SortStableFunc(sorted, func(a, b InlineLinkJSON) int {
+		return b.Offset - a.Offset
+	})
+
+	for _, link := range sorted {
+		text, ok := lo.Find(texts, func(text decodedText) bool {
+			return text.key == link.Field
+		})
+		end := link.Offset + len(link.Text)
+
+		if !ok || link.Offset < 0 || end > len(*text.text) || (*text.text)[link.Offset:end] != link.Text {
+			return fmt.Errorf("%w: %q is not at offset %d of %q", ErrInvalidLink, link.Text, link.Offset, link.Field)
+		}
+
+		*text.text = (*text.text)[:link.Offset] + "[" + link.Text + "](" + link.URL + ")" + (*text.text)[end:]
+	}
+
+	return nil
+}
+
+// Restore links without a position, on the first occurrence of their text. Links are listed in the order they
+// appear, across all texts.
+func restoreLegacyLinks(links []InlineLinkJSON, texts ...decodedText) {
+	for _, text := range texts {
+		cursor := 0
+
+		for len(links) > 0 {
+			position := strings.Index((*text.text)[cursor:], links[0].Text)
+			if position < 0 {
+				break
+			}
+
+			inline := "[" + links[0].Text + "](" + links[0].URL + ")"
+			*text.text = (*text.text)[:cursor+position] + inline + (*text.text)[cursor+position+len(links[0].Text):]
+			cursor += position + len(inline)
+			links = links[1:]
+		}
+	}
+}
+
+func decodeBase(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[BaseJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := decodeChildren(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = restoreLinks(payload.Links, decodedText{key: "message", text: &payload.Message}); err != nil {
+		return nil, err
+	}
+
+	return NewBase(payload.Message, children...), nil
+}
+
+func decodeTitle(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[TitleJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := decodeChildren(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = restoreLinks(
+		payload.Links,
+		decodedText{key: "message", text: &payload.Message},
+		decodedText{key: "content", text: &payload.Content},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTitle(payload.Message, payload.Content, children...), nil
+}
+
+func decodeError(rendered map[string]interface{}) (quicklog.Message, error) {
+	// Older versions rendered the error as a plain string.
+	if legacy, ok := rendered["error"].(string); ok {
+		message, _ := rendered["message"].(string)
+		return NewError(errors.New(legacy), lo.Ternary(message == legacy, "", message)), nil
+	}
+
+	payload, err := decodeAs[ErrorJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	cause := decodeErrorDetails(payload.Error)
+	// Errors logged without a message use the error as the message.
+	if cause != nil && cause.Error() == payload.Message {
+		payload.Message = ""
+	}
+
+	return NewError(cause, payload.Message), nil
+}
+
+func decodePanic(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[PanicJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	// Panics with an error value keep the details of the error.
+	if cause := decodeErrorDetails(payload.Error); cause != nil {
+		return NewPanic(cause, []byte(payload.Stack)), nil
+	}
+
+	return NewPanic(payload.Panic, []byte(payload.Stack)), nil
+}
+
+func decodeLoader(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[LoaderJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &decodedLoader{frame: payload}
+
+	if payload.Data != nil {
+		if loader.nested, err = Decode(payload.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return loader, nil
+}
+
+func decodeCode(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[CodeJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCode(payload.Language, payload.Code, &CodeConfigDefault), nil
+}
+
+func decodeDiff(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[DiffJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	hunks := make([]diffHunk, len(payload.Hunks))
+
+	for i, hunk := range payload.Hunks {
+		hunks[i] = diffHunk{
+			oldStart: hunk.OldStart,
+			oldLines: hunk.OldLines,
+			newStart: hunk.NewStart,
+			newLines: hunk.NewLines,
+			edits:    make([]diffEdit, len(hunk.Lines)),
+		}
+
+		// Each line starts with its operation: " ", "+" or "-".
+		for j, line := range hunk.Lines {
+			if line == "" || !lo.Contains([]byte{diffOpEqual, diffOpInsert, diffOpDelete}, line[0]) {
+				return nil, fmt.Errorf("%w: line %d of hunk %d has no operation: %q", ErrInvalidDiffHunk, j, i, line)
+			}
+
+			hunks[i].edits[j] = diffEdit{op: line[0], text: line[1:]}
+		}
+	}
+
+	return &diffMessage{
+		hunks: hunks,
+		config: DiffConfig{
+			Context:            DiffConfigDefault.Context,
+			SideBySideMinWidth: DiffConfigDefault.SideBySideMinWidth,
+			BeforeLabel:        payload.Before,
+			AfterLabel:         payload.After,
+		},
+	}, nil
+}
+
+func decodeMapDiff(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[MapDiffJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []mapChange
+
+	for path, value := range payload.Added {
+		changes = append(changes, mapChange{path: path, op: diffOpInsert, after: value})
+	}
+
+	for path, value := range payload.Removed {
+		changes = append(changes, mapChange{path: path, op: diffOpDelete, before: value})
+	}
+
+	for path, change := range payload.Changed {
+		changes = append(changes, mapChange{path: path, op: diffOpChange, before: change.Before, after: change.After})
+	}
+
+	// Changes are listed by path, as NewMapDiff does.
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].path < changes[j].path
+	})
+
+	return &mapDiffMessage{
+		changes: changes,
+		config:  DiffConfig{BeforeLabel: payload.Before, AfterLabel: payload.After},
+	}, nil
+}
+
+func decodeLink(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[LinkJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLink(payload.Message, payload.URL), nil
+}
+
+func decodeText(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[TextJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	// Texts without styles do not list their spans.
+	if len(payload.Spans) == 0 {
+		return Text().Plain(payload.Message), nil
+	}
+
+	text := Text()
+
+	for _, span := range payload.Spans {
+		switch richTextStyle(span.Style) {
+		case richTextBold:
+			text = text.Bold(span.Text)
+		case richTextDim:
+			text = text.Dim(span.Text)
+		case richTextItalic:
+			text = text.Italic(span.Text)
+		case richTextUnderline:
+			text = text.Underline(span.Text)
+		case richTextCode:
+			text = text.Code(span.Text)
+		case richTextColor:
+			text = text.Color(span.Text, span.Color)
+		case richTextLink:
+			text = text.Link(span.Text, span.URL)
+		default:
+			text = text.Plain(span.Text)
+		}
+	}
+
+	return text, nil
+}
+
+// Rebuild a group. The header is rendered from the other keys of the group.
+func decodeGroup(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[GroupJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := decodeChildren(payload.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	header := lo.OmitByKeys(rendered, []string{
+		quicklog.KindKey, quicklog.SchemaVersionKey, "header_kind", "children", "trace_id", "span_id",
+	})
+
+	// Older versions did not set the kind of the header.
+	if payload.HeaderKind == "" && header["message"] == nil {
+		return NewGroup(nil, children...), nil
+	}
+
+	if payload.HeaderKind != "" {
+		header[quicklog.KindKey] = payload.HeaderKind
+	}
+
+	decodedHeader, err := Decode(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGroup(decodedHeader, children...), nil
+}
+
+func decodeTreeNode(node TreeNodeJSON) (TreeNode, error) {
+	output := TreeNode{Label: node.Label}
+
+	if node.Detail != nil {
+		detail, err := Decode(node.Detail)
+		if err != nil {
+			return output, err
+		}
+
+		output.Detail = detail
+	}
+
+	for _, child := range node.Children {
+		decoded, err := decodeTreeNode(child)
+		if err != nil {
+			return output, err
+		}
+
+		output.Children = append(output.Children, decoded)
+	}
+
+	return output, nil
+}
+
+func decodeTree(rendered map[string]interface{}) (quicklog.Message, error) {
+	payload, err := decodeAs[TreeJSON](rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := decodeTreeNode(payload.Tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTree(root), nil
+}
+
+// Return the decoder of a kind, or nil if the kind is unknown.
+func kindDecoder(kind string) func(rendered map[string]interface{}) (quicklog.Message, error) {
+	switch kind {
+	case KindBase:
+		return decodeBase
+	case KindTitle:
+		return decodeTitle
+	case KindError:
+		return decodeError
+	case KindPanic:
+		return decodePanic
+	case KindLoader:
+		return decodeLoader
+	case KindCode:
+		return decodeCode
+	case KindDiff:
+		return decodeDiff
+	case KindMapDiff:
+		return decodeMapDiff
+	case KindLink:
+		return decodeLink
+	case KindText:
+		return decodeText
+	case KindGroup:
+		return decodeGroup
+	case KindTree:
+		return decodeTree
+	default:
+		return nil
+	}
+}
+
+// Guess the kind of renderings made before the kind was rendered, from their keys.
+func legacyKind(rendered map[string]interface{}) string {
+	has := func(key string) bool {
+		_, ok := rendered[key]
+		return ok
+	}
+
+	switch {
+	case has("children"):
+		return KindGroup
+	case has("panic"):
+		return KindPanic
+	case has("error"):
+		return KindError
+	case has("op_id") && has("status"):
+		return KindLoader
+	case has("tree"):
+		return KindTree
+	case has("code"):
+		return KindCode
+	case has("hunks"):
+		return KindDiff
+	case has("added") && has("removed") && has("changed"):
+		return KindMapDiff
+	case has("spans"):
+		return KindText
+	case has("url"):
+		return KindLink
+	case has("content"):
+		return KindTitle
+	default:
+		return KindBase
+	}
+}
+
+// Attach the trace of a rendering to the decoded message, so it is rendered again.
+func decodeTrace(rendered map[string]interface{}, message quicklog.Message) quicklog.Message {
+	traceID, _ := rendered["trace_id"].(string)
+	spanID, _ := rendered["span_id"].(string)
+
+	parsedTraceID, traceErr := trace.TraceIDFromHex(traceID)
+	parsedSpanID, spanErr := trace.SpanIDFromHex(spanID)
+
+	if traceErr != nil || spanErr != nil {
+		return message
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: parsedTraceID,
+		SpanID:  parsedSpanID,
+		Remote:  true,
+	})
+
+	return NewTraced(trace.ContextWithSpanContext(context.Background(), spanContext), message)
+}
+
+// Decode rebuilds a message from its JSON rendering, so it can be rendered again in any format. The rendering may
+// come from an earlier version of the schema. A nil rendering, as returned by empty messages, decodes to an empty
+// message.
+//
+// Messages are rebuilt from the kind of the rendering. Loaders are rebuilt as a static message, that renders the
+// state of the frame.
+func Decode(rendered map[string]interface{}) (quicklog.Message, error) {
+	if rendered == nil {
+		return NewBase(""), nil
+	}
+
+	version, _ := rendered[quicklog.SchemaVersionKey].(float64)
+	if intVersion, ok := rendered[quicklog.SchemaVersionKey].(int); ok {
+		version = float64(intVersion)
+	}
+
+	if version > quicklog.SchemaVersion {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedSchemaVersion, rendered[quicklog.SchemaVersionKey])
+	}
+
+	kind, _ := rendered[quicklog.KindKey].(string)
+	if kind == "" {
+		kind = legacyKind(rendered)
+	}
+
+	decoder := kindDecoder(kind)
+	if decoder == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+
+	message, err := decoder(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeTrace(rendered, message), nil
+}
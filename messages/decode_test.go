@@ -0,0 +1,301 @@
+package messages_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Encode a rendering as it would be written to a log, and read it back.
+func roundTripJSON(t *testing.T, rendered map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	raw, err := json.Marshal(rendered)
+	require.NoError(t, err)
+
+	var output map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &output))
+
+	return output
+}
+
+func TestDecode(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	defer span.End()
+
+	testCases := []struct {
+		name string
+
+		message quicklog.Message
+		kind    string
+	}{
+		{
+			name:    "Base",
+			message: messages.NewBase("See [logs](https://logs.io)", messages.NewBase("First"), messages.NewBase("Second")),
+			kind:    messages.KindBase,
+		},
+		{
+			name:    "Title",
+			message: messages.NewTitle("Deploy [v1](https://example.com)", "to production", messages.NewBase("Child")),
+			kind:    messages.KindTitle,
+		},
+		{
+			// The text of the link appears before the link.
+			name:    "BaseRepeatedLinkText",
+			message: messages.NewBase("see docs: [docs](https://docs.io)"),
+			kind:    messages.KindBase,
+		},
+		{
+			name:    "TitleRepeatedLinkText",
+			message: messages.NewTitle("logs", "See [logs](https://logs.io)"),
+			kind:    messages.KindTitle,
+		},
+		{
+			name:    "Error",
+			message: messages.NewError(errors.New("out of memory"), "Deploy failed"),
+			kind:    messages.KindError,
+		},
+		{
+			name:    "ErrorWithoutMessage",
+			message: messages.NewError(errors.New("out of memory"), ""),
+			kind:    messages.KindError,
+		},
+		{
+			name:    "Panic",
+			message: messages.NewPanic("something went wrong", dummyStack),
+			kind:    messages.KindPanic,
+		},
+		{
+			name:    "Code",
+			message: messages.NewCode("go", "package main\n", &messages.CodeConfigDefault),
+			kind:    messages.KindCode,
+		},
+		{
+			name: "Diff",
+			message: messages.NewDiff("a\nb\nc\n", "a\nB\nc\n", &messages.DiffConfig{
+				BeforeLabel: "old.txt",
+				AfterLabel:  "new.txt",
+			}),
+			kind: messages.KindDiff,
+		},
+		{
+			name:    "MapDiff",
+			message: messages.NewMapDiff(mapDiffBefore, mapDiffAfter, &messages.DiffConfigDefault),
+			kind:    messages.KindMapDiff,
+		},
+		{
+			name:    "Link",
+			message: messages.NewLink("Dashboard", "https://example.com"),
+			kind:    messages.KindLink,
+		},
+		{
+			name:    "Text",
+			message: messages.Text().Plain("Deployed ").Bold("api").Color(" to prod", "9"),
+			kind:    messages.KindText,
+		},
+		{
+			name:    "PlainText",
+			message: messages.Text().Plain("Deployed api"),
+			kind:    messages.KindText,
+		},
+		{
+			name:    "Group",
+			message: messages.NewGroup(messages.NewTitle("Deploying", "to production"), messages.NewBase("api")),
+			kind:    messages.KindGroup,
+		},
+		{
+			name:    "GroupWithoutHeader",
+			message: messages.NewGroup(nil, messages.NewBase("api")),
+			kind:    messages.KindGroup,
+		},
+		{
+			name:    "Tree",
+			message: messages.NewTree(dummyTree),
+			kind:    messages.KindTree,
+		},
+		{
+			name:    "Traced",
+			message: messages.NewTraced(ctx, messages.NewBase("Hello world")),
+			kind:    messages.KindBase,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered := roundTripJSON(t, testCase.message.RenderJSON())
+			require.Equal(t, testCase.kind, rendered[quicklog.KindKey])
+			require.InDelta(t, quicklog.SchemaVersion, rendered[quicklog.SchemaVersionKey], 0)
+
+			decoded, err := messages.Decode(rendered)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.message.RenderTerminal(), decoded.RenderTerminal())
+			require.Equal(t, quicklog.RenderMarkdown(testCase.message), quicklog.RenderMarkdown(decoded))
+			require.Equal(t, rendered, roundTripJSON(t, decoded.RenderJSON()))
+		})
+	}
+}
+
+func TestDecodeErrorChain(t *testing.T) {
+	err := fmt.Errorf("load config: %w", errors.Join(errors.New("first error"), fieldsError{}))
+	message := messages.NewError(err, "")
+
+	rendered := roundTripJSON(t, message.RenderJSON())
+
+	decoded, decodeErr := messages.Decode(rendered)
+	require.NoError(t, decodeErr)
+
+	// Joined errors and fields are preserved, along with the types of the original errors.
+	require.Equal(t, message.RenderTerminal(), decoded.RenderTerminal())
+	require.Equal(t, rendered, roundTripJSON(t, decoded.RenderJSON()))
+}
+
+func TestDecodeLoader(t *testing.T) {
+	opID := uuid.MustParse("2b7b7a5e-1f2c-4a4e-9b1a-6f0e2c6d1a3b")
+
+	frame := map[string]interface{}{
+		"kind":           "loader",
+		"schema_version": float64(1),
+		"message":        "Built",
+		"elapsed":        "1.5s",
+		"elapsed_nanos":  float64(1500000000),
+		"op_id":          opID.String(),
+		"status":         "success",
+		"data":           map[string]interface{}{"kind": "base", "message": "3 artifacts"},
+	}
+
+	decoded, err := messages.Decode(frame)
+	require.NoError(t, err)
+
+	require.Contains(t, decoded.RenderTerminal(), "✓ Built")
+	require.Contains(t, decoded.RenderTerminal(), "1.5s")
+	require.Contains(t, decoded.RenderTerminal(), "3 artifacts")
+	require.Equal(t, "✓ Built *(1.5s)*\n\n3 artifacts\n\n", quicklog.RenderMarkdown(decoded))
+	require.Equal(t, frame, roundTripJSON(t, decoded.RenderJSON()))
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		rendered map[string]interface{}
+
+		expect quicklog.Message
+	}{
+		{
+			name:     "Base",
+			rendered: map[string]interface{}{"message": "Starting", "data": map[string]interface{}{"message": "child"}},
+			expect:   messages.NewBase("Starting", messages.NewBase("child")),
+		},
+		{
+			name:     "Title",
+			rendered: map[string]interface{}{"message": "Deploy", "content": "Deploying the app"},
+			expect:   messages.NewTitle("Deploy", "Deploying the app"),
+		},
+		{
+			name: "Links",
+			rendered: map[string]interface{}{
+				"message": "See logs",
+				"links":   []interface{}{map[string]interface{}{"text": "logs", "url": "https://logs.io"}},
+			},
+			expect: messages.NewBase("See [logs](https://logs.io)"),
+		},
+		{
+			name:     "StringError",
+			rendered: map[string]interface{}{"message": "Deploy failed", "error": "timeout"},
+			expect:   messages.NewError(errors.New("timeout"), "Deploy failed"),
+		},
+		{
+			name:     "Link",
+			rendered: map[string]interface{}{"message": "Dashboard", "url": "https://example.com"},
+			expect:   messages.NewLink("Dashboard", "https://example.com"),
+		},
+		{
+			name: "Group",
+			rendered: map[string]interface{}{
+				"message":  "Deploying",
+				"children": []interface{}{map[string]interface{}{"message": "api"}},
+			},
+			expect: messages.NewGroup(messages.NewBase("Deploying"), messages.NewBase("api")),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			decoded, err := messages.Decode(testCase.rendered)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expect.RenderTerminal(), decoded.RenderTerminal())
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Run("UnknownKind", func(t *testing.T) {
+		_, err := messages.Decode(map[string]interface{}{"kind": "chart", "message": "Sales"})
+		require.ErrorIs(t, err, messages.ErrUnknownKind)
+	})
+
+	t.Run("NewerSchema", func(t *testing.T) {
+		_, err := messages.Decode(map[string]interface{}{
+			"kind": "base", "schema_version": float64(quicklog.SchemaVersion + 1), "message": "Hello",
+		})
+		require.ErrorIs(t, err, messages.ErrUnsupportedSchemaVersion)
+	})
+
+	t.Run("InvalidField", func(t *testing.T) {
+		_, err := messages.Decode(map[string]interface{}{"kind": "base", "message": 42})
+		require.Error(t, err)
+	})
+
+	t.Run("InvalidLink", func(t *testing.T) {
+		_, err := messages.Decode(map[string]interface{}{
+			"kind":    "base",
+			"message": "See logs",
+			"links": []interface{}{
+				map[string]interface{}{"text": "logs", "url": "https://logs.io", "field": "message", "offset": 2},
+			},
+		})
+		require.ErrorIs(t, err, messages.ErrInvalidLink)
+	})
+
+	t.Run("InvalidDiffHunk", func(t *testing.T) {
+		for _, line := range []string{"", "?unknown"} {
+			_, err := messages.Decode(map[string]interface{}{
+				"kind":  "diff",
+				"hunks": []interface{}{map[string]interface{}{"lines": []interface{}{line}}},
+			})
+			require.ErrorIs(t, err, messages.ErrInvalidDiffHunk, line)
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		decoded, err := messages.Decode(nil)
+		require.NoError(t, err)
+		require.Nil(t, decoded.RenderJSON())
+	})
+}
+
+// Every kind has a type, so it is part of the published schema.
+func TestKindTypes(t *testing.T) {
+	for kind, kindType := range messages.KindTypes {
+		raw, err := json.Marshal(kindType)
+		require.NoError(t, err)
+
+		var rendered map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &rendered))
+
+		_, err = messages.Decode(lo.Assign(rendered, map[string]interface{}{"kind": kind}))
+		require.NoError(t, err, kind)
+	}
+}
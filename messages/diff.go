@@ -0,0 +1,677 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// ==============================================================================================================
+// Line diff.
+// ==============================================================================================================
+
+const (
+	diffOpEqual  = ' '
+	diffOpInsert = '+'
+	diffOpDelete = '-'
+)
+
+// diffEdit is a single line of a diff.
+type diffEdit struct {
+	op   byte
+	text string
+}
+
+// diffHunk is a group of changes, surrounded by unchanged lines for context.
+type diffHunk struct {
+	// Position of the hunk in the original text. Line numbers start at 1.
+	oldStart, oldLines int
+	// Position of the hunk in the new text. Line numbers start at 1.
+	newStart, newLines int
+
+	edits []diffEdit
+}
+
+func (hunk diffHunk) header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", hunk.oldStart, hunk.oldLines, hunk.newStart, hunk.newLines)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Maximum number of edits searched by the Myers algorithm. Its trace grows with the square of the number of edits,
+// so texts that differ more are diffed as a single block replacement.
+const diffMaxEdits = 1000
+
+// Compute the shortest sequence of edits that turns before into after. Lines shared by the start and end of both
+// texts are kept apart, so the edit limit only applies to the part that changed.
+func diffLines(before, after []string) []diffEdit {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	beforeChanged, afterChanged := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+
+	edits, ok := diffMyers(beforeChanged, afterChanged)
+	if !ok {
+		edits = append(diffEditsOf(diffOpDelete, beforeChanged), diffEditsOf(diffOpInsert, afterChanged)...)
+	}
+
+	edits = append(diffEditsOf(diffOpEqual, before[:prefix]), edits...)
+
+	return append(edits, diffEditsOf(diffOpEqual, before[len(before)-suffix:])...)
+}
+
+// Apply the same operation to lines.
+func diffEditsOf(op byte, lines []string) []diffEdit {
+	return lo.Map(lines, func(line string, _ int) diffEdit {
+		return diffEdit{op: op, text: line}
+	})
+}
+
+// Compute the shortest sequence of edits that turns before into after, using the Myers algorithm. It returns false
+// if the texts need more than diffMaxEdits edits.
+// http://www.xmailserver.org/diff2.pdf
+func diffMyers(before, after []string) ([]diffEdit, bool) {
+	n, m := len(before), len(after)
+	maxD := n + m
+
+	// Furthest reaching x position on each diagonal k = x - y, offset by maxD so indexes are positive.
+	frontier := make([]int, 2*maxD+2)
+	// Snapshot of the frontier before each round, limited to the diagonals reachable in that round.
+	var trace [][]int
+
+	found := false
+
+search:
+	for d := 0; d <= min(maxD, diffMaxEdits); d++ {
+		trace = append(trace, append([]int(nil), frontier[maxD-d:maxD+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && frontier[maxD+k-1] < frontier[maxD+k+1]) {
+				x = frontier[maxD+k+1]
+			} else {
+				x = frontier[maxD+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && before[x] == after[y] {
+				x++
+				y++
+			}
+
+			frontier[maxD+k] = x
+
+			if x >= n && y >= m {
+				found = true
+				break search
+			}
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	// Walk the trace backward to recover the edits.
+	var edits []diffEdit
+
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		snapshot := trace[d]
+		// Read the frontier of diagonal k, from the snapshot of round d.
+		at := func(k int) int { return snapshot[k+d] }
+
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, diffEdit{op: diffOpEqual, text: before[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, diffEdit{op: diffOpInsert, text: after[y-1]})
+			} else {
+				edits = append(edits, diffEdit{op: diffOpDelete, text: before[x-1]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	return lo.Reverse(edits), true
+}
+
+// Group edits in hunks, keeping up to context unchanged lines around each change.
+func buildHunks(edits []diffEdit, context int) []diffHunk {
+	// Line numbers in the original and new texts, before each edit.
+	oldLine, newLine := make([]int, len(edits)+1), make([]int, len(edits)+1)
+	for i, edit := range edits {
+		oldLine[i+1] = oldLine[i] + lo.Ternary(edit.op != diffOpInsert, 1, 0)
+		newLine[i+1] = newLine[i] + lo.Ternary(edit.op != diffOpDelete, 1, 0)
+	}
+
+	// Ranges of edits covered by each hunk. Changes separated by less than 2 contexts share the same hunk.
+	var ranges [][2]int
+
+	for i, edit := range edits {
+		if edit.op == diffOpEqual {
+			continue
+		}
+
+		start, end := lo.Max([]int{0, i - context}), lo.Min([]int{len(edits), i + context + 1})
+
+		if len(ranges) > 0 && start <= ranges[len(ranges)-1][1] {
+			ranges[len(ranges)-1][1] = end
+			continue
+		}
+
+		ranges = append(ranges, [2]int{start, end})
+	}
+
+	return lo.Map(ranges, func(bounds [2]int, _ int) diffHunk {
+		start, end := bounds[0], bounds[1]
+
+		hunk := diffHunk{
+			oldStart: oldLine[start] + 1,
+			oldLines: oldLine[end] - oldLine[start],
+			newStart: newLine[start] + 1,
+			newLines: newLine[end] - newLine[start],
+			edits:    edits[start:end],
+		}
+
+		// Empty ranges point to the line before them, like GNU diff.
+		if hunk.oldLines == 0 {
+			hunk.oldStart--
+		}
+
+		if hunk.newLines == 0 {
+			hunk.newStart--
+		}
+
+		return hunk
+	})
+}
+
+// ==============================================================================================================
+// Rendering.
+// ==============================================================================================================
+
+var (
+	diffStyles = map[byte]lipgloss.Style{
+		diffOpEqual:  lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true),
+		diffOpInsert: lipgloss.NewStyle().Foreground(lipgloss.Color("46")),
+		diffOpDelete: lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+	}
+	diffHeaderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Faint(true)
+	diffLabelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true)
+)
+
+// Render a single line of a diff, truncated to the given width.
+func renderDiffLine(style lipgloss.Style, text string, width int) string {
+	text = strings.ReplaceAll(text, "\t", "    ")
+
+	return style.Width(width).Render(ansi.Truncate(text, width, "…"))
+}
+
+// Render the labels of the compared texts, if any.
+func renderDiffLabels(config DiffConfig, width int) string {
+	if !config.hasLabels() {
+		return ""
+	}
+
+	return renderDiffLine(diffLabelStyle, "--- "+config.BeforeLabel, width) + "\n" +
+		renderDiffLine(diffLabelStyle, "+++ "+config.AfterLabel, width) + "\n"
+}
+
+// Render the lines of a diff in the unified format, as HTML. If labeled is true, the first 2 lines are the labels
+// of the compared texts.
+func renderDiffHTML(lines []string, labeled bool) string {
+	rendered := lo.Map(lines, func(line string, index int) string {
+		class := lo.Switch[byte, string](line[0]).
+			Case(diffOpInsert, "quicklog-diff-insert").
+			Case(diffOpDelete, "quicklog-diff-delete").
+			Case('@', "quicklog-diff-header").
+			Default("quicklog-diff-equal")
+
+		if labeled && index < 2 {
+			class = "quicklog-diff-header"
+		}
+
+		return `<span class="` + class + `">` + html.EscapeString(line) + "</span>"
+	})
+
+	return `<pre class="quicklog-diff">` + strings.Join(rendered, "\n") + "</pre>\n"
+}
+
+type diffMessage struct {
+	hunks []diffHunk
+
+	config DiffConfig
+
+	quicklog.Message
+}
+
+func (diff *diffMessage) renderUnified(width int) string {
+	output := renderDiffLabels(diff.config, width)
+
+	for _, hunk := range diff.hunks {
+		output += renderDiffLine(diffHeaderStyle, hunk.header(), width) + "\n"
+
+		for _, edit := range hunk.edits {
+			output += renderDiffLine(diffStyles[edit.op], string(edit.op)+" "+edit.text, width) + "\n"
+		}
+	}
+
+	return output
+}
+
+// Return the edit at the given index, or nil if the list is shorter.
+func diffEditAt(edits []*diffEdit, index int) *diffEdit {
+	if index >= len(edits) {
+		return nil
+	}
+
+	return edits[index]
+}
+
+// Render the hunks in 2 columns, the original text on the left and the new one on the right.
+func (diff *diffMessage) renderSideBySide(width int) string {
+	separator := diffStyles[diffOpEqual].Render(" │ ")
+	// The right column takes the remaining column when the width is odd.
+	leftWidth := (width - lipgloss.Width(separator)) / 2
+	rightWidth := width - lipgloss.Width(separator) - leftWidth
+
+	renderCell := func(edit *diffEdit, width int) string {
+		if edit == nil {
+			return strings.Repeat(" ", width)
+		}
+
+		return renderDiffLine(diffStyles[edit.op], string(edit.op)+" "+edit.text, width)
+	}
+
+	output := renderDiffLabels(diff.config, width)
+
+	for _, hunk := range diff.hunks {
+		output += renderDiffLine(diffHeaderStyle, hunk.header(), width) + "\n"
+
+		for i := 0; i < len(hunk.edits); {
+			if hunk.edits[i].op == diffOpEqual {
+				output += renderCell(&hunk.edits[i], leftWidth) + separator + renderCell(&hunk.edits[i], rightWidth) + "\n"
+				i++
+
+				continue
+			}
+
+			// Align a run of deleted lines with the lines that replace them.
+			var deleted, inserted []*diffEdit
+
+			for ; i < len(hunk.edits) && hunk.edits[i].op != diffOpEqual; i++ {
+				if hunk.edits[i].op == diffOpDelete {
+					deleted = append(deleted, &hunk.edits[i])
+				} else {
+					inserted = append(inserted, &hunk.edits[i])
+				}
+			}
+
+			for row := 0; row < lo.Max([]int{len(deleted), len(inserted)}); row++ {
+				output += renderCell(diffEditAt(deleted, row), leftWidth) + separator +
+					renderCell(diffEditAt(inserted, row), rightWidth) + "\n"
+			}
+		}
+	}
+
+	return output
+}
+
+func (diff *diffMessage) RenderTerminal() string {
+	return diff.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (diff *diffMessage) RenderTerminalWidth(width int) string {
+	if len(diff.hunks) == 0 {
+		return diffStyles[diffOpEqual].Width(width).Render("No changes.") + "\n"
+	}
+
+	if diff.config.SideBySide && width >= diff.config.SideBySideMinWidth {
+		return diff.renderSideBySide(width)
+	}
+
+	return diff.renderUnified(width)
+}
+
+func (diff *diffMessage) RenderJSON() map[string]interface{} {
+	output := map[string]interface{}{
+		"hunks": lo.Map(diff.hunks, func(hunk diffHunk, _ int) map[string]interface{} {
+			return map[string]interface{}{
+				"old_start": hunk.oldStart,
+				"old_lines": hunk.oldLines,
+				"new_start": hunk.newStart,
+				"new_lines": hunk.newLines,
+				"lines": lo.Map(hunk.edits, func(edit diffEdit, _ int) string {
+					return string(edit.op) + edit.text
+				}),
+			}
+		}),
+	}
+
+	if diff.config.BeforeLabel != "" {
+		output["before"] = diff.config.BeforeLabel
+	}
+
+	if diff.config.AfterLabel != "" {
+		output["after"] = diff.config.AfterLabel
+	}
+
+	return quicklog.RenderKindJSON(KindDiff, output)
+}
+
+// Render the diff in the unified format, without styles.
+func (diff *diffMessage) renderPlain() string {
+	var output string
+
+	if diff.config.hasLabels() {
+		output = "--- " + diff.config.BeforeLabel + "\n+++ " + diff.config.AfterLabel + "\n"
+	}
+
+	for _, hunk := range diff.hunks {
+		output += hunk.header() + "\n"
+
+		for _, edit := range hunk.edits {
+			output += string(edit.op) + edit.text + "\n"
+		}
+	}
+
+	return output
+}
+
+func (diff *diffMessage) RenderMarkdown() string {
+	if len(diff.hunks) == 0 {
+		return markdownParagraph("No changes.")
+	}
+
+	return markdownCodeBlock("diff", diff.renderPlain()) + "\n"
+}
+
+func (diff *diffMessage) RenderHTML() string {
+	if len(diff.hunks) == 0 {
+		return htmlParagraph("quicklog-diff", "No changes.")
+	}
+
+	return renderDiffHTML(
+		strings.Split(strings.TrimSuffix(diff.renderPlain(), "\n"), "\n"), diff.config.hasLabels(),
+	)
+}
+
+type DiffConfig struct {
+	// Optional.
+
+	// Context is the number of unchanged lines displayed around each change. Defaults to 3.
+	Context *int
+	// SideBySide displays the original and new texts in 2 columns, when the terminal is wide enough.
+	SideBySide bool
+	// SideBySideMinWidth is the minimum width of the terminal for the side-by-side mode. Narrower terminals use
+	// the unified mode. Defaults to 100.
+	SideBySideMinWidth int
+	// BeforeLabel and AfterLabel name the compared texts, for example with their file path.
+	BeforeLabel string
+	AfterLabel  string
+}
+
+func (config DiffConfig) hasLabels() bool {
+	return config.BeforeLabel != "" || config.AfterLabel != ""
+}
+
+var DiffConfigDefault = DiffConfig{
+	Context:            lo.ToPtr(3),
+	SideBySideMinWidth: 100,
+}
+
+// NewDiff computes the changes between 2 texts, line by line, and renders them as a diff.
+func NewDiff(before, after string, config *DiffConfig) quicklog.Message {
+	diffConfig := DiffConfig{
+		Context:            lo.CoalesceOrEmpty(config.Context, DiffConfigDefault.Context),
+		SideBySide:         config.SideBySide,
+		SideBySideMinWidth: lo.CoalesceOrEmpty(config.SideBySideMinWidth, DiffConfigDefault.SideBySideMinWidth),
+		BeforeLabel:        config.BeforeLabel,
+		AfterLabel:         config.AfterLabel,
+	}
+
+	return &diffMessage{
+		hunks:  buildHunks(diffLines(splitLines(before), splitLines(after)), lo.FromPtr(diffConfig.Context)),
+		config: diffConfig,
+	}
+}
+
+// ==============================================================================================================
+// Structured diff.
+// ==============================================================================================================
+
+const diffOpChange = '~'
+
+// mapChange is a key that differs between 2 maps.
+type mapChange struct {
+	// Path of the key. Keys of nested maps are joined with dots.
+	path   string
+	op     byte
+	before interface{}
+	after  interface{}
+}
+
+// Compare 2 maps, key by key. Nested maps are compared recursively.
+func diffMaps(prefix string, before, after map[string]interface{}) []mapChange {
+	var changes []mapChange
+
+	keys := lo.Uniq(append(sortedFieldKeys(before), sortedFieldKeys(after)...))
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		beforeValue, inBefore := before[key]
+		afterValue, inAfter := after[key]
+
+		switch {
+		case !inBefore:
+			changes = append(changes, mapChange{path: prefix + key, op: diffOpInsert, after: afterValue})
+		case !inAfter:
+			changes = append(changes, mapChange{path: prefix + key, op: diffOpDelete, before: beforeValue})
+		case reflect.DeepEqual(beforeValue, afterValue):
+			continue
+		default:
+			beforeMap, beforeIsMap := beforeValue.(map[string]interface{})
+			afterMap, afterIsMap := afterValue.(map[string]interface{})
+
+			if beforeIsMap && afterIsMap {
+				changes = append(changes, diffMaps(prefix+key+".", beforeMap, afterMap)...)
+				continue
+			}
+
+			changes = append(changes, mapChange{
+				path: prefix + key, op: diffOpChange, before: beforeValue, after: afterValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+// Format a value of a map, as it would appear in JSON.
+func formatMapValue(value interface{}) string {
+	rendered, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(rendered)
+}
+
+// Return the lines of a change, in the unified format. Changed keys are displayed as a removal followed by an
+// addition.
+func (change mapChange) plainLines() []string {
+	return lo.Switch[byte, []string](change.op).
+		Case(diffOpInsert, []string{"+ " + change.path + ": " + formatMapValue(change.after)}).
+		Case(diffOpDelete, []string{"- " + change.path + ": " + formatMapValue(change.before)}).
+		Default([]string{
+			"- " + change.path + ": " + formatMapValue(change.before),
+			"+ " + change.path + ": " + formatMapValue(change.after),
+		})
+}
+
+type mapDiffMessage struct {
+	changes []mapChange
+
+	config DiffConfig
+
+	quicklog.Message
+}
+
+func (diff *mapDiffMessage) RenderTerminal() string {
+	return diff.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (diff *mapDiffMessage) RenderTerminalWidth(width int) string {
+	if len(diff.changes) == 0 {
+		return diffStyles[diffOpEqual].Width(width).Render("No changes.") + "\n"
+	}
+
+	changeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("33"))
+
+	output := renderDiffLabels(diff.config, width)
+
+	for _, change := range diff.changes {
+		switch change.op {
+		case diffOpInsert:
+			output += renderDiffLine(
+				diffStyles[diffOpInsert], "+ "+change.path+": "+formatMapValue(change.after), width,
+			)
+		case diffOpDelete:
+			output += renderDiffLine(
+				diffStyles[diffOpDelete], "- "+change.path+": "+formatMapValue(change.before), width,
+			)
+		default:
+			output += renderDiffLine(
+				changeStyle,
+				"~ "+change.path+": "+formatMapValue(change.before)+" → "+formatMapValue(change.after),
+				width,
+			)
+		}
+
+		output += "\n"
+	}
+
+	return output
+}
+
+func (diff *mapDiffMessage) RenderJSON() map[string]interface{} {
+	added := map[string]interface{}{}
+	removed := map[string]interface{}{}
+	changed := map[string]interface{}{}
+
+	for _, change := range diff.changes {
+		switch change.op {
+		case diffOpInsert:
+			added[change.path] = change.after
+		case diffOpDelete:
+			removed[change.path] = change.before
+		default:
+			changed[change.path] = map[string]interface{}{"before": change.before, "after": change.after}
+		}
+	}
+
+	output := map[string]interface{}{
+		"added":   added,
+		"removed": removed,
+		"changed": changed,
+	}
+
+	if diff.config.BeforeLabel != "" {
+		output["before"] = diff.config.BeforeLabel
+	}
+
+	if diff.config.AfterLabel != "" {
+		output["after"] = diff.config.AfterLabel
+	}
+
+	return quicklog.RenderKindJSON(KindMapDiff, output)
+}
+
+// Render the changes in the unified format, without styles.
+func (diff *mapDiffMessage) renderPlain() []string {
+	var lines []string
+
+	if diff.config.hasLabels() {
+		lines = append(lines, "--- "+diff.config.BeforeLabel, "+++ "+diff.config.AfterLabel)
+	}
+
+	for _, change := range diff.changes {
+		lines = append(lines, change.plainLines()...)
+	}
+
+	return lines
+}
+
+func (diff *mapDiffMessage) RenderMarkdown() string {
+	if len(diff.changes) == 0 {
+		return markdownParagraph("No changes.")
+	}
+
+	return markdownCodeBlock("diff", strings.Join(diff.renderPlain(), "\n")) + "\n"
+}
+
+func (diff *mapDiffMessage) RenderHTML() string {
+	if len(diff.changes) == 0 {
+		return htmlParagraph("quicklog-diff", "No changes.")
+	}
+
+	return renderDiffHTML(diff.renderPlain(), diff.config.hasLabels())
+}
+
+// NewMapDiff compares 2 maps, and renders the keys that were added, removed or changed. Nested maps are compared
+// recursively, and their keys are joined with dots.
+func NewMapDiff(before, after map[string]interface{}, config *DiffConfig) quicklog.Message {
+	return &mapDiffMessage{
+		changes: diffMaps("", before, after),
+		config: DiffConfig{
+			BeforeLabel: config.BeforeLabel,
+			AfterLabel:  config.AfterLabel,
+		},
+	}
+}
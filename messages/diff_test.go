@@ -0,0 +1,311 @@
+package messages_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+const (
+	diffBefore = "a\nb\nc\nd\ne\nf\ng\nh\n"
+	diffAfter  = "a\nB\nc\nd\ne\nf\ng\nh\ni\n"
+)
+
+func TestDiffTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		before string
+		after  string
+		config *messages.DiffConfig
+
+		termWidth int
+
+		expect string
+	}{
+		{
+			name: "Unified",
+
+			before: diffBefore,
+			after:  diffAfter,
+			config: &messages.DiffConfig{Context: lo.ToPtr(1), BeforeLabel: "old.txt", AfterLabel: "new.txt"},
+
+			expect: "--- old.txt                                                                     \n" +
+				"+++ new.txt                                                                     \n" +
+				"@@ -1,3 +1,3 @@                                                                 \n" +
+				"  a                                                                             \n" +
+				"- b                                                                             \n" +
+				"+ B                                                                             \n" +
+				"  c                                                                             \n" +
+				"@@ -8,1 +8,2 @@                                                                 \n" +
+				"  h                                                                             \n" +
+				"+ i                                                                             \n",
+		},
+		{
+			name: "MergedHunks",
+
+			before: diffBefore,
+			after:  diffAfter,
+			config: &messages.DiffConfigDefault,
+
+			expect: "@@ -1,8 +1,9 @@                                                                 \n" +
+				"  a                                                                             \n" +
+				"- b                                                                             \n" +
+				"+ B                                                                             \n" +
+				"  c                                                                             \n" +
+				"  d                                                                             \n" +
+				"  e                                                                             \n" +
+				"  f                                                                             \n" +
+				"  g                                                                             \n" +
+				"  h                                                                             \n" +
+				"+ i                                                                             \n",
+		},
+		{
+			name: "EmptyBefore",
+
+			after:  "a\n",
+			config: &messages.DiffConfigDefault,
+
+			expect: "@@ -0,0 +1,1 @@                                                                 \n" +
+				"+ a                                                                             \n",
+		},
+		{
+			name: "NoChanges",
+
+			before: diffBefore,
+			after:  diffBefore,
+			config: &messages.DiffConfigDefault,
+
+			expect: "No changes.                                                                     \n",
+		},
+		{
+			name: "LongLines",
+
+			before: "short\n",
+			after: "this line is way too long to fit in the terminal, so it will be truncated with an " +
+				"ellipsis\n",
+			config: &messages.DiffConfigDefault,
+
+			expect: "@@ -1,1 +1,1 @@                                                                 \n" +
+				"- short                                                                         \n" +
+				"+ this line is way too long to fit in the terminal, so it will be truncated wit…\n",
+		},
+		{
+			name: "SideBySide",
+
+			before: "a\nb\nc\n",
+			after:  "a\nB\nB2\nc\n",
+			config: &messages.DiffConfig{SideBySide: true},
+
+			termWidth: 40,
+
+			// The right column takes the remaining cell, since the width is odd.
+			expect: "@@ -1,3 +1,4 @@                         \n" +
+				"  a                │   a                \n" +
+				"- b                │ + B                \n" +
+				"                   │ + B2               \n" +
+				"  c                │   c                \n",
+		},
+		{
+			name: "SideBySideTooNarrow",
+
+			before: "a\n",
+			after:  "b\n",
+			config: &messages.DiffConfig{SideBySide: true, SideBySideMinWidth: 100},
+
+			expect: "@@ -1,1 +1,1 @@                                                                 \n" +
+				"- a                                                                             \n" +
+				"+ b                                                                             \n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.termWidth > 0 {
+				termWidth := quicklog.TermWidth
+				quicklog.TermWidth = testCase.termWidth
+
+				defer func() { quicklog.TermWidth = termWidth }()
+
+				testCase.config.SideBySideMinWidth = testCase.termWidth
+			}
+
+			message := messages.NewDiff(testCase.before, testCase.after, testCase.config)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	message := messages.NewDiff(diffBefore, diffAfter, &messages.DiffConfig{
+		Context:     lo.ToPtr(0),
+		BeforeLabel: "old.txt",
+		AfterLabel:  "new.txt",
+	})
+
+	require.Equal(t, map[string]interface{}{
+		"kind":           "diff",
+		"schema_version": quicklog.SchemaVersion,
+		"before":         "old.txt",
+		"after":          "new.txt",
+		"hunks": []map[string]interface{}{
+			{"old_start": 2, "old_lines": 1, "new_start": 2, "new_lines": 1, "lines": []string{"-b", "+B"}},
+			{"old_start": 8, "old_lines": 0, "new_start": 9, "new_lines": 1, "lines": []string{"+i"}},
+		},
+	}, message.RenderJSON())
+
+	require.Equal(
+		t,
+		map[string]interface{}{
+			"kind": "diff", "schema_version": quicklog.SchemaVersion, "hunks": []map[string]interface{}{},
+		},
+		messages.NewDiff("a", "a", &messages.DiffConfigDefault).RenderJSON(),
+	)
+}
+
+func TestDiffUnrelated(t *testing.T) {
+	before, after := []string{"start"}, []string{"start"}
+	for i := range 50000 {
+		before = append(before, fmt.Sprintf("a%d", i))
+		after = append(after, fmt.Sprintf("b%d", i))
+	}
+
+	var stats runtime.MemStats
+
+	runtime.ReadMemStats(&stats)
+	allocated := stats.TotalAlloc
+
+	rendered := messages.NewDiff(
+		strings.Join(append(before, "end"), "\n"), strings.Join(append(after, "end"), "\n"), &messages.DiffConfigDefault,
+	).RenderJSON()
+
+	runtime.ReadMemStats(&stats)
+
+	// Texts that have too many differences are diffed as a single replacement, without searching for the shortest
+	// sequence of edits.
+	require.Less(t, stats.TotalAlloc-allocated, uint64(256<<20))
+
+	hunks := rendered["hunks"].([]map[string]interface{})
+	require.Len(t, hunks, 1)
+	require.Equal(t, 1, hunks[0]["old_start"])
+	require.Equal(t, 50002, hunks[0]["old_lines"])
+	require.Equal(t, 50002, hunks[0]["new_lines"])
+
+	lines := hunks[0]["lines"].([]string)
+	require.Equal(t, []string{" start", "-a0"}, lines[:2])
+	require.Equal(t, []string{"-a49999", "+b0"}, lines[50000:50002])
+	require.Equal(t, []string{"+b49999", " end"}, lines[len(lines)-2:])
+}
+
+func TestDiffMarkdown(t *testing.T) {
+	message := messages.NewDiff("a\nb\n", "a\n<b>\n", &messages.DiffConfig{BeforeLabel: "old", AfterLabel: "new"})
+
+	require.Equal(
+		t,
+		"```diff\n--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n+<b>\n```\n\n",
+		quicklog.RenderMarkdown(message),
+	)
+	require.Equal(
+		t,
+		"<pre class=\"quicklog-diff\">"+
+			"<span class=\"quicklog-diff-header\">--- old</span>\n"+
+			"<span class=\"quicklog-diff-header\">+++ new</span>\n"+
+			"<span class=\"quicklog-diff-header\">@@ -1,2 +1,2 @@</span>\n"+
+			"<span class=\"quicklog-diff-equal\"> a</span>\n"+
+			"<span class=\"quicklog-diff-delete\">-b</span>\n"+
+			"<span class=\"quicklog-diff-insert\">+&lt;b&gt;</span>"+
+			"</pre>\n",
+		quicklog.RenderHTML(message),
+	)
+}
+
+var (
+	mapDiffBefore = map[string]interface{}{
+		"name":    "quicklog",
+		"stars":   42,
+		"license": "MIT",
+		"owner":   map[string]interface{}{"name": "a-novel-kit", "type": "org"},
+	}
+	mapDiffAfter = map[string]interface{}{
+		"name":     "quicklog",
+		"stars":    43,
+		"archived": false,
+		"owner":    map[string]interface{}{"name": "a-novel-kit", "url": "https://github.com/a-novel-kit"},
+	}
+)
+
+func TestMapDiffTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		before map[string]interface{}
+		after  map[string]interface{}
+
+		expect string
+	}{
+		{
+			name: "Changes",
+
+			before: mapDiffBefore,
+			after:  mapDiffAfter,
+
+			expect: "+ archived: false                                                               \n" +
+				"- license: \"MIT\"                                                                \n" +
+				"- owner.type: \"org\"                                                             \n" +
+				"+ owner.url: \"https://github.com/a-novel-kit\"                                   \n" +
+				"~ stars: 42 → 43                                                                \n",
+		},
+		{
+			name: "NoChanges",
+
+			before: mapDiffBefore,
+			after:  mapDiffBefore,
+
+			expect: "No changes.                                                                     \n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewMapDiff(testCase.before, testCase.after, &messages.DiffConfigDefault)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestMapDiffJSON(t *testing.T) {
+	message := messages.NewMapDiff(mapDiffBefore, mapDiffAfter, &messages.DiffConfigDefault)
+
+	require.Equal(t, map[string]interface{}{
+		"kind":           "map_diff",
+		"schema_version": quicklog.SchemaVersion,
+		"added": map[string]interface{}{
+			"archived":  false,
+			"owner.url": "https://github.com/a-novel-kit",
+		},
+		"removed": map[string]interface{}{
+			"license":    "MIT",
+			"owner.type": "org",
+		},
+		"changed": map[string]interface{}{
+			"stars": map[string]interface{}{"before": 42, "after": 43},
+		},
+	}, message.RenderJSON())
+}
+
+func TestMapDiffMarkdown(t *testing.T) {
+	message := messages.NewMapDiff(
+		map[string]interface{}{"stars": 42},
+		map[string]interface{}{"stars": 43},
+		&messages.DiffConfigDefault,
+	)
+
+	require.Equal(t, "```diff\n- stars: 42\n+ stars: 43\n```\n\n", quicklog.RenderMarkdown(message))
+}
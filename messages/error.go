@@ -1,37 +1,365 @@
 package messages
 
 import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
 
 	"github.com/a-novel-kit/quicklog"
 )
 
+// ErrorWithStack is implemented by errors that carry the stack trace of their creation.
+//
+// Errors exposing a StackTrace method that returns a slice of program counters (such as github.com/pkg/errors) are
+// also supported.
+type ErrorWithStack interface {
+	error
+	StackTrace() []runtime.Frame
+}
+
+// ErrorWithFields is implemented by errors that carry structured information.
+type ErrorWithFields interface {
+	error
+	Fields() map[string]interface{}
+}
+
+// errorNode is a single error in the chain, along with its causes.
+type errorNode struct {
+	err error
+	// The part of the error message that is not inherited from its causes.
+	text   string
+	fields map[string]interface{}
+	stack  []runtime.Frame
+	// Causes of the error. A single cause for wrapped errors, multiple causes for joined errors.
+	causes []*errorNode
+	joined bool
+}
+
+// Convert program counters to readable frames.
+func framesFromCallers(callers []uintptr) []runtime.Frame {
+	var frames []runtime.Frame
+
+	callersFrames := runtime.CallersFrames(callers)
+
+	for {
+		frame, more := callersFrames.Next()
+		frames = append(frames, frame)
+
+		if !more {
+			return frames
+		}
+	}
+}
+
+// Return the frames of an error that carries a stack trace, if any.
+func extractStack(err error) []runtime.Frame {
+	if withStack, ok := err.(ErrorWithStack); ok { //nolint:errorlint
+		return withStack.StackTrace()
+	}
+
+	// Look for a StackTrace method that returns program counters, without depending on the package that
+	// declares it.
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	if trace.Len() == 0 {
+		return nil
+	}
+
+	callers := make([]uintptr, trace.Len())
+	for i := range callers {
+		callers[i] = uintptr(trace.Index(i).Uint())
+	}
+
+	return framesFromCallers(callers)
+}
+
+// Build the tree of causes of an error.
+//
+// Only the deepest stack trace of each branch is kept, since wrappers that record their own stack usually
+// duplicate the one of their cause.
+func buildErrorNode(err error) (node *errorNode, hasStack bool) {
+	node = &errorNode{err: err, text: err.Error()}
+
+	if withFields, ok := err.(ErrorWithFields); ok { //nolint:errorlint
+		node.fields = withFields.Fields()
+	}
+
+	//nolint:errorlint
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapped.Unwrap(); cause != nil {
+			causeNode, causeHasStack := buildErrorNode(cause)
+			node.causes = []*errorNode{causeNode}
+			hasStack = causeHasStack
+			// Wrappers created with fmt.Errorf repeat the message of their cause.
+			node.text = strings.TrimSuffix(node.text, ": "+cause.Error())
+		}
+	case interface{ Unwrap() []error }:
+		node.joined = true
+
+		var causesMessages []string
+
+		for _, cause := range wrapped.Unwrap() {
+			if cause == nil {
+				continue
+			}
+
+			causeNode, causeHasStack := buildErrorNode(cause)
+			node.causes = append(node.causes, causeNode)
+			hasStack = hasStack || causeHasStack
+			causesMessages = append(causesMessages, cause.Error())
+		}
+
+		// Errors created with errors.Join have no message of their own.
+		if node.text == strings.Join(causesMessages, "\n") {
+			node.text = ""
+		}
+	}
+
+	if !hasStack {
+		node.stack = extractStack(err)
+		hasStack = len(node.stack) > 0
+	}
+
+	return node, hasStack
+}
+
+// Return the deepest stack trace in the tree of causes.
+func (node *errorNode) deepestStack() []runtime.Frame {
+	for _, cause := range node.causes {
+		if stack := cause.deepestStack(); len(stack) > 0 {
+			return stack
+		}
+	}
+
+	return node.stack
+}
+
+// Return whether the error carries more information than its message.
+func (node *errorNode) hasDetails() bool {
+	return len(node.causes) > 0 || len(node.fields) > 0 || len(node.stack) > 0
+}
+
+// A node is transparent if it only groups its causes, without adding any information. Those nodes are not displayed
+// in the terminal, and their causes are attached to the parent instead.
+func (node *errorNode) isTransparent() bool {
+	return node.text == "" && len(node.fields) == 0 && len(node.stack) == 0
+}
+
+// Return the causes to display under a node, replacing transparent causes with their own causes.
+func (node *errorNode) visibleCauses() []*errorNode {
+	var causes []*errorNode
+
+	for _, cause := range node.causes {
+		if cause.isTransparent() {
+			causes = append(causes, cause.visibleCauses()...)
+			continue
+		}
+
+		causes = append(causes, cause)
+	}
+
+	return causes
+}
+
+func sortedFieldKeys(fields map[string]interface{}) []string {
+	keys := lo.Keys(fields)
+	sort.Strings(keys)
+
+	return keys
+}
+
+func renderStackFrame(frame runtime.Frame) string {
+	return fmt.Sprintf("at %s (%s:%d)", frame.Function, frame.File, frame.Line)
+}
+
 type errorMessage struct {
 	err     error
 	message string
 
+	config ErrorConfig
+
 	quicklog.Message
 }
 
+// Render the details of a node (fields and stack trace), under the node text.
+func (err *errorMessage) renderNodeDetails(node *errorNode, indent string, width int) string {
+	detailsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Faint(true)
+	stackStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+
+	var output string
+
+	for _, key := range sortedFieldKeys(node.fields) {
+		output += renderTreeLines(
+			detailsStyle, keyStyle.Render(key+":")+" "+fmt.Sprint(node.fields[key]), indent, indent, width,
+		)
+	}
+
+	maxFrames := lo.FromPtr(err.config.MaxStackFrames)
+
+	for i, frame := range node.stack {
+		if i >= maxFrames {
+			output += renderTreeLines(
+				stackStyle, fmt.Sprintf("… %d more frames", len(node.stack)-maxFrames), indent, indent, width,
+			)
+
+			break
+		}
+
+		output += renderTreeLines(stackStyle, renderStackFrame(frame), indent, indent, width)
+	}
+
+	return output
+}
+
+// Render a node and its causes as a tree.
+func (err *errorMessage) renderNode(node *errorNode, indent, connector, childIndent string, width int) string {
+	mainStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+
+	causes := node.visibleCauses()
+
+	output := renderTreeLines(mainStyle, node.text, indent+connector, childIndent, width)
+	output += err.renderNodeDetails(node, childIndent+lo.Ternary(len(causes) > 0, "│   ", "    "), width)
+
+	output += err.renderCauses(causes, childIndent, width)
+
+	return output
+}
+
+func (err *errorMessage) renderCauses(causes []*errorNode, indent string, width int) string {
+	var output string
+
+	for i, cause := range causes {
+		last := i == len(causes)-1
+
+		output += err.renderNode(
+			cause,
+			indent,
+			lo.Ternary(last, "└── ", "├── "),
+			indent+lo.Ternary(last, "    ", "│   "),
+			width,
+		)
+	}
+
+	return output
+}
+
+func (err *errorMessage) renderErrorTerminal(width int) string {
+	mainStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Width(width)
+
+	root, _ := buildErrorNode(err.err)
+
+	// Preserve the compact rendering of errors that carry no additional information.
+	if !root.hasDetails() {
+		return mainStyle.Render(err.err.Error()) + "\n"
+	}
+
+	// Joined errors have no message of their own: their causes are displayed at the root.
+	if root.isTransparent() {
+		return err.renderCauses(root.visibleCauses(), "", width)
+	}
+
+	return err.renderNode(root, "", "", "", width)
+}
+
 func (err *errorMessage) RenderTerminal() string {
-	mainStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Width(quicklog.TermWidth)
+	return err.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (err *errorMessage) RenderTerminalWidth(width int) string {
 	messageStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("15")).Background(lipgloss.Color("52")).
-		Width(quicklog.TermWidth)
+		Width(width)
 
 	if err.err == nil && err.message == "" {
 		return ""
 	}
 
 	if err.message == "" {
-		return mainStyle.Render(err.err.Error()) + "\n"
+		return err.renderErrorTerminal(width)
 	}
 
 	if err.err == nil {
 		return messageStyle.Render(err.message) + "\n"
 	}
 
-	return messageStyle.Render(err.message) + "\n" + mainStyle.Render(err.err.Error()) + "\n"
+	return messageStyle.Render(err.message) + "\n" + err.renderErrorTerminal(width)
+}
+
+// Return the Go type of an error. Errors rebuilt by Decode report the type of the error they were rendered from.
+func errorTypeName(err error) string {
+	if decoded, ok := err.(interface{ typeName() string }); ok && decoded.typeName() != "" { //nolint:errorlint
+		return decoded.typeName()
+	}
+
+	return fmt.Sprintf("%T", err)
+}
+
+// Render the chain of errors, from the outermost to the innermost. Joined errors end the chain, and expose each of
+// their causes as a separate chain.
+func renderErrorChainJSON(node *errorNode) []map[string]interface{} {
+	var chain []map[string]interface{}
+
+	for node != nil {
+		entry := map[string]interface{}{
+			"message": node.err.Error(),
+			"type":    errorTypeName(node.err),
+		}
+
+		if len(node.fields) > 0 {
+			entry["fields"] = node.fields
+		}
+
+		chain = append(chain, entry)
+
+		if node.joined {
+			entry["errors"] = lo.Map(node.causes, func(cause *errorNode, _ int) []map[string]interface{} {
+				return renderErrorChainJSON(cause)
+			})
+
+			return chain
+		}
+
+		node = lo.FirstOr(node.causes, nil)
+	}
+
+	return chain
+}
+
+func (err *errorMessage) renderErrorJSON() map[string]interface{} {
+	root, _ := buildErrorNode(err.err)
+
+	output := map[string]interface{}{
+		"type":  errorTypeName(err.err),
+		"chain": renderErrorChainJSON(root),
+	}
+
+	if stack := root.deepestStack(); len(stack) > 0 {
+		output["stack"] = lo.Map(stack, func(frame runtime.Frame, _ int) map[string]interface{} {
+			return map[string]interface{}{
+				"function": frame.Function,
+				"file":     frame.File,
+				"line":     frame.Line,
+			}
+		})
+	}
+
+	return output
 }
 
 func (err *errorMessage) RenderJSON() map[string]interface{} {
@@ -40,27 +368,88 @@ func (err *errorMessage) RenderJSON() map[string]interface{} {
 	}
 
 	if err.message == "" {
-		return map[string]interface{}{
+		return quicklog.RenderKindJSON(KindError, map[string]interface{}{
 			"message": err.err.Error(),
-		}
+			"error":   err.renderErrorJSON(),
+		})
 	}
 
 	if err.err == nil {
-		return map[string]interface{}{
+		return quicklog.RenderKindJSON(KindError, map[string]interface{}{
 			"message": err.message,
-		}
+		})
 	}
 
-	return map[string]interface{}{
+	return quicklog.RenderKindJSON(KindError, map[string]interface{}{
 		"message": err.message,
-		"error":   err.err.Error(),
+		"error":   err.renderErrorJSON(),
+	})
+}
+
+// Errors are rendered as callouts.
+func (err *errorMessage) RenderMarkdown() string {
+	if err.err == nil && err.message == "" {
+		return ""
+	}
+
+	var content string
+
+	if err.message != "" {
+		content = "**" + escapeMarkdown(err.message) + "**\n\n"
+	}
+
+	if err.err != nil {
+		content += renderErrorMarkdown(err.err)
 	}
+
+	return markdownCallout("CAUTION", content)
+}
+
+func (err *errorMessage) RenderHTML() string {
+	if err.err == nil && err.message == "" {
+		return ""
+	}
+
+	var content string
+
+	if err.message != "" {
+		content = htmlParagraph("quicklog-error-message", err.message)
+	}
+
+	if err.err != nil {
+		content += renderErrorHTML(err.err)
+	}
+
+	return `<div class="quicklog-error">` + "\n" + content + "</div>\n"
+}
+
+type ErrorConfig struct {
+	// Optional.
+
+	// MaxStackFrames is the number of stack frames displayed in the terminal, before the remaining ones are
+	// collapsed. Defaults to 10.
+	MaxStackFrames *int
+}
+
+var ErrorConfigDefault = ErrorConfig{
+	MaxStackFrames: lo.ToPtr(10),
 }
 
 // NewError creates a new error message.
+//
+// Wrapped and joined errors are rendered as a tree of causes. Errors implementing ErrorWithStack or ErrorWithFields
+// also display their stack trace and fields.
 func NewError(err error, message string) quicklog.Message {
+	return NewErrorWithConfig(err, message, &ErrorConfigDefault)
+}
+
+// NewErrorWithConfig creates a new error message, with a custom rendering configuration.
+func NewErrorWithConfig(err error, message string, config *ErrorConfig) quicklog.Message {
 	return &errorMessage{
 		err:     err,
 		message: message,
+		config: ErrorConfig{
+			MaxStackFrames: lo.CoalesceOrEmpty(config.MaxStackFrames, ErrorConfigDefault.MaxStackFrames),
+		},
 	}
 }
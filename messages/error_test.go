@@ -2,10 +2,14 @@ package messages_test
 
 import (
 	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
+	"github.com/a-novel-kit/quicklog"
 	"github.com/a-novel-kit/quicklog/messages"
 )
 
@@ -73,7 +77,9 @@ func TestErrorJSON(t *testing.T) {
 			message: "Hello, world!",
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
+				"kind":           "error",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
 			},
 		},
 		{
@@ -82,7 +88,15 @@ func TestErrorJSON(t *testing.T) {
 			err: errors.New("this is an error"),
 
 			expect: map[string]interface{}{
-				"message": "this is an error",
+				"kind":           "error",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "this is an error",
+				"error": map[string]interface{}{
+					"type": "*errors.errorString",
+					"chain": []map[string]interface{}{
+						{"message": "this is an error", "type": "*errors.errorString"},
+					},
+				},
 			},
 		},
 		{
@@ -92,8 +106,15 @@ func TestErrorJSON(t *testing.T) {
 			message: "Hello, world!",
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
-				"error":   "this is an error",
+				"kind":           "error",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
+				"error": map[string]interface{}{
+					"type": "*errors.errorString",
+					"chain": []map[string]interface{}{
+						{"message": "this is an error", "type": "*errors.errorString"},
+					},
+				},
 			},
 		},
 		{
@@ -110,3 +131,317 @@ func TestErrorJSON(t *testing.T) {
 		})
 	}
 }
+
+type fieldsError struct{}
+
+func (fieldsError) Error() string {
+	return "not found"
+}
+
+func (fieldsError) Fields() map[string]interface{} {
+	return map[string]interface{}{"table": "users", "id": 42}
+}
+
+type stackError struct {
+	frames []runtime.Frame
+}
+
+func (err stackError) Error() string {
+	return "boom"
+}
+
+func (err stackError) StackTrace() []runtime.Frame {
+	return err.frames
+}
+
+// Mimics the errors of github.com/pkg/errors, that expose their stack trace as program counters.
+type callersFrame uintptr
+
+type callersError struct {
+	callers []callersFrame
+}
+
+func (err callersError) Error() string {
+	return "crash"
+}
+
+func (err callersError) StackTrace() []callersFrame {
+	return err.callers
+}
+
+var dummyFrames = []runtime.Frame{
+	{Function: "main.foo", File: "/app/foo.go", Line: 12},
+	{Function: "main.bar", File: "/app/bar.go", Line: 34},
+	{Function: "main.main", File: "/app/main.go", Line: 56},
+}
+
+func TestErrorChainTerminal(t *testing.T) {
+	testData := []struct {
+		name string
+
+		err    error
+		config *messages.ErrorConfig
+
+		expect string
+	}{
+		{
+			name: "WrappedError",
+
+			err: fmt.Errorf("load config: %w", fmt.Errorf("read file: %w", errors.New("not found"))),
+
+			expect: "load config                                                                     \n" +
+				"└── read file                                                                   \n" +
+				"    └── not found                                                               \n",
+		},
+		{
+			name: "JoinedErrors",
+
+			err: errors.Join(errors.New("first error"), fmt.Errorf("second error: %w", errors.New("cause"))),
+
+			expect: "├── first error                                                                 \n" +
+				"└── second error                                                                \n" +
+				"    └── cause                                                                   \n",
+		},
+		{
+			name: "WrappedJoinedErrors",
+
+			err: fmt.Errorf("load config: %w", errors.Join(errors.New("first error"), errors.New("second error"))),
+
+			expect: "load config                                                                     \n" +
+				"├── first error                                                                 \n" +
+				"└── second error                                                                \n",
+		},
+		{
+			name: "LongCause",
+
+			err: errors.Join(
+				errors.New("this cause is long enough to wrap, and its lines stay connected to the next causes"),
+				errors.New("short cause"),
+			),
+
+			expect: "├── this cause is long enough to wrap, and its lines stay connected to the next \n" +
+				"│   causes                                                                      \n" +
+				"└── short cause                                                                 \n",
+		},
+		{
+			name: "Fields",
+
+			err: fmt.Errorf("get user: %w", fieldsError{}),
+
+			expect: "get user                                                                        \n" +
+				"└── not found                                                                   \n" +
+				"        id: 42                                                                  \n" +
+				"        table: users                                                            \n",
+		},
+		{
+			name: "Stack",
+
+			err: fmt.Errorf("run: %w", stackError{frames: dummyFrames}),
+
+			expect: "run                                                                             \n" +
+				"└── boom                                                                        \n" +
+				"        at main.foo (/app/foo.go:12)                                            \n" +
+				"        at main.bar (/app/bar.go:34)                                            \n" +
+				"        at main.main (/app/main.go:56)                                          \n",
+		},
+		{
+			name: "StackCollapsed",
+
+			err:    stackError{frames: dummyFrames},
+			config: &messages.ErrorConfig{MaxStackFrames: lo.ToPtr(1)},
+
+			expect: "boom                                                                            \n" +
+				"    at main.foo (/app/foo.go:12)                                                \n" +
+				"    … 2 more frames                                                             \n",
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := lo.CoalesceOrEmpty(testCase.config, &messages.ErrorConfigDefault)
+			message := messages.NewErrorWithConfig(testCase.err, "", config)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestErrorChainJSON(t *testing.T) {
+	t.Run("WrappedJoinedErrors", func(t *testing.T) {
+		err := fmt.Errorf("load config: %w", errors.Join(errors.New("first error"), fieldsError{}))
+
+		require.Equal(t, map[string]interface{}{
+			"kind":           "error",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "load config: first error\nnot found",
+			"error": map[string]interface{}{
+				"type": "*fmt.wrapError",
+				"chain": []map[string]interface{}{
+					{"message": "load config: first error\nnot found", "type": "*fmt.wrapError"},
+					{
+						"message": "first error\nnot found",
+						"type":    "*errors.joinError",
+						"errors": [][]map[string]interface{}{
+							{
+								{"message": "first error", "type": "*errors.errorString"},
+							},
+							{
+								{
+									"message": "not found",
+									"type":    "messages_test.fieldsError",
+									"fields":  map[string]interface{}{"table": "users", "id": 42},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, messages.NewError(err, "").RenderJSON())
+	})
+
+	t.Run("Stack", func(t *testing.T) {
+		err := fmt.Errorf("run: %w", stackError{frames: dummyFrames[:1]})
+
+		require.Equal(t, map[string]interface{}{
+			"kind":           "error",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "run: boom",
+			"error": map[string]interface{}{
+				"type": "*fmt.wrapError",
+				"chain": []map[string]interface{}{
+					{"message": "run: boom", "type": "*fmt.wrapError"},
+					{"message": "boom", "type": "messages_test.stackError"},
+				},
+				"stack": []map[string]interface{}{
+					{"function": "main.foo", "file": "/app/foo.go", "line": 12},
+				},
+			},
+		}, messages.NewError(err, "").RenderJSON())
+	})
+
+	t.Run("CallersStack", func(t *testing.T) {
+		callers := make([]uintptr, 1)
+		runtime.Callers(1, callers)
+
+		err := callersError{callers: []callersFrame{callersFrame(callers[0])}}
+
+		stack := messages.NewError(err, "").RenderJSON()["error"].(map[string]interface{})["stack"]
+		require.Len(t, stack, 1)
+		require.Equal(
+			t,
+			"github.com/a-novel-kit/quicklog/messages_test.TestErrorChainJSON.func3",
+			stack.([]map[string]interface{})[0]["function"],
+		)
+	})
+}
+
+func TestErrorMarkdown(t *testing.T) {
+	testData := []struct {
+		name string
+
+		err     error
+		message string
+
+		expect string
+	}{
+		{
+			name: "SimpleErrorMessage",
+
+			message: "Hello, world!",
+
+			expect: "> [!CAUTION]\n> **Hello, world!**\n\n",
+		},
+		{
+			name: "SimpleError",
+
+			err: errors.New("this is an error"),
+
+			expect: "> [!CAUTION]\n> this is an error\n\n",
+		},
+		{
+			name: "Chain",
+
+			err: fmt.Errorf(
+				"run: %w", errors.Join(fieldsError{}, fmt.Errorf("exec: %w", stackError{frames: dummyFrames[:1]})),
+			),
+			message: "Failed",
+
+			expect: "> [!CAUTION]\n" +
+				"> **Failed**\n" +
+				">\n" +
+				"> - run\n" +
+				">   - not found\n" +
+				">     - `id`: 42\n" +
+				">     - `table`: users\n" +
+				">   - exec\n" +
+				">     - boom\n" +
+				">\n" +
+				"> <details><summary>Stack trace</summary>\n" +
+				">\n" +
+				"> ```\n" +
+				"> at main.foo (/app/foo.go:12)\n" +
+				"> ```\n" +
+				">\n" +
+				"> </details>\n\n",
+		},
+		{
+			name: "NoMessage",
+
+			expect: "",
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewError(testCase.err, testCase.message)
+			require.Equal(t, testCase.expect, quicklog.RenderMarkdown(message))
+		})
+	}
+}
+
+func TestErrorHTML(t *testing.T) {
+	testData := []struct {
+		name string
+
+		err     error
+		message string
+
+		expect string
+	}{
+		{
+			name: "SimpleError",
+
+			err:     errors.New("this is an <error>"),
+			message: "Hello, world!",
+
+			expect: "<div class=\"quicklog-error\">\n" +
+				"<p class=\"quicklog-error-message\">Hello, world!</p>\n" +
+				"<p class=\"quicklog-error-cause\">this is an &lt;error&gt;</p>\n" +
+				"</div>\n",
+		},
+		{
+			name: "Chain",
+
+			err: fmt.Errorf("run: %w", errors.Join(fieldsError{}, stackError{frames: dummyFrames[:1]})),
+
+			expect: "<div class=\"quicklog-error\">\n" +
+				"<ul><li>run<ul>" +
+				"<li>not found<ul><li><code>id</code>: 42</li><li><code>table</code>: users</li></ul></li>" +
+				"<li>boom</li>" +
+				"</ul></li></ul>\n" +
+				"<details><summary>Stack trace</summary><pre>at main.foo (/app/foo.go:12)</pre></details>\n" +
+				"</div>\n",
+		},
+		{
+			name: "NoMessage",
+
+			expect: "",
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewError(testCase.err, testCase.message)
+			require.Equal(t, testCase.expect, quicklog.RenderHTML(message))
+		})
+	}
+}
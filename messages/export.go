@@ -0,0 +1,183 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"runtime"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ==============================================================================================================
+// Markdown.
+// ==============================================================================================================
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"|", `\|`,
+	"~", `\~`,
+)
+
+// Escape the characters of a text that would otherwise be interpreted as Markdown syntax.
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// Render a text as a Markdown paragraph, preserving its line breaks.
+func markdownParagraph(text string) string {
+	return strings.ReplaceAll(escapeMarkdown(text), "\n", "\\\n") + "\n\n"
+}
+
+// Render a text as a fenced code block. The fence is longer than any backtick sequence in the text, so the
+// text cannot close the block early.
+func markdownCodeBlock(language, text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	return fence + language + "\n" + strings.TrimRight(text, "\n") + "\n" + fence + "\n"
+}
+
+// Render a collapsible section. GitHub and most Markdown renderers support inline HTML for this purpose.
+func markdownDetails(summary, content string) string {
+	return "<details><summary>" + html.EscapeString(summary) + "</summary>\n\n" + content + "\n</details>\n"
+}
+
+// Render a block as a GitHub-style callout (alert). Other renderers display it as a regular quote.
+func markdownCallout(kind, content string) string {
+	return markdownQuote("[!" + kind + "]\n" + content)
+}
+
+// Render a block quote.
+func markdownQuote(content string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	var output string
+	for _, line := range lines {
+		output += strings.TrimRight("> "+line, " ") + "\n"
+	}
+
+	return output + "\n"
+}
+
+// Render an error node and its causes as a nested list.
+func renderErrorNodeMarkdown(node *errorNode, depth int) string {
+	indent := strings.Repeat("  ", depth)
+
+	output := indent + "- " + escapeMarkdown(node.text) + "\n"
+
+	for _, key := range sortedFieldKeys(node.fields) {
+		output += indent + "  - `" + key + "`: " + escapeMarkdown(fmt.Sprint(node.fields[key])) + "\n"
+	}
+
+	for _, cause := range node.visibleCauses() {
+		output += renderErrorNodeMarkdown(cause, depth+1)
+	}
+
+	return output
+}
+
+// Render an error as a nested list of causes, followed by a collapsible stack trace.
+func renderErrorMarkdown(err error) string {
+	root, _ := buildErrorNode(err)
+
+	if !root.hasDetails() {
+		return markdownParagraph(err.Error())
+	}
+
+	var output string
+
+	if root.isTransparent() {
+		for _, cause := range root.visibleCauses() {
+			output += renderErrorNodeMarkdown(cause, 0)
+		}
+	} else {
+		output = renderErrorNodeMarkdown(root, 0)
+	}
+
+	if stack := root.deepestStack(); len(stack) > 0 {
+		output += "\n" + markdownDetails("Stack trace", markdownCodeBlock("", strings.Join(
+			lo.Map(stack, func(frame runtime.Frame, _ int) string { return renderStackFrame(frame) }), "\n",
+		)))
+	}
+
+	return output + "\n"
+}
+
+// ==============================================================================================================
+// HTML.
+// ==============================================================================================================
+
+// Render a text as an HTML paragraph, preserving its line breaks.
+func htmlParagraph(class, text string) string {
+	return fmt.Sprintf(
+		`<p class="%s">%s</p>`+"\n", class, strings.ReplaceAll(html.EscapeString(text), "\n", "<br>\n"),
+	)
+}
+
+// Render a collapsible section.
+func htmlDetails(summary, content string) string {
+	return "<details><summary>" + html.EscapeString(summary) + "</summary>" + content + "</details>\n"
+}
+
+// Render an error node and its causes as a nested list item.
+func renderErrorNodeHTML(node *errorNode) string {
+	output := "<li>" + html.EscapeString(node.text)
+
+	var children string
+
+	for _, key := range sortedFieldKeys(node.fields) {
+		children += fmt.Sprintf(
+			"<li><code>%s</code>: %s</li>", html.EscapeString(key), html.EscapeString(fmt.Sprint(node.fields[key])),
+		)
+	}
+
+	for _, cause := range node.visibleCauses() {
+		children += renderErrorNodeHTML(cause)
+	}
+
+	if children != "" {
+		output += "<ul>" + children + "</ul>"
+	}
+
+	return output + "</li>"
+}
+
+// Render an error as a nested list of causes, followed by a collapsible stack trace.
+func renderErrorHTML(err error) string {
+	root, _ := buildErrorNode(err)
+
+	if !root.hasDetails() {
+		return htmlParagraph("quicklog-error-cause", err.Error())
+	}
+
+	var output string
+
+	if root.isTransparent() {
+		for _, cause := range root.visibleCauses() {
+			output += renderErrorNodeHTML(cause)
+		}
+	} else {
+		output = renderErrorNodeHTML(root)
+	}
+
+	output = "<ul>" + output + "</ul>\n"
+
+	if stack := root.deepestStack(); len(stack) > 0 {
+		output += htmlDetails("Stack trace", "<pre>"+html.EscapeString(strings.Join(
+			lo.Map(stack, func(frame runtime.Frame, _ int) string { return renderStackFrame(frame) }), "\n",
+		))+"</pre>")
+	}
+
+	return output
+}
@@ -0,0 +1,191 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+var groupGutterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+
+type groupMessage struct {
+	header   quicklog.Message
+	children []quicklog.Message
+
+	config GroupConfig
+
+	quicklog.Message
+}
+
+// Return whether the children are hidden from the terminal output.
+func (group *groupMessage) collapsed() bool {
+	return group.config.Collapse || (group.config.CollapseInCI && quicklog.CI)
+}
+
+func (group *groupMessage) RenderTerminal() string {
+	return group.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (group *groupMessage) RenderTerminalWidth(width int) string {
+	header := quicklog.RenderTerminalWidth(group.header, width)
+
+	// Without a header, the gutter has nothing to attach to: children are rendered one after the other.
+	if header == "" {
+		var output string
+		for _, child := range group.children {
+			output += quicklog.RenderTerminalWidth(child, width)
+		}
+
+		return output
+	}
+
+	if len(group.children) == 0 {
+		return header
+	}
+
+	if group.collapsed() {
+		summary := fmt.Sprintf(
+			"… %d %s collapsed", len(group.children), lo.Ternary(len(group.children) == 1, "message", "messages"),
+		)
+
+		return header + groupGutterStyle.Width(width).Render(group.config.Gutter+summary) + "\n"
+	}
+
+	gutter := groupGutterStyle.Render(group.config.Gutter)
+	childWidth := lo.Max([]int{1, width - lipgloss.Width(group.config.Gutter)})
+
+	output := header
+
+	for _, child := range group.children {
+		rendered := quicklog.RenderTerminalWidth(child, childWidth)
+		if rendered == "" {
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSuffix(rendered, "\n"), "\n") {
+			output += gutter + line + "\n"
+		}
+	}
+
+	return output
+}
+
+// SectionTitle returns the title of the header, or the first line of its rendering.
+func (group *groupMessage) SectionTitle() string {
+	if section, ok := group.header.(quicklog.SectionMessage); ok {
+		return section.SectionTitle()
+	}
+
+	if group.header == nil {
+		return ""
+	}
+
+	firstLine, _, _ := strings.Cut(ansi.Strip(group.header.RenderTerminal()), "\n")
+
+	return strings.TrimSpace(firstLine)
+}
+
+func (group *groupMessage) RenderJSON() map[string]interface{} {
+	var output map[string]interface{}
+
+	if group.header != nil {
+		output = group.header.RenderJSON()
+	}
+
+	// The header is rendered inline, so its kind is kept under a separate key.
+	if kind, ok := output[quicklog.KindKey]; ok {
+		output["header_kind"] = kind
+	}
+
+	children := lo.FilterMap(group.children, func(child quicklog.Message, _ int) (map[string]interface{}, bool) {
+		rendered := quicklog.RenderChildJSON(child)
+
+		return rendered, rendered != nil
+	})
+
+	if len(output) == 0 && len(children) == 0 {
+		return nil
+	}
+
+	output = lo.Assign(output, map[string]interface{}{"children": children})
+
+	return quicklog.RenderKindJSON(KindGroup, output)
+}
+
+func (group *groupMessage) RenderMarkdown() string {
+	var children string
+
+	for _, child := range group.children {
+		children += quicklog.RenderMarkdown(child)
+	}
+
+	header := quicklog.RenderMarkdown(group.header)
+	if header == "" {
+		return children
+	}
+
+	if children == "" {
+		return header
+	}
+
+	// Children are nested in a quote, the closest equivalent to the gutter of the terminal.
+	return header + markdownQuote(children)
+}
+
+func (group *groupMessage) RenderHTML() string {
+	var children string
+
+	for _, child := range group.children {
+		children += quicklog.RenderHTML(child)
+	}
+
+	header := quicklog.RenderHTML(group.header)
+	if header == "" && children == "" {
+		return ""
+	}
+
+	return `<section class="quicklog-group">` + "\n" + header +
+		`<div class="quicklog-group-children">` + "\n" + children + "</div>\n</section>\n"
+}
+
+type GroupConfig struct {
+	// Gutter is printed on the left of each line of the children. Defaults to "│ ".
+	Gutter string
+	// Collapse hides the children from the terminal, and only displays their count. Children are always part of the
+	// JSON output.
+	Collapse bool
+	// CollapseInCI collapses the children in CI environments only (see quicklog.CI).
+	CollapseInCI bool
+}
+
+var GroupConfigDefault = GroupConfig{
+	Gutter: "│ ",
+}
+
+// NewGroup renders a header, followed by any number of children. Children are indented under a gutter, and may be
+// collapsed in CI environments. In JSON, children are rendered as an array, next to the fields of the header.
+//
+// The header is optional: without a header, children are rendered one after the other.
+func NewGroup(header quicklog.Message, children ...quicklog.Message) quicklog.Message {
+	return NewGroupWithConfig(&GroupConfigDefault, header, children...)
+}
+
+// NewGroupWithConfig creates a new group, with a custom rendering configuration.
+func NewGroupWithConfig(config *GroupConfig, header quicklog.Message, children ...quicklog.Message) quicklog.Message {
+	return &groupMessage{
+		header: header,
+		children: lo.Filter(children, func(child quicklog.Message, _ int) bool {
+			return child != nil
+		}),
+		config: GroupConfig{
+			Gutter:       lo.CoalesceOrEmpty(config.Gutter, GroupConfigDefault.Gutter),
+			Collapse:     config.Collapse,
+			CollapseInCI: config.CollapseInCI,
+		},
+	}
+}
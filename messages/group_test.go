@@ -0,0 +1,253 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func withCI(t *testing.T, enabled bool) {
+	t.Helper()
+
+	previous := quicklog.CI
+	quicklog.CI = enabled
+
+	t.Cleanup(func() {
+		quicklog.CI = previous
+	})
+}
+
+func TestGroupTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		group quicklog.Message
+		width int
+		ci    bool
+
+		expect string
+	}{
+		{
+			name: "Group",
+
+			group: messages.NewGroup(
+				messages.NewBase("Deploying"),
+				messages.NewBase("Building the image, then pushing it"),
+				messages.NewBase("Updating the service"),
+			),
+			width: 24,
+
+			expect: "Deploying               \n" +
+				"│ Building the image,   \n" +
+				"│ then pushing it       \n" +
+				"│ Updating the service  \n",
+		},
+		{
+			name: "Nested",
+
+			group: messages.NewGroup(
+				messages.NewBase("Deploying"),
+				messages.NewGroup(messages.NewBase("api"), messages.NewBase("Built")),
+				messages.NewGroup(messages.NewBase("worker"), messages.NewBase("Built")),
+			),
+			width: 12,
+
+			expect: "Deploying   \n" +
+				"│ api       \n" +
+				"│ │ Built   \n" +
+				"│ worker    \n" +
+				"│ │ Built   \n",
+		},
+		{
+			name: "NoHeader",
+
+			group: messages.NewGroup(nil, messages.NewBase("api"), messages.NewBase("worker")),
+			width: 8,
+
+			expect: "api     \n" +
+				"worker  \n",
+		},
+		{
+			name: "NoChildren",
+
+			group: messages.NewGroup(messages.NewBase("Deploying"), nil),
+			width: 12,
+
+			expect: "Deploying   \n",
+		},
+		{
+			name: "CustomGutter",
+
+			group: messages.NewGroupWithConfig(
+				&messages.GroupConfig{Gutter: "    "},
+				messages.NewBase("Deploying"),
+				messages.NewBase("api"),
+			),
+			width: 12,
+
+			expect: "Deploying   \n" +
+				"    api     \n",
+		},
+		{
+			name: "CollapseInCI",
+
+			group: messages.NewGroupWithConfig(
+				&messages.GroupConfig{CollapseInCI: true},
+				messages.NewBase("Deploying"),
+				messages.NewBase("api"),
+				messages.NewBase("worker"),
+			),
+			width: 24,
+			ci:    true,
+
+			expect: "Deploying               \n" +
+				"│ … 2 messages collapsed\n",
+		},
+		{
+			name: "CollapseInCIOutsideCI",
+
+			group: messages.NewGroupWithConfig(
+				&messages.GroupConfig{CollapseInCI: true},
+				messages.NewBase("Deploying"),
+				messages.NewBase("api"),
+			),
+			width: 12,
+
+			expect: "Deploying   \n" +
+				"│ api       \n",
+		},
+		{
+			name: "Collapse",
+
+			group: messages.NewGroupWithConfig(
+				&messages.GroupConfig{Collapse: true},
+				messages.NewBase("Deploying"),
+				messages.NewBase("api"),
+			),
+			width: 24,
+
+			expect: "Deploying               \n" +
+				"│ … 1 message collapsed \n",
+		},
+		{
+			name: "Empty",
+
+			group: messages.NewGroup(nil),
+			width: 12,
+
+			expect: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			withCI(t, testCase.ci)
+
+			require.Equal(t, testCase.expect, quicklog.RenderTerminalWidth(testCase.group, testCase.width))
+		})
+	}
+}
+
+func TestGroupJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		group quicklog.Message
+
+		expect map[string]interface{}
+	}{
+		{
+			name: "Group",
+
+			group: messages.NewGroup(
+				messages.NewTitle("Deploying", "to production"),
+				messages.NewBase("api"),
+				messages.NewBase(""),
+				messages.NewBase("worker"),
+			),
+
+			expect: map[string]interface{}{
+				"kind":           "group",
+				"schema_version": quicklog.SchemaVersion,
+				"header_kind":    "title",
+				"message":        "Deploying",
+				"content":        "to production",
+				"children": []map[string]interface{}{
+					{"kind": "base", "message": "api"},
+					{"kind": "base", "message": "worker"},
+				},
+			},
+		},
+		{
+			// Collapsing only applies to the terminal.
+			name: "Collapsed",
+
+			group: messages.NewGroupWithConfig(
+				&messages.GroupConfig{Collapse: true},
+				messages.NewBase("Deploying"),
+				messages.NewBase("api"),
+			),
+
+			expect: map[string]interface{}{
+				"kind":           "group",
+				"schema_version": quicklog.SchemaVersion,
+				"header_kind":    "base",
+				"message":        "Deploying",
+				"children":       []map[string]interface{}{{"kind": "base", "message": "api"}},
+			},
+		},
+		{
+			name: "NoHeader",
+
+			group: messages.NewGroup(nil, messages.NewBase("api")),
+
+			expect: map[string]interface{}{
+				"kind":           "group",
+				"schema_version": quicklog.SchemaVersion,
+				"children":       []map[string]interface{}{{"kind": "base", "message": "api"}},
+			},
+		},
+		{
+			name: "Empty",
+
+			group: messages.NewGroup(nil),
+
+			expect: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, testCase.group.RenderJSON())
+		})
+	}
+}
+
+func TestGroupMarkdown(t *testing.T) {
+	group := messages.NewGroup(messages.NewBase("Deploying"), messages.NewBase("api"), messages.NewBase("worker"))
+
+	require.Equal(t, "Deploying\n\n> api\n>\n> worker\n\n", quicklog.RenderMarkdown(group))
+	require.Equal(
+		t,
+		`<section class="quicklog-group">`+"\n"+
+			`<p class="quicklog-base">Deploying</p>`+"\n"+
+			`<div class="quicklog-group-children">`+"\n"+
+			`<p class="quicklog-base">api</p>`+"\n"+
+			`<p class="quicklog-base">worker</p>`+"\n"+
+			"</div>\n</section>\n",
+		quicklog.RenderHTML(group),
+	)
+}
+
+func TestGroupSectionTitle(t *testing.T) {
+	section := func(message quicklog.Message) string {
+		return message.(quicklog.SectionMessage).SectionTitle()
+	}
+
+	require.Equal(t, "Deploy", section(messages.NewGroup(messages.NewTitle("Deploy", "to [prod](https://prod.io)"))))
+	require.Equal(t, "Deploying the app", section(messages.NewGroup(messages.NewBase("Deploying the app\nNow."))))
+	require.Equal(t, "", section(messages.NewGroup(nil, messages.NewBase("api"))))
+}
@@ -0,0 +1,319 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// ==============================================================================================================
+// Inline links.
+// ==============================================================================================================
+
+// Links are written inline, using the Markdown syntax: [text](url).
+var inlineLinkPattern = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+
+// Matches the OSC 8 sequences that open or close a hyperlink. The URL is captured, and is empty for closing
+// sequences.
+var hyperlinkSequencePattern = regexp.MustCompile("\x1b\\]8;[^;\x07\x1b]*;([^\x07\x1b]*)(?:\x07|\x1b\\\\)")
+
+var linkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Underline(true)
+
+// textSegment is a part of a text, that may point to a URL.
+type textSegment struct {
+	text string
+	url  string
+}
+
+// Split a text around its inline links.
+func splitLinks(text string) []textSegment {
+	var segments []textSegment
+
+	cursor := 0
+
+	for _, match := range inlineLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		if match[0] > cursor {
+			segments = append(segments, textSegment{text: text[cursor:match[0]]})
+		}
+
+		segments = append(segments, textSegment{text: text[match[2]:match[3]], url: text[match[4]:match[5]]})
+		cursor = match[1]
+	}
+
+	if cursor < len(text) {
+		segments = append(segments, textSegment{text: text[cursor:]})
+	}
+
+	return segments
+}
+
+// Render a link for the terminal. Links are clickable in terminals that support hyperlinks, and display their URL
+// otherwise.
+func renderLinkTerminal(text, url string) string {
+	if url == "" {
+		return text
+	}
+
+	if !quicklog.Hyperlinks {
+		if text == url {
+			return url
+		}
+
+		return text + " (" + url + ")"
+	}
+
+	return ansi.SetHyperlink(url) + text + ansi.ResetHyperlink()
+}
+
+// Replace the inline links of a text with their terminal rendering.
+func renderLinksTerminal(text string) string {
+	var output string
+
+	for _, segment := range splitLinks(text) {
+		output += renderLinkTerminal(segment.text, segment.url)
+	}
+
+	return output
+}
+
+// Hyperlinks that span multiple lines would also cover the padding of each line, and anything printed before the
+// next line by the logger. Close them at the end of each line, and open them again on the next one.
+func splitHyperlinkLines(rendered string) string {
+	if !strings.Contains(rendered, "\x1b]8;") {
+		return rendered
+	}
+
+	lines := strings.Split(rendered, "\n")
+
+	var activeURL string
+
+	for i, line := range lines {
+		if activeURL != "" {
+			line = ansi.SetHyperlink(activeURL) + line
+		}
+
+		for _, match := range hyperlinkSequencePattern.FindAllStringSubmatch(line, -1) {
+			activeURL = match[1]
+		}
+
+		if activeURL != "" {
+			line += ansi.ResetHyperlink()
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Return the text, without the markup of its inline links.
+func stripLinks(text string) string {
+	return inlineLinkPattern.ReplaceAllString(text, "$1")
+}
+
+// linkedText is a text that may contain inline links, rendered under a key of the JSON output.
+type linkedText struct {
+	key  string
+	text string
+}
+
+// Return the inline links of texts, for JSON output. Each link records the key of its text, and its offset in the
+// text without the markup of links, so it can be placed back.
+func renderLinksJSON(texts ...linkedText) []map[string]interface{} {
+	var links []map[string]interface{}
+
+	for _, text := range texts {
+		offset := 0
+
+		for _, segment := range splitLinks(text.text) {
+			if segment.url != "" {
+				links = append(links, map[string]interface{}{
+					"text":   segment.text,
+					"url":    segment.url,
+					"field":  text.key,
+					"offset": offset,
+				})
+			}
+
+			offset += len(segment.text)
+		}
+	}
+
+	return links
+}
+
+// Schemes of the URLs that are linked in reports. Other schemes, such as javascript:, can run code when the link is
+// clicked.
+var safeLinkSchemes = []string{"http", "https", "mailto"}
+
+var linkSchemePattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+
+// Whether a URL can be linked in a report. Relative URLs are safe.
+//
+// The scheme is read like browsers do: tabs and line breaks are ignored, as well as control characters and spaces
+// around the URL, so "java\nscript:" is not mistaken for a relative URL.
+func isSafeLinkURL(raw string) bool {
+	cleaned := strings.TrimFunc(strings.NewReplacer("\t", "", "\n", "", "\r", "").Replace(raw), func(char rune) bool {
+		return char <= ' '
+	})
+
+	scheme := linkSchemePattern.FindStringSubmatch(cleaned)
+
+	return scheme == nil || lo.Contains(safeLinkSchemes, strings.ToLower(scheme[1]))
+}
+
+// Percent-encode the characters of a URL that would end the destination of a Markdown link, or break its line.
+func escapeMarkdownURL(raw string) string {
+	var output strings.Builder
+
+	for _, char := range []byte(raw) {
+		if char <= ' ' || char == 0x7f || strings.IndexByte("()<>\\", char) >= 0 {
+			output.WriteString(fmt.Sprintf("%%%02X", char))
+			continue
+		}
+
+		output.WriteByte(char)
+	}
+
+	return output.String()
+}
+
+// Render a link for Markdown. Links to unsafe URLs are rendered as plain text.
+func renderLinkMarkdown(text, url string) string {
+	if url == "" || !isSafeLinkURL(url) {
+		return escapeMarkdown(text)
+	}
+
+	return "[" + escapeMarkdown(text) + "](" + escapeMarkdownURL(url) + ")"
+}
+
+// Render a link for HTML. Links to unsafe URLs are rendered as plain text.
+func renderLinkHTML(text, url string) string {
+	if url == "" || !isSafeLinkURL(url) {
+		return html.EscapeString(text)
+	}
+
+	return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(text) + "</a>"
+}
+
+// Escape a text for Markdown, preserving its inline links.
+func escapeMarkdownLinks(text string) string {
+	var output string
+
+	for _, segment := range splitLinks(text) {
+		output += renderLinkMarkdown(segment.text, segment.url)
+	}
+
+	return output
+}
+
+// Escape a text for HTML, converting its inline links to anchors.
+func escapeHTMLLinks(text string) string {
+	var output string
+
+	for _, segment := range splitLinks(text) {
+		output += renderLinkHTML(segment.text, segment.url)
+	}
+
+	return output
+}
+
+// Render a text as a Markdown paragraph, preserving its line breaks and inline links.
+func markdownLinksParagraph(text string) string {
+	return strings.ReplaceAll(escapeMarkdownLinks(text), "\n", "\\\n") + "\n\n"
+}
+
+// Render a text as an HTML paragraph, preserving its line breaks and inline links.
+func htmlLinksParagraph(class, text string) string {
+	return `<p class="` + class + `">` + strings.ReplaceAll(escapeHTMLLinks(text), "\n", "<br>\n") + "</p>\n"
+}
+
+// ==============================================================================================================
+// Message.
+// ==============================================================================================================
+
+type linkMessage struct {
+	text string
+	url  string
+
+	quicklog.Message
+}
+
+// String returns the link in the inline syntax, so it can be embedded in the text of other messages.
+func (link *linkMessage) String() string {
+	if link.url == "" {
+		return link.text
+	}
+
+	return "[" + link.text + "](" + link.url + ")"
+}
+
+func (link *linkMessage) RenderTerminal() string {
+	return link.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (link *linkMessage) RenderTerminalWidth(width int) string {
+	if link.text == "" {
+		return ""
+	}
+
+	content := lipgloss.NewStyle().
+		Width(width).
+		Render(renderLinkTerminal(linkStyle.Render(link.text), link.url))
+
+	return splitHyperlinkLines(content) + "\n"
+}
+
+func (link *linkMessage) RenderJSON() map[string]interface{} {
+	if link.text == "" {
+		return nil
+	}
+
+	output := map[string]interface{}{
+		"message": link.text,
+	}
+
+	if link.url != "" {
+		output["url"] = link.url
+	}
+
+	return quicklog.RenderKindJSON(KindLink, output)
+}
+
+func (link *linkMessage) RenderMarkdown() string {
+	if link.text == "" {
+		return ""
+	}
+
+	return renderLinkMarkdown(link.text, link.url) + "\n\n"
+}
+
+func (link *linkMessage) RenderHTML() string {
+	if link.text == "" {
+		return ""
+	}
+
+	return `<p class="quicklog-link">` + renderLinkHTML(link.text, link.url) + "</p>\n"
+}
+
+// NewLink creates a link to a URL. The link is clickable in terminals that support hyperlinks, and is rendered as
+// "text (url)" otherwise. The text defaults to the URL.
+//
+// Links can also be embedded in the text of base and title messages, using the [text](url) syntax. The value
+// returned by NewLink formats to this syntax, so it can be used with fmt.Sprintf.
+//
+// Markdown and HTML reports only link http, https and mailto URLs, and relative URLs. Links to other URLs are
+// rendered as plain text.
+func NewLink(text, url string) quicklog.Message {
+	return &linkMessage{
+		text: lo.CoalesceOrEmpty(text, url),
+		url:  url,
+	}
+}
@@ -0,0 +1,260 @@
+package messages_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func withHyperlinks(t *testing.T, enabled bool) {
+	t.Helper()
+
+	previous := quicklog.Hyperlinks
+	quicklog.Hyperlinks = enabled
+
+	t.Cleanup(func() {
+		quicklog.Hyperlinks = previous
+	})
+}
+
+func TestLinkTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		text       string
+		url        string
+		width      int
+		hyperlinks bool
+
+		expect string
+	}{
+		{
+			name: "Hyperlink",
+
+			text:       "Dashboard",
+			url:        "https://example.com/dashboard",
+			width:      20,
+			hyperlinks: true,
+
+			expect: "\x1b]8;;https://example.com/dashboard\x07Dashboard\x1b]8;;\x07           \n",
+		},
+		{
+			name: "Fallback",
+
+			text:  "Dashboard",
+			url:   "https://example.com",
+			width: 40,
+
+			expect: "Dashboard (https://example.com)         \n",
+		},
+		{
+			name: "FallbackNoText",
+
+			url:   "https://example.com",
+			width: 20,
+
+			expect: "https://example.com \n",
+		},
+		{
+			name: "NoURL",
+
+			text:       "Dashboard",
+			width:      20,
+			hyperlinks: true,
+
+			expect: "Dashboard           \n",
+		},
+		{
+			name: "Empty",
+
+			width: 20,
+
+			expect: "",
+		},
+		{
+			// Each line opens and closes its own hyperlink.
+			name: "Wrapped",
+
+			text:       "Pull request number twelve",
+			url:        "https://example.com/pr/12",
+			width:      14,
+			hyperlinks: true,
+
+			expect: "\x1b]8;;https://example.com/pr/12\x07Pull request  \x1b]8;;\x07\n" +
+				"\x1b]8;;https://example.com/pr/12\x07number twelve\x1b]8;;\x07 \n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			withHyperlinks(t, testCase.hyperlinks)
+
+			message := messages.NewLink(testCase.text, testCase.url)
+			require.Equal(t, testCase.expect, quicklog.RenderTerminalWidth(message, testCase.width))
+		})
+	}
+}
+
+func TestLinkInline(t *testing.T) {
+	link := messages.NewLink("PR #12", "https://example.com/pr/12")
+	message := messages.NewBase(fmt.Sprintf("Opened %s for review, please take a look.", link), nil)
+
+	t.Run("Hyperlink", func(t *testing.T) {
+		withHyperlinks(t, true)
+
+		require.Equal(
+			t,
+			"Opened \x1b]8;;https://example.com/pr/12\x07PR \x1b]8;;\x07\n"+
+				"\x1b]8;;https://example.com/pr/12\x07#12\x1b]8;;\x07 for   \n"+
+				"review,   \n"+
+				"please    \n"+
+				"take a    \n"+
+				"look.     \n",
+			quicklog.RenderTerminalWidth(message, 10),
+		)
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		withHyperlinks(t, false)
+
+		require.Equal(
+			t,
+			"Opened PR #12 (https://example.com/pr/12) for review, please take a look.       \n",
+			message.RenderTerminal(),
+		)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		require.Equal(t, map[string]interface{}{
+			"kind":           "base",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "Opened PR #12 for review, please take a look.",
+			"links": []map[string]interface{}{
+				{"text": "PR #12", "url": "https://example.com/pr/12", "field": "message", "offset": 7},
+			},
+		}, message.RenderJSON())
+	})
+
+	t.Run("Title", func(t *testing.T) {
+		title := messages.NewTitle("Deploy [v1.2.0](https://example.com/v1.2.0)", "See [logs](https://logs.io)", nil)
+
+		require.Equal(t, map[string]interface{}{
+			"kind":           "title",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "Deploy v1.2.0",
+			"content":        "See logs",
+			"links": []map[string]interface{}{
+				{"text": "v1.2.0", "url": "https://example.com/v1.2.0", "field": "message", "offset": 7},
+				{"text": "logs", "url": "https://logs.io", "field": "content", "offset": 4},
+			},
+		}, title.RenderJSON())
+
+		require.Equal(
+			t,
+			"## Deploy [v1.2.0](https://example.com/v1.2.0)\n\nSee [logs](https://logs.io)\n\n",
+			quicklog.RenderMarkdown(title),
+		)
+		require.Equal(
+			t,
+			`<h2 class="quicklog-title">Deploy <a href="https://example.com/v1.2.0">v1.2.0</a></h2>`+"\n"+
+				`<p class="quicklog-title-description">See <a href="https://logs.io">logs</a></p>`+"\n",
+			quicklog.RenderHTML(title),
+		)
+	})
+}
+
+func TestLinkJSON(t *testing.T) {
+	require.Equal(t, map[string]interface{}{
+		"kind":           "link",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "Dashboard",
+		"url":            "https://example.com",
+	}, messages.NewLink("Dashboard", "https://example.com").RenderJSON())
+
+	require.Equal(t, map[string]interface{}{
+		"kind":           "link",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "https://example.com",
+		"url":            "https://example.com",
+	}, messages.NewLink("", "https://example.com").RenderJSON())
+
+	require.Nil(t, messages.NewLink("", "").RenderJSON())
+}
+
+func TestLinkMarkdown(t *testing.T) {
+	message := messages.NewLink("Build *artifacts*", "https://example.com/a?b=c&d=e")
+
+	require.Equal(t, "[Build \\*artifacts\\*](https://example.com/a?b=c&d=e)\n\n", quicklog.RenderMarkdown(message))
+	require.Equal(
+		t,
+		`<p class="quicklog-link"><a href="https://example.com/a?b=c&amp;d=e">Build *artifacts*</a></p>`+"\n",
+		quicklog.RenderHTML(message),
+	)
+}
+
+func TestLinkUnsafe(t *testing.T) {
+	testData := []struct {
+		name string
+
+		message quicklog.Message
+
+		expectMarkdown string
+		expectHTML     string
+	}{
+		{
+			name:           "Script",
+			message:        messages.NewLink("Click me", "javascript:alert(document.cookie)"),
+			expectMarkdown: "Click me\n\n",
+			expectHTML:     `<p class="quicklog-link">Click me</p>` + "\n",
+		},
+		{
+			name:           "ScriptUppercase",
+			message:        messages.NewLink("Click me", "JavaScript:alert(1)"),
+			expectMarkdown: "Click me\n\n",
+			expectHTML:     `<p class="quicklog-link">Click me</p>` + "\n",
+		},
+		{
+			name:           "ScriptObfuscated",
+			message:        messages.NewLink("Click me", " java\nscript:alert(1)"),
+			expectMarkdown: "Click me\n\n",
+			expectHTML:     `<p class="quicklog-link">Click me</p>` + "\n",
+		},
+		{
+			name:           "Data",
+			message:        messages.NewBase("See [this](data:text/html;base64,PHNjcmlwdD4=)"),
+			expectMarkdown: "See this\n\n",
+			expectHTML:     `<p class="quicklog-base">See this</p>` + "\n",
+		},
+		{
+			name:           "Relative",
+			message:        messages.NewLink("Logs", "/builds/42/logs"),
+			expectMarkdown: "[Logs](/builds/42/logs)\n\n",
+			expectHTML:     `<p class="quicklog-link"><a href="/builds/42/logs">Logs</a></p>` + "\n",
+		},
+		{
+			name:           "Mail",
+			message:        messages.NewLink("Support", "mailto:support@example.com"),
+			expectMarkdown: "[Support](mailto:support@example.com)\n\n",
+			expectHTML:     `<p class="quicklog-link"><a href="mailto:support@example.com">Support</a></p>` + "\n",
+		},
+		{
+			// Characters that would end the link, or break its line, are percent-encoded.
+			name:           "Encoded",
+			message:        messages.NewLink("Wiki", "https://example.com/a_(b)\n<c> d"),
+			expectMarkdown: "[Wiki](https://example.com/a_%28b%29%0A%3Cc%3E%20d)\n\n",
+			expectHTML: `<p class="quicklog-link"><a href="https://example.com/a_(b)` + "\n" +
+				`&lt;c&gt; d">Wiki</a></p>` + "\n",
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectMarkdown, quicklog.RenderMarkdown(testCase.message))
+			require.Equal(t, testCase.expectHTML, quicklog.RenderHTML(testCase.message))
+		})
+	}
+}
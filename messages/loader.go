@@ -1,6 +1,9 @@
 package messages
 
 import (
+	"context"
+	"fmt"
+	"html"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +13,9 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/a-novel-kit/quicklog"
 )
@@ -39,35 +45,95 @@ type Loader interface {
 	Success(step string)
 	// Error generates an error message, and closes the loader.
 	Error(err error)
+
+	// Context returns a context carrying the span of the loader, if tracing is enabled, so the operation it tracks
+	// can create child spans. Otherwise, it returns the parent context of the loader.
+	Context() context.Context
+}
+
+// loaderOutput is a single-slot mailbox, that always holds the latest state published by the loader.
+//
+// Publishing never blocks: if the subscriber did not consume the previous value yet, this value is discarded and
+// replaced by the new one. Slow subscribers thus only receive coalesced, up-to-date states.
+type loaderOutput[T any] struct {
+	channel chan T
+	// True while a published value sits in the channel buffer, and may not have been received yet.
+	pending bool
+}
+
+func newLoaderOutput[T any]() *loaderOutput[T] {
+	return &loaderOutput[T]{channel: make(chan T, 1)}
+}
+
+// Settle the previously published value. If the subscriber did not receive it yet, it is discarded. The returned
+// flag indicates whether the previous value was delivered to the subscriber.
+func (output *loaderOutput[T]) settle() (delivered bool) {
+	if !output.pending {
+		return false
+	}
+
+	output.pending = false
+
+	select {
+	case <-output.channel:
+		return false
+	default:
+		return true
+	}
+}
+
+// Publish a new value to the subscriber, discarding the previous one if it is still pending.
+//
+// Calls to publish MUST be serialized by the caller. Under this condition, the send operation cannot block, since
+// this method is the only producer, and always empties the buffer before sending.
+func (output *loaderOutput[T]) publish(value T) {
+	output.settle()
+	output.channel <- value
+	output.pending = true
+}
+
+// Close the subscriber channel. The last published value, if not consumed yet, remains available for reading.
+func (output *loaderOutput[T]) close() {
+	close(output.channel)
 }
 
 type loaderMessage struct {
-	renderTerminal chan string
-	renderJSON     chan map[string]interface{}
+	renderTerminal *loaderOutput[string]
+	renderJSON     *loaderOutput[map[string]interface{}]
 
 	closed bool
 
+	// The current state of the loader.
+	status loaderStatus
 	nested quicklog.Message
 
 	// Keep track of the last rendered step message, for auto updates.
 	lastStep string
-	// Keep track of the last rendered terminal output, for post-processing.
+	// Keep track of the last terminal output that was delivered to the subscriber, so it can be erased.
 	lastRenderedTerminal string
+	// Keep track of the last terminal output published, that might still be waiting for the subscriber.
+	pendingRenderedTerminal string
 	// A flag to determine whether the loader is running in a CI environment.
 	ci bool
 
 	// Record the start time to show a timer after the message.
 	startedAt time.Time
+	// Record the time the loader reached a final state, so the timer stops moving.
+	finishedAt time.Time
 	// Display a custom spinner.
 	spinner *spinner.Model
 	// Record the last time spinner was updated. This helps trigger proper updates, according to fps parameter.
 	spinnerLastUpdate time.Time
 	// Allow logs to be grouped under JSON environments.
 	opID uuid.UUID
+	// The span opened for the lifetime of the loader, if tracing is enabled. The context carries the span, or the
+	// parent context otherwise.
+	span trace.Span
+	ctx  context.Context
 	// Set the updater frequency for the elapsed timer.
 	elapsedUpdateFrequency  time.Duration
-	elapsedUpdateTicker     *time.Ticker
-	elapsedUpdateTickerStop chan bool
+	elapsedUpdateTickerStop chan struct{}
+	elapsedUpdateTickerOnce sync.Once
 
 	wait sync.WaitGroup
 	mu   sync.Mutex
@@ -75,79 +141,12 @@ type loaderMessage struct {
 	quicklog.AnimatedMessage
 }
 
-// ==============================================================================================================
-// Accessors.
-// ==============================================================================================================
-
-// Return whether the terminal channel is set.
-func (loader *loaderMessage) hasTerminalChan() bool {
-	loader.mu.Lock()
-	defer loader.mu.Unlock()
-
-	if loader.renderTerminal == nil {
-		return false
-	}
-
-	return !loader.closed
-}
-
-// Return whether the JSON channel is set.
-func (loader *loaderMessage) hasJSONChan() bool {
-	loader.mu.Lock()
-	defer loader.mu.Unlock()
-
-	if loader.renderJSON == nil {
-		return false
-	}
-
-	return !loader.closed
-}
-
-// Return the terminal channel if it is set. Otherwise, set a new one and return it.
-func (loader *loaderMessage) getOrSetTerminalOutput() <-chan string {
-	if !loader.hasTerminalChan() {
-		loader.mu.Lock()
-		loader.renderTerminal = make(chan string)
-		loader.mu.Unlock()
-	}
-
-	return loader.renderTerminal
-}
-
-// Return the JSON channel if it is set. Otherwise, set a new one and return it.
-func (loader *loaderMessage) getOrSetJSONOutput() <-chan map[string]interface{} {
-	if !loader.hasJSONChan() {
-		loader.mu.Lock()
-		loader.renderJSON = make(chan map[string]interface{})
-		loader.mu.Unlock()
-	}
-
-	return loader.renderJSON
-}
-
-func (loader *loaderMessage) getLastStep() string {
-	loader.mu.Lock()
-	defer loader.mu.Unlock()
-
-	return loader.lastStep
-}
-
-func (loader *loaderMessage) isCI() bool {
-	loader.mu.Lock()
-	defer loader.mu.Unlock()
-
-	return loader.ci
-}
-
 // ==============================================================================================================
 // Rendering.
 // ==============================================================================================================
 
-// Updates and return the loader view.
+// Updates and return the loader view. Caller must hold the lock.
 func (loader *loaderMessage) renderLoader() string {
-	loader.mu.Lock()
-	defer loader.mu.Unlock()
-
 	if time.Since(loader.spinnerLastUpdate) > loader.spinner.Spinner.FPS {
 		// Will be true on first render, prevent unnecessary updates.
 		if loader.spinnerLastUpdate != (time.Time{}) {
@@ -163,13 +162,18 @@ func (loader *loaderMessage) renderLoader() string {
 	return loader.spinner.View()
 }
 
-// Updates and return the time elapsed since the loader started running.
-func (loader *loaderMessage) renderTimeElapsed() string {
-	// Compute the time elapsed since the loader started running.
-	loader.mu.Lock()
-	timeElapsedRaw := time.Since(loader.startedAt)
-	loader.mu.Unlock()
+// Return the time elapsed since the loader started running, up to the moment it reached a final state.
+// Caller must hold the lock.
+func (loader *loaderMessage) timeElapsed() time.Duration {
+	if loader.finishedAt != (time.Time{}) {
+		return loader.finishedAt.Sub(loader.startedAt)
+	}
 
+	return time.Since(loader.startedAt)
+}
+
+// Format the time elapsed since a loader started running.
+func renderLoaderElapsed(timeElapsedRaw time.Duration) string {
 	// Prevent the display of values with large fractions.
 	if timeElapsedRaw >= 10*time.Second {
 		timeElapsedRaw = timeElapsedRaw.Round(time.Second)
@@ -180,23 +184,14 @@ func (loader *loaderMessage) renderTimeElapsed() string {
 	return timeElapsedRaw.String()
 }
 
-// Send a new message to the terminal channel, if set.
-//
-// If no terminal channel is set, this method is a no-op.
-func (loader *loaderMessage) updateTerminalOutput(step string, status loaderStatus) {
-	if !loader.hasTerminalChan() {
-		return
-	}
-
-	if step == "" {
-		step = loader.getLastStep()
-	}
-
-	prefix := lo.Switch[loaderStatus, string](status).
-		Case(loaderStatusSuccess, lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("✓")).
-		Case(loaderStatusError, lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("✗")).
-		DefaultF(func() string { return loader.renderLoader() })
+// Updates and return the time elapsed since the loader started running. Caller must hold the lock.
+func (loader *loaderMessage) renderTimeElapsed() string {
+	return renderLoaderElapsed(loader.timeElapsed())
+}
 
+// Render the line of a loader in the terminal: the status prefix and the step, with the elapsed time aligned to the
+// right.
+func renderLoaderLine(status loaderStatus, prefix, step, elapsed string) string {
 	message := lo.Switch[loaderStatus, string](status).
 		Case(loaderStatusSuccess, lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render(step)).
 		Case(loaderStatusError, lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(step)).
@@ -207,7 +202,7 @@ func (loader *loaderMessage) updateTerminalOutput(step string, status loaderStat
 	timeElapsed := lipgloss.NewStyle().
 		Faint(true).
 		Foreground(lipgloss.Color("15")).
-		Render(loader.renderTimeElapsed())
+		Render(elapsed)
 
 	timeElapsedMargin := lo.Max([]int{
 		1,
@@ -215,119 +210,178 @@ func (loader *loaderMessage) updateTerminalOutput(step string, status loaderStat
 			((lipgloss.Width(mainMessage) + lipgloss.Width(timeElapsed)) % quicklog.TermWidth),
 	})
 
-	fullMessage := lipgloss.NewStyle().
+	return lipgloss.NewStyle().
 		Width(quicklog.TermWidth).
 		Render(mainMessage+lipgloss.NewStyle().MarginLeft(timeElapsedMargin).Render(timeElapsed)) + "\n"
+}
 
-	if loader.nested != nil {
-		fullMessage += loader.nested.RenderTerminal()
+// Return the colored symbol of a final status.
+func renderLoaderPrefix(status loaderStatus) string {
+	return lo.Switch[loaderStatus, string](status).
+		Case(loaderStatusSuccess, lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Render("✓")).
+		Case(loaderStatusError, lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("✗")).
+		Default("")
+}
+
+// Publish the current state to the terminal subscriber, if any. Caller must hold the lock.
+func (loader *loaderMessage) publishTerminalOutput() {
+	if loader.renderTerminal == nil {
+		return
 	}
 
-	loader.mu.Lock()
-	lastRendered := loader.lastRenderedTerminal
-	loader.mu.Unlock()
+	prefix := renderLoaderPrefix(loader.status)
+	if loader.status == loaderStatusDefault {
+		prefix = loader.renderLoader()
+	}
 
-	// Only overwrite the previous message outside CI environment.
-	if !loader.isCI() {
-		// Get the previous content so it can be erased.
-		loader.mu.Lock()
-		loader.lastRenderedTerminal = fullMessage
+	fullMessage := renderLoaderLine(loader.status, prefix, loader.lastStep, loader.renderTimeElapsed())
 
-		if lastRendered != "" {
-			fullMessage = strings.Repeat(EraseLineSequence, lipgloss.Height(lastRendered)) + fullMessage
-		}
+	if loader.nested != nil {
+		fullMessage += loader.nested.RenderTerminal()
+	}
 
-		loader.mu.Unlock()
+	// The previous output is only erased once it has actually been delivered. If it was discarded, then the
+	// subscriber still displays the one before it.
+	if loader.renderTerminal.settle() {
+		loader.lastRenderedTerminal = loader.pendingRenderedTerminal
 	}
 
-	loader.renderTerminal <- fullMessage
+	output := fullMessage
+	if !loader.ci && loader.lastRenderedTerminal != "" {
+		output = strings.Repeat(EraseLineSequence, lipgloss.Height(loader.lastRenderedTerminal)) + fullMessage
+	}
+
+	loader.renderTerminal.publish(output)
+	loader.pendingRenderedTerminal = fullMessage
 }
 
-// Send a new message to the JSON channel, if set.
-//
-// If no JSON channel is set, this method is a no-op.
-func (loader *loaderMessage) updateJSONOutput(step string, status loaderStatus) {
-	if !loader.hasJSONChan() {
+// Publish the current state to the JSON subscriber, if any. Caller must hold the lock.
+func (loader *loaderMessage) publishJSONOutput() {
+	if loader.renderJSON == nil {
 		return
 	}
 
-	if step == "" {
-		step = loader.getLastStep()
-	}
-
-	loader.mu.Lock()
-	elapsedTime := time.Since(loader.startedAt)
-	loader.mu.Unlock()
+	elapsedTime := loader.timeElapsed()
 
-	output := map[string]interface{}{
-		"message":       step,
+	output := quicklog.RenderKindJSON(KindLoader, map[string]interface{}{
+		"message":       loader.lastStep,
 		"elapsed":       elapsedTime.String(),
 		"elapsed_nanos": elapsedTime.Nanoseconds(),
 		"op_id":         loader.opID.String(),
-		"status":        string(status),
-	}
+		"status":        string(loader.status),
+	})
+
+	// Correlate the output with the span of the loader, or its parent.
+	output = lo.Assign(output, traceJSON(loader.ctx))
 
 	if loader.nested != nil {
-		loader.mu.Lock()
-		output["data"] = loader.nested.RenderJSON()
-		loader.mu.Unlock()
+		output["data"] = quicklog.RenderChildJSON(loader.nested)
 	}
 
-	loader.renderJSON <- output
+	loader.renderJSON.publish(output)
+}
+
+// Return the symbol representing a status in static renderings.
+func loaderStatusSymbol(status loaderStatus) string {
+	return lo.Switch[loaderStatus, string](status).
+		Case(loaderStatusSuccess, "✓").
+		Case(loaderStatusError, "✗").
+		Default("…")
+}
+
+// Return the symbol representing the current status in static renderings. Caller must hold the lock.
+func (loader *loaderMessage) renderStatusSymbol() string {
+	return loaderStatusSymbol(loader.status)
+}
+
+// RenderMarkdown renders the current state of the loader, usually its final state once it has been closed.
+func (loader *loaderMessage) RenderMarkdown() string {
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	content := loader.renderStatusSymbol() + " " + escapeMarkdown(loader.lastStep) +
+		" *(" + loader.renderTimeElapsed() + ")*\n\n"
+
+	return quicklog.RenderWithChildMarkdown(content, loader.nested)
+}
+
+// RenderHTML renders the current state of the loader, usually its final state once it has been closed.
+func (loader *loaderMessage) RenderHTML() string {
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	content := fmt.Sprintf(
+		`<p class="quicklog-loader quicklog-loader-%s">%s %s <small>%s</small></p>`+"\n",
+		loader.status, loader.renderStatusSymbol(), html.EscapeString(loader.lastStep), loader.renderTimeElapsed(),
+	)
+
+	return quicklog.RenderWithChildHTML(content, loader.nested)
 }
 
 // ==============================================================================================================
 // Loader state management.
 // ==============================================================================================================
 
-// Updates the value of the latest rendered step.
-func (loader *loaderMessage) setLastStep(step string) {
-	if step == "" {
-		return
-	}
-
+// Record a new state for the loader, and publish it to every subscriber. Calls made once the loader has reached a
+// final state, or was closed, are ignored: the returned flag indicates whether the state was recorded.
+func (loader *loaderMessage) setState(step string, status loaderStatus) bool {
 	loader.mu.Lock()
 	defer loader.mu.Unlock()
-	loader.lastStep = step
-}
 
-// Close the previous ticker if any.
-func (loader *loaderMessage) closeTicker() {
-	if loader.elapsedUpdateTickerStop != nil {
-		loader.elapsedUpdateTickerStop <- true
+	if loader.closed || loader.status != loaderStatusDefault {
+		return false
 	}
 
-	if loader.elapsedUpdateTicker != nil {
-		loader.elapsedUpdateTicker.Stop()
+	if step != "" {
+		loader.lastStep = step
 	}
 
-	loader.wait.Wait()
+	loader.status = status
+	if status != loaderStatusDefault {
+		loader.finishedAt = time.Now()
+	}
+
+	loader.publishTerminalOutput()
+	loader.publishJSONOutput()
+
+	return true
 }
 
-// Return the elapsed update ticker if it is set. Otherwise, set a new one and return it.
-func (loader *loaderMessage) getOrSetTicker() *time.Ticker {
-	if loader.elapsedUpdateTicker == nil {
-		loader.mu.Lock()
-		loader.elapsedUpdateTicker = time.NewTicker(loader.elapsedUpdateFrequency)
-		loader.elapsedUpdateTickerStop = make(chan bool)
-		loader.mu.Unlock()
+// Re-render the current state of the terminal subscriber, to refresh the spinner and timer.
+func (loader *loaderMessage) refreshTerminalOutput() {
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	if loader.closed || loader.status != loaderStatusDefault {
+		return
 	}
 
-	return loader.elapsedUpdateTicker
+	loader.publishTerminalOutput()
+}
+
+// Stop the periodic updates, if any. This method is safe to call multiple times.
+func (loader *loaderMessage) closeTicker() {
+	loader.elapsedUpdateTickerOnce.Do(func() {
+		close(loader.elapsedUpdateTickerStop)
+	})
+
+	loader.wait.Wait()
 }
 
 // Periodically send new messages to the terminal channel, independently of user updates.
 func (loader *loaderMessage) runAutoTerminalUpdates() {
-	ticker := loader.getOrSetTicker()
+	ticker := time.NewTicker(loader.elapsedUpdateFrequency)
 	loader.wait.Add(1)
 
 	go func() {
+		defer loader.wait.Done()
+		defer ticker.Stop()
+
 		for {
 			select {
 			case <-ticker.C:
-				loader.updateTerminalOutput("", loaderStatusDefault)
+				loader.refreshTerminalOutput()
 			case <-loader.elapsedUpdateTickerStop:
-				loader.wait.Done()
 				return
 			}
 		}
@@ -345,68 +399,124 @@ func (loader *loaderMessage) Nest(message quicklog.Message) {
 }
 
 func (loader *loaderMessage) Update(step string) {
-	loader.updateTerminalOutput(step, loaderStatusDefault)
-	loader.updateJSONOutput(step, loaderStatusDefault)
-	loader.setLastStep(step)
+	if loader.setState(step, loaderStatusDefault) && loader.span != nil && step != "" {
+		loader.span.AddEvent(step)
+	}
 }
 
 func (loader *loaderMessage) Success(step string) {
-	loader.closeTicker()
+	if loader.setState(step, loaderStatusSuccess) && loader.span != nil {
+		if step != "" {
+			loader.span.AddEvent(step)
+		}
 
-	loader.updateTerminalOutput(step, loaderStatusSuccess)
-	loader.updateJSONOutput(step, loaderStatusSuccess)
-	loader.setLastStep(step)
+		loader.span.SetStatus(codes.Ok, "")
+		loader.span.End()
+	}
+
+	loader.closeTicker()
 }
 
 func (loader *loaderMessage) Error(err error) {
-	loader.closeTicker()
+	if loader.setState(err.Error(), loaderStatusError) && loader.span != nil {
+		loader.span.RecordError(err)
+		loader.span.SetStatus(codes.Error, err.Error())
+		loader.span.End()
+	}
 
-	message := err.Error()
+	loader.closeTicker()
+}
 
-	loader.updateTerminalOutput(message, loaderStatusError)
-	loader.updateJSONOutput(message, loaderStatusError)
-	loader.setLastStep(message)
+func (loader *loaderMessage) Context() context.Context {
+	return loader.ctx
 }
 
 func (loader *loaderMessage) Close() {
+	loader.mu.Lock()
+	if loader.closed {
+		loader.mu.Unlock()
+		return
+	}
+
+	loader.closed = true
+	// Loaders closed before reaching a final state still end their span, without a status.
+	running := loader.status == loaderStatusDefault
+	loader.mu.Unlock()
+
+	if running && loader.span != nil {
+		loader.span.End()
+	}
+
+	// Wait for the ticker to stop before closing the channels, so it does not publish to a closed channel.
+	// Any publication attempted in the meantime is discarded, since the loader is marked as closed.
 	loader.closeTicker()
 
-	if loader.hasTerminalChan() {
-		close(loader.renderTerminal)
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	if loader.renderTerminal != nil {
+		loader.renderTerminal.close()
 	}
-	if loader.hasJSONChan() {
-		close(loader.renderJSON)
+	if loader.renderJSON != nil {
+		loader.renderJSON.close()
 	}
+}
 
+// SectionTitle returns the current step of the loader.
+func (loader *loaderMessage) SectionTitle() string {
 	loader.mu.Lock()
-	loader.closed = true
-	loader.mu.Unlock()
+	defer loader.mu.Unlock()
+
+	return loader.lastStep
 }
 
 func (loader *loaderMessage) RunTerminal(isCI bool) <-chan string {
 	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	if loader.renderTerminal != nil {
+		return loader.renderTerminal.channel
+	}
+
 	loader.ci = isCI
-	loader.mu.Unlock()
+	loader.renderTerminal = newLoaderOutput[string]()
+
+	if loader.closed {
+		loader.renderTerminal.close()
+		return loader.renderTerminal.channel
+	}
 
-	channel := loader.getOrSetTerminalOutput()
 	// Trigger initial rendering.
-	go loader.Update("")
+	loader.publishTerminalOutput()
 
 	// If outside CI environment, run periodic updates on our own. Otherwise, let the Update method provide relevant
 	// updates.
-	if !isCI {
+	if !isCI && loader.status == loaderStatusDefault {
 		loader.runAutoTerminalUpdates()
 	}
 
-	return channel
+	return loader.renderTerminal.channel
 }
 
 func (loader *loaderMessage) RunJSON() <-chan map[string]interface{} {
-	channel := loader.getOrSetJSONOutput()
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	if loader.renderJSON != nil {
+		return loader.renderJSON.channel
+	}
+
+	loader.renderJSON = newLoaderOutput[map[string]interface{}]()
+
+	if loader.closed {
+		loader.renderJSON.close()
+		return loader.renderJSON.channel
+	}
+
 	// Trigger initial rendering.
-	go loader.Update("")
+	loader.publishJSONOutput()
 
-	return channel
+	return loader.renderJSON.channel
 }
 
 // ==============================================================================================================
@@ -419,6 +529,14 @@ type LoaderConfig struct {
 	OpID            *uuid.UUID
 	UpdateFrequency *time.Duration
 
+	// Tracer, if set, opens a span for the lifetime of the loader, named after its first step. Updates are recorded
+	// as events of the span, and the final state sets its status. The op_id of the loader is set as the
+	// quicklog.op_id attribute of the span.
+	Tracer trace.Tracer
+	// Context is the parent context of the span. If it carries a span, JSON outputs are correlated with it, even
+	// without a Tracer.
+	Context context.Context
+
 	// Required.
 
 	Spinner spinner.Model
@@ -435,12 +553,24 @@ var LoaderConfigDefault = LoaderConfig{
 }
 
 func NewLoader(step string, config *LoaderConfig) Loader {
+	// Each loader owns its spinner, so concurrent loaders sharing a config do not update the same model.
+	loaderSpinner := config.Spinner
+
 	loader := &loaderMessage{
-		spinner:                &config.Spinner,
-		lastStep:               step,
-		opID:                   lo.Ternary(config.OpID != nil, lo.FromPtr(config.OpID), uuid.New()),
-		startedAt:              time.Now(),
-		elapsedUpdateFrequency: lo.CoalesceOrEmpty(lo.FromPtr(config.UpdateFrequency), 50*time.Millisecond),
+		spinner:                 &loaderSpinner,
+		lastStep:                step,
+		status:                  loaderStatusDefault,
+		opID:                    lo.Ternary(config.OpID != nil, lo.FromPtr(config.OpID), uuid.New()),
+		ctx:                     lo.Ternary(config.Context != nil, config.Context, context.Background()),
+		startedAt:               time.Now(),
+		elapsedUpdateFrequency:  lo.CoalesceOrEmpty(lo.FromPtr(config.UpdateFrequency), 50*time.Millisecond),
+		elapsedUpdateTickerStop: make(chan struct{}),
+	}
+
+	if config.Tracer != nil {
+		loader.ctx, loader.span = config.Tracer.Start(
+			loader.ctx, step, trace.WithAttributes(attribute.String("quicklog.op_id", loader.opID.String())),
+		)
 	}
 
 	return loader
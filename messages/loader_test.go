@@ -1,8 +1,11 @@
 package messages_test
 
 import (
+	"context"
 	"errors"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,9 +13,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	testutils "github.com/a-novel-kit/test-utils"
 
+	"github.com/a-novel-kit/quicklog"
 	"github.com/a-novel-kit/quicklog/messages"
 )
 
@@ -245,6 +254,8 @@ func TestLoaderJSON(t *testing.T) {
 		defer loader.Close()
 
 		testutils.RequireChan(t, loader.RunJSON(), func(collect *assert.CollectT, value map[string]interface{}) {
+			assert.Equal(collect, "loader", value["kind"])
+			assert.Equal(collect, quicklog.SchemaVersion, value["schema_version"])
 			assert.Equal(collect, "initial message", value["message"])
 			assert.Regexp(collect, regexp.MustCompile(`^\d{1,3}(\.\d+)?(µs|ms|s)$`), value["elapsed"])
 			assert.NotEmpty(collect, value["elapsed_nanos"])
@@ -361,7 +372,7 @@ func TestLoaderJSON(t *testing.T) {
 			assert.NotEmpty(collect, value["elapsed_nanos"])
 			assert.Equal(collect, dummyOpID.String(), value["op_id"])
 			assert.Equal(collect, "running", value["status"])
-			assert.Equal(collect, map[string]interface{}{"message": "child message"}, value["data"])
+			assert.Equal(collect, map[string]interface{}{"kind": "base", "message": "child message"}, value["data"])
 		})
 
 		loader.Nest(nil)
@@ -376,3 +387,327 @@ func TestLoaderJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestLoaderNonBlocking(t *testing.T) {
+	t.Run("UpdateWithoutSubscriber", func(t *testing.T) {
+		loader := messages.NewLoader("initial message", loaderTestConfig)
+		defer loader.Close()
+
+		loader.RunTerminal(true)
+		loader.RunJSON()
+
+		// Nobody reads the channels: updates must not block.
+		for i := 0; i < 100; i++ {
+			loader.Update("updated message")
+		}
+
+		loader.Success("success message")
+	})
+
+	t.Run("CoalesceUpdates", func(t *testing.T) {
+		loader := messages.NewLoader("initial message", loaderTestConfig)
+
+		channel := loader.RunJSON()
+
+		loader.Update("updated message")
+		loader.Update("updated message 2")
+		loader.Success("success message")
+		loader.Close()
+
+		var received []map[string]interface{}
+		for value := range channel {
+			received = append(received, value)
+		}
+
+		// Only the latest state is kept for slow subscribers.
+		require.Len(t, received, 1)
+		require.Equal(t, "success message", received[0]["message"])
+		require.Equal(t, "success", received[0]["status"])
+	})
+
+	t.Run("IgnoreUpdatesAfterCompletion", func(t *testing.T) {
+		loader := messages.NewLoader("initial message", loaderTestConfig)
+
+		channel := loader.RunJSON()
+
+		loader.Error(errors.New("error message"))
+		loader.Update("updated message")
+		loader.Success("success message")
+		loader.Close()
+		// Closing twice is a no-op.
+		loader.Close()
+
+		var last map[string]interface{}
+		for value := range channel {
+			last = value
+		}
+
+		require.Equal(t, "error message", last["message"])
+		require.Equal(t, "error", last["status"])
+	})
+
+	t.Run("EraseOnlyDeliveredOutput", func(t *testing.T) {
+		loader := messages.NewLoader("initial message", loaderTestConfig)
+
+		channel := loader.RunTerminal(false)
+
+		// Discard the initial render before it is received: nothing was displayed, so nothing needs erasing.
+		loader.Nest(messages.NewBase("child message", nil))
+		loader.Update("updated message")
+
+		value := <-channel
+		require.Regexp(t, regexp.MustCompile(`^u updated message .+\nchild message\s+\n$`), value)
+
+		// The displayed output spans 2 lines, that must be erased by the next render.
+		loader.Nest(nil)
+		loader.Success("success message")
+		loader.Close()
+
+		var last string
+		for value = range channel {
+			last = value
+		}
+
+		require.Equal(t, strings.Repeat(messages.EraseLineSequence, 2), last[:2*len(messages.EraseLineSequence)])
+		require.Regexp(t, regexp.MustCompile(`✓ success message .+\n$`), last)
+	})
+
+	t.Run("RunAfterClose", func(t *testing.T) {
+		loader := messages.NewLoader("initial message", loaderTestConfig)
+		loader.Close()
+
+		_, ok := <-loader.RunTerminal(false)
+		require.False(t, ok)
+
+		_, ok = <-loader.RunJSON()
+		require.False(t, ok)
+	})
+}
+
+func TestLoaderConcurrentUpdateAndClose(t *testing.T) {
+	cfg := *loaderTestConfig
+	cfg.UpdateFrequency = lo.ToPtr(time.Millisecond)
+
+	for i := 0; i < 50; i++ {
+		loader := messages.NewLoader("initial message", &cfg)
+
+		terminalChannel := loader.RunTerminal(i%2 == 0)
+		jsonChannel := loader.RunJSON()
+
+		var consumers sync.WaitGroup
+
+		consumers.Add(2)
+
+		go func() {
+			defer consumers.Done()
+
+			for range terminalChannel {
+			}
+		}()
+		go func() {
+			defer consumers.Done()
+
+			for range jsonChannel {
+			}
+		}()
+
+		var producers sync.WaitGroup
+
+		for j := 0; j < 8; j++ {
+			producers.Add(1)
+
+			go func(j int) {
+				defer producers.Done()
+
+				for k := 0; k < 50; k++ {
+					switch {
+					case j == 0 && k == 25:
+						loader.Success("success message")
+					case j == 1 && k == 25:
+						loader.Error(errors.New("error message"))
+					case j == 2 && k%10 == 0:
+						loader.Nest(messages.NewBase("child message", nil))
+					default:
+						loader.Update("updated message")
+					}
+				}
+			}(j)
+		}
+
+		// Close while producers are still running.
+		go loader.Close()
+
+		producers.Wait()
+		loader.Close()
+		consumers.Wait()
+	}
+}
+
+func TestLoaderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		run func(loader messages.Loader)
+
+		expectMarkdown *regexp.Regexp
+		expectHTML     *regexp.Regexp
+	}{
+		{
+			name: "Running",
+
+			run: func(loader messages.Loader) {
+				loader.Update("updated *message*")
+			},
+
+			expectMarkdown: regexp.MustCompile(`^… updated \\\*message\\\* \*\([^)]+\)\*\n\n$`),
+			expectHTML: regexp.MustCompile(
+				`^<p class="quicklog-loader quicklog-loader-running">… updated \*message\* <small>[^<]+</small></p>\n$`,
+			),
+		},
+		{
+			name: "Success",
+
+			run: func(loader messages.Loader) {
+				loader.Nest(messages.NewBase("nested message", nil))
+				loader.Success("success message")
+			},
+
+			expectMarkdown: regexp.MustCompile(`^✓ success message \*\([^)]+\)\*\n\nnested message\n\n$`),
+			expectHTML: regexp.MustCompile(
+				`^<p class="quicklog-loader quicklog-loader-success">✓ success message <small>[^<]+</small></p>\n` +
+					`<p class="quicklog-base">nested message</p>\n$`,
+			),
+		},
+		{
+			name: "Error",
+
+			run: func(loader messages.Loader) {
+				loader.Error(errors.New("uh oh"))
+			},
+
+			expectMarkdown: regexp.MustCompile(`^✗ uh oh \*\([^)]+\)\*\n\n$`),
+			expectHTML: regexp.MustCompile(
+				`^<p class="quicklog-loader quicklog-loader-error">✗ uh oh <small>[^<]+</small></p>\n$`,
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			loader := messages.NewLoader("initial message", loaderTestConfig)
+			testCase.run(loader)
+			loader.Close()
+
+			markdownLoader, ok := loader.(quicklog.MarkdownMessage)
+			require.True(t, ok)
+			require.Regexp(t, testCase.expectMarkdown, markdownLoader.RenderMarkdown())
+
+			htmlLoader, ok := loader.(quicklog.HTMLMessage)
+			require.True(t, ok)
+			require.Regexp(t, testCase.expectHTML, htmlLoader.RenderHTML())
+		})
+	}
+}
+
+func TestLoaderSpan(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tracer, exporter := newTestTracer(t)
+
+		parentCtx, parent := tracer.Start(context.Background(), "parent")
+		defer parent.End()
+
+		config := *loaderTestConfig
+		config.Tracer = tracer
+		config.Context = parentCtx
+
+		loader := messages.NewLoader("initial message", &config)
+		defer loader.Close()
+
+		span := trace.SpanFromContext(loader.Context())
+		require.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+
+		// The JSON output is correlated with the span of the loader.
+		testutils.RequireChan(t, loader.RunJSON(), func(collect *assert.CollectT, value map[string]interface{}) {
+			assert.Equal(collect, span.SpanContext().TraceID().String(), value["trace_id"])
+			assert.Equal(collect, span.SpanContext().SpanID().String(), value["span_id"])
+		})
+
+		loader.Update("updated message")
+		// Re-rendering the previous step is not an event.
+		loader.Update("")
+		loader.Success("success message")
+		// Ignored, the loader is done.
+		loader.Update("late message")
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+
+		require.Equal(t, "initial message", spans[0].Name)
+		require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		require.Equal(t, codes.Ok, spans[0].Status.Code)
+		require.Equal(t, []attribute.KeyValue{
+			attribute.String("quicklog.op_id", dummyOpID.String()),
+		}, spans[0].Attributes)
+		require.Equal(t, []string{"updated message", "success message"}, lo.Map(
+			spans[0].Events, func(event sdktrace.Event, _ int) string { return event.Name },
+		))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tracer, exporter := newTestTracer(t)
+
+		config := *loaderTestConfig
+		config.Tracer = tracer
+
+		loader := messages.NewLoader("initial message", &config)
+		defer loader.Close()
+
+		loader.Error(errors.New("error message"))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+
+		require.False(t, spans[0].Parent.IsValid())
+		require.Equal(t, sdktrace.Status{Code: codes.Error, Description: "error message"}, spans[0].Status)
+		require.Len(t, spans[0].Events, 1)
+		require.Equal(t, "exception", spans[0].Events[0].Name)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		tracer, exporter := newTestTracer(t)
+
+		config := *loaderTestConfig
+		config.Tracer = tracer
+
+		loader := messages.NewLoader("initial message", &config)
+		loader.Close()
+		loader.Close()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("NoTracer", func(t *testing.T) {
+		tracer, exporter := newTestTracer(t)
+
+		parentCtx, parent := tracer.Start(context.Background(), "parent")
+		defer parent.End()
+
+		config := *loaderTestConfig
+		config.Context = parentCtx
+
+		loader := messages.NewLoader("initial message", &config)
+		defer loader.Close()
+
+		require.Equal(t, parentCtx, loader.Context())
+
+		// The JSON output is correlated with the parent span.
+		testutils.RequireChan(t, loader.RunJSON(), func(collect *assert.CollectT, value map[string]interface{}) {
+			assert.Equal(collect, parent.SpanContext().SpanID().String(), value["span_id"])
+		})
+
+		loader.Success("success message")
+		require.Empty(t, exporter.GetSpans())
+	})
+}
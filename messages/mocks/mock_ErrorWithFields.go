@@ -0,0 +1,124 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package messagesmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockErrorWithFields is an autogenerated mock type for the ErrorWithFields type
+type MockErrorWithFields struct {
+	mock.Mock
+}
+
+type MockErrorWithFields_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockErrorWithFields) EXPECT() *MockErrorWithFields_Expecter {
+	return &MockErrorWithFields_Expecter{mock: &_m.Mock}
+}
+
+// Error provides a mock function with given fields:
+func (_m *MockErrorWithFields) Error() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Error")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockErrorWithFields_Error_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Error'
+type MockErrorWithFields_Error_Call struct {
+	*mock.Call
+}
+
+// Error is a helper method to define mock.On call
+func (_e *MockErrorWithFields_Expecter) Error() *MockErrorWithFields_Error_Call {
+	return &MockErrorWithFields_Error_Call{Call: _e.mock.On("Error")}
+}
+
+func (_c *MockErrorWithFields_Error_Call) Run(run func()) *MockErrorWithFields_Error_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockErrorWithFields_Error_Call) Return(_a0 string) *MockErrorWithFields_Error_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockErrorWithFields_Error_Call) RunAndReturn(run func() string) *MockErrorWithFields_Error_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fields provides a mock function with given fields:
+func (_m *MockErrorWithFields) Fields() map[string]interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fields")
+	}
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func() map[string]interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	return r0
+}
+
+// MockErrorWithFields_Fields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fields'
+type MockErrorWithFields_Fields_Call struct {
+	*mock.Call
+}
+
+// Fields is a helper method to define mock.On call
+func (_e *MockErrorWithFields_Expecter) Fields() *MockErrorWithFields_Fields_Call {
+	return &MockErrorWithFields_Fields_Call{Call: _e.mock.On("Fields")}
+}
+
+func (_c *MockErrorWithFields_Fields_Call) Run(run func()) *MockErrorWithFields_Fields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockErrorWithFields_Fields_Call) Return(_a0 map[string]interface{}) *MockErrorWithFields_Fields_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockErrorWithFields_Fields_Call) RunAndReturn(run func() map[string]interface{}) *MockErrorWithFields_Fields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockErrorWithFields creates a new instance of MockErrorWithFields. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockErrorWithFields(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockErrorWithFields {
+	mock := &MockErrorWithFields{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
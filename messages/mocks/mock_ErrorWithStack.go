@@ -0,0 +1,128 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package messagesmocks
+
+import (
+	runtime "runtime"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockErrorWithStack is an autogenerated mock type for the ErrorWithStack type
+type MockErrorWithStack struct {
+	mock.Mock
+}
+
+type MockErrorWithStack_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockErrorWithStack) EXPECT() *MockErrorWithStack_Expecter {
+	return &MockErrorWithStack_Expecter{mock: &_m.Mock}
+}
+
+// Error provides a mock function with given fields:
+func (_m *MockErrorWithStack) Error() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Error")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockErrorWithStack_Error_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Error'
+type MockErrorWithStack_Error_Call struct {
+	*mock.Call
+}
+
+// Error is a helper method to define mock.On call
+func (_e *MockErrorWithStack_Expecter) Error() *MockErrorWithStack_Error_Call {
+	return &MockErrorWithStack_Error_Call{Call: _e.mock.On("Error")}
+}
+
+func (_c *MockErrorWithStack_Error_Call) Run(run func()) *MockErrorWithStack_Error_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockErrorWithStack_Error_Call) Return(_a0 string) *MockErrorWithStack_Error_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockErrorWithStack_Error_Call) RunAndReturn(run func() string) *MockErrorWithStack_Error_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StackTrace provides a mock function with given fields:
+func (_m *MockErrorWithStack) StackTrace() []runtime.Frame {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StackTrace")
+	}
+
+	var r0 []runtime.Frame
+	if rf, ok := ret.Get(0).(func() []runtime.Frame); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]runtime.Frame)
+		}
+	}
+
+	return r0
+}
+
+// MockErrorWithStack_StackTrace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StackTrace'
+type MockErrorWithStack_StackTrace_Call struct {
+	*mock.Call
+}
+
+// StackTrace is a helper method to define mock.On call
+func (_e *MockErrorWithStack_Expecter) StackTrace() *MockErrorWithStack_StackTrace_Call {
+	return &MockErrorWithStack_StackTrace_Call{Call: _e.mock.On("StackTrace")}
+}
+
+func (_c *MockErrorWithStack_StackTrace_Call) Run(run func()) *MockErrorWithStack_StackTrace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockErrorWithStack_StackTrace_Call) Return(_a0 []runtime.Frame) *MockErrorWithStack_StackTrace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockErrorWithStack_StackTrace_Call) RunAndReturn(run func() []runtime.Frame) *MockErrorWithStack_StackTrace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockErrorWithStack creates a new instance of MockErrorWithStack. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockErrorWithStack(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockErrorWithStack {
+	mock := &MockErrorWithStack{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
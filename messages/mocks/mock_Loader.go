@@ -3,8 +3,11 @@
 package messagesmocks
 
 import (
-	quicklog "github.com/a-novel-kit/quicklog"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	quicklog "github.com/a-novel-kit/quicklog"
 )
 
 // MockLoader is an autogenerated mock type for the Loader type
@@ -52,6 +55,53 @@ func (_c *MockLoader_Close_Call) RunAndReturn(run func()) *MockLoader_Close_Call
 	return _c
 }
 
+// Context provides a mock function with given fields:
+func (_m *MockLoader) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// MockLoader_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type MockLoader_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *MockLoader_Expecter) Context() *MockLoader_Context_Call {
+	return &MockLoader_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *MockLoader_Context_Call) Run(run func()) *MockLoader_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLoader_Context_Call) Return(_a0 context.Context) *MockLoader_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoader_Context_Call) RunAndReturn(run func() context.Context) *MockLoader_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Error provides a mock function with given fields: err
 func (_m *MockLoader) Error(err error) {
 	_m.Called(err)
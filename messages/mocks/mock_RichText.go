@@ -0,0 +1,513 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package messagesmocks
+
+import (
+	messages "github.com/a-novel-kit/quicklog/messages"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRichText is an autogenerated mock type for the RichText type
+type MockRichText struct {
+	mock.Mock
+}
+
+type MockRichText_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRichText) EXPECT() *MockRichText_Expecter {
+	return &MockRichText_Expecter{mock: &_m.Mock}
+}
+
+// Bold provides a mock function with given fields: text
+func (_m *MockRichText) Bold(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bold")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Bold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bold'
+type MockRichText_Bold_Call struct {
+	*mock.Call
+}
+
+// Bold is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Bold(text interface{}) *MockRichText_Bold_Call {
+	return &MockRichText_Bold_Call{Call: _e.mock.On("Bold", text)}
+}
+
+func (_c *MockRichText_Bold_Call) Run(run func(text string)) *MockRichText_Bold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Bold_Call) Return(_a0 messages.RichText) *MockRichText_Bold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Bold_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Bold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Code provides a mock function with given fields: text
+func (_m *MockRichText) Code(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Code")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Code_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Code'
+type MockRichText_Code_Call struct {
+	*mock.Call
+}
+
+// Code is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Code(text interface{}) *MockRichText_Code_Call {
+	return &MockRichText_Code_Call{Call: _e.mock.On("Code", text)}
+}
+
+func (_c *MockRichText_Code_Call) Run(run func(text string)) *MockRichText_Code_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Code_Call) Return(_a0 messages.RichText) *MockRichText_Code_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Code_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Code_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Color provides a mock function with given fields: text, color
+func (_m *MockRichText) Color(text string, color string) messages.RichText {
+	ret := _m.Called(text, color)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Color")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string, string) messages.RichText); ok {
+		r0 = rf(text, color)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Color_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Color'
+type MockRichText_Color_Call struct {
+	*mock.Call
+}
+
+// Color is a helper method to define mock.On call
+//   - text string
+//   - color string
+func (_e *MockRichText_Expecter) Color(text interface{}, color interface{}) *MockRichText_Color_Call {
+	return &MockRichText_Color_Call{Call: _e.mock.On("Color", text, color)}
+}
+
+func (_c *MockRichText_Color_Call) Run(run func(text string, color string)) *MockRichText_Color_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Color_Call) Return(_a0 messages.RichText) *MockRichText_Color_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Color_Call) RunAndReturn(run func(string, string) messages.RichText) *MockRichText_Color_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Dim provides a mock function with given fields: text
+func (_m *MockRichText) Dim(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dim")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Dim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dim'
+type MockRichText_Dim_Call struct {
+	*mock.Call
+}
+
+// Dim is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Dim(text interface{}) *MockRichText_Dim_Call {
+	return &MockRichText_Dim_Call{Call: _e.mock.On("Dim", text)}
+}
+
+func (_c *MockRichText_Dim_Call) Run(run func(text string)) *MockRichText_Dim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Dim_Call) Return(_a0 messages.RichText) *MockRichText_Dim_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Dim_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Dim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Italic provides a mock function with given fields: text
+func (_m *MockRichText) Italic(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Italic")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Italic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Italic'
+type MockRichText_Italic_Call struct {
+	*mock.Call
+}
+
+// Italic is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Italic(text interface{}) *MockRichText_Italic_Call {
+	return &MockRichText_Italic_Call{Call: _e.mock.On("Italic", text)}
+}
+
+func (_c *MockRichText_Italic_Call) Run(run func(text string)) *MockRichText_Italic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Italic_Call) Return(_a0 messages.RichText) *MockRichText_Italic_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Italic_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Italic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Link provides a mock function with given fields: text, url
+func (_m *MockRichText) Link(text string, url string) messages.RichText {
+	ret := _m.Called(text, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Link")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string, string) messages.RichText); ok {
+		r0 = rf(text, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Link_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Link'
+type MockRichText_Link_Call struct {
+	*mock.Call
+}
+
+// Link is a helper method to define mock.On call
+//   - text string
+//   - url string
+func (_e *MockRichText_Expecter) Link(text interface{}, url interface{}) *MockRichText_Link_Call {
+	return &MockRichText_Link_Call{Call: _e.mock.On("Link", text, url)}
+}
+
+func (_c *MockRichText_Link_Call) Run(run func(text string, url string)) *MockRichText_Link_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Link_Call) Return(_a0 messages.RichText) *MockRichText_Link_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Link_Call) RunAndReturn(run func(string, string) messages.RichText) *MockRichText_Link_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Plain provides a mock function with given fields: text
+func (_m *MockRichText) Plain(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Plain")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Plain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Plain'
+type MockRichText_Plain_Call struct {
+	*mock.Call
+}
+
+// Plain is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Plain(text interface{}) *MockRichText_Plain_Call {
+	return &MockRichText_Plain_Call{Call: _e.mock.On("Plain", text)}
+}
+
+func (_c *MockRichText_Plain_Call) Run(run func(text string)) *MockRichText_Plain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Plain_Call) Return(_a0 messages.RichText) *MockRichText_Plain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Plain_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Plain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderJSON provides a mock function with given fields:
+func (_m *MockRichText) RenderJSON() map[string]interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderJSON")
+	}
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func() map[string]interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_RenderJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderJSON'
+type MockRichText_RenderJSON_Call struct {
+	*mock.Call
+}
+
+// RenderJSON is a helper method to define mock.On call
+func (_e *MockRichText_Expecter) RenderJSON() *MockRichText_RenderJSON_Call {
+	return &MockRichText_RenderJSON_Call{Call: _e.mock.On("RenderJSON")}
+}
+
+func (_c *MockRichText_RenderJSON_Call) Run(run func()) *MockRichText_RenderJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRichText_RenderJSON_Call) Return(_a0 map[string]interface{}) *MockRichText_RenderJSON_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_RenderJSON_Call) RunAndReturn(run func() map[string]interface{}) *MockRichText_RenderJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderTerminal provides a mock function with given fields:
+func (_m *MockRichText) RenderTerminal() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderTerminal")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockRichText_RenderTerminal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderTerminal'
+type MockRichText_RenderTerminal_Call struct {
+	*mock.Call
+}
+
+// RenderTerminal is a helper method to define mock.On call
+func (_e *MockRichText_Expecter) RenderTerminal() *MockRichText_RenderTerminal_Call {
+	return &MockRichText_RenderTerminal_Call{Call: _e.mock.On("RenderTerminal")}
+}
+
+func (_c *MockRichText_RenderTerminal_Call) Run(run func()) *MockRichText_RenderTerminal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRichText_RenderTerminal_Call) Return(_a0 string) *MockRichText_RenderTerminal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_RenderTerminal_Call) RunAndReturn(run func() string) *MockRichText_RenderTerminal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Underline provides a mock function with given fields: text
+func (_m *MockRichText) Underline(text string) messages.RichText {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Underline")
+	}
+
+	var r0 messages.RichText
+	if rf, ok := ret.Get(0).(func(string) messages.RichText); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(messages.RichText)
+		}
+	}
+
+	return r0
+}
+
+// MockRichText_Underline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Underline'
+type MockRichText_Underline_Call struct {
+	*mock.Call
+}
+
+// Underline is a helper method to define mock.On call
+//   - text string
+func (_e *MockRichText_Expecter) Underline(text interface{}) *MockRichText_Underline_Call {
+	return &MockRichText_Underline_Call{Call: _e.mock.On("Underline", text)}
+}
+
+func (_c *MockRichText_Underline_Call) Run(run func(text string)) *MockRichText_Underline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRichText_Underline_Call) Return(_a0 messages.RichText) *MockRichText_Underline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRichText_Underline_Call) RunAndReturn(run func(string) messages.RichText) *MockRichText_Underline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRichText creates a new instance of MockRichText. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRichText(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRichText {
+	mock := &MockRichText{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+type panicMessage struct {
+	value interface{}
+	stack []byte
+
+	quicklog.Message
+}
+
+func (message *panicMessage) RenderTerminal() string {
+	return message.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (message *panicMessage) RenderTerminalWidth(width int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15")).Background(lipgloss.Color("52")).Bold(true).
+		Width(width)
+	stackStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15")).Faint(true).
+		Width(width)
+
+	output := titleStyle.Render(fmt.Sprintf("panic: %v", message.value)) + "\n"
+
+	// Panics with an error value benefit from the rendering of error chains.
+	if err, ok := message.value.(error); ok {
+		if root, _ := buildErrorNode(err); root.hasDetails() {
+			output += quicklog.RenderTerminalWidth(NewError(err, ""), width)
+		}
+	}
+
+	if stack := strings.TrimSpace(string(message.stack)); stack != "" {
+		output += stackStyle.Render(stack) + "\n"
+	}
+
+	return output
+}
+
+func (message *panicMessage) RenderJSON() map[string]interface{} {
+	output := map[string]interface{}{
+		"message": fmt.Sprintf("panic: %v", message.value),
+		"panic":   fmt.Sprint(message.value),
+	}
+
+	if err, ok := message.value.(error); ok {
+		output["error"] = NewError(err, "").RenderJSON()["error"]
+	}
+
+	if len(message.stack) > 0 {
+		output["stack"] = string(message.stack)
+	}
+
+	return quicklog.RenderKindJSON(KindPanic, output)
+}
+
+func (message *panicMessage) RenderMarkdown() string {
+	content := "**" + escapeMarkdown(fmt.Sprintf("panic: %v", message.value)) + "**\n\n"
+
+	if err, ok := message.value.(error); ok {
+		if root, _ := buildErrorNode(err); root.hasDetails() {
+			content += renderErrorMarkdown(err)
+		}
+	}
+
+	if stack := strings.TrimSpace(string(message.stack)); stack != "" {
+		content += markdownDetails("Stack trace", markdownCodeBlock("", stack))
+	}
+
+	return markdownCallout("CAUTION", content)
+}
+
+func (message *panicMessage) RenderHTML() string {
+	content := htmlParagraph("quicklog-error-message", fmt.Sprintf("panic: %v", message.value))
+
+	if err, ok := message.value.(error); ok {
+		if root, _ := buildErrorNode(err); root.hasDetails() {
+			content += renderErrorHTML(err)
+		}
+	}
+
+	if stack := strings.TrimSpace(string(message.stack)); stack != "" {
+		content += htmlDetails("Stack trace", "<pre>"+html.EscapeString(stack)+"</pre>")
+	}
+
+	return `<div class="quicklog-error quicklog-panic">` + "\n" + content + "</div>\n"
+}
+
+// NewPanic creates a message that renders a recovered panic value, along with the stack of the goroutine that
+// panicked (as returned by runtime/debug.Stack).
+func NewPanic(value interface{}, stack []byte) quicklog.Message {
+	return &panicMessage{
+		value: value,
+		stack: stack,
+	}
+}
+
+func init() {
+	quicklog.NewPanicMessage = NewPanic
+}
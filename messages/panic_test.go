@@ -0,0 +1,154 @@
+package messages_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+var dummyStack = []byte("goroutine 1 [running]:\nmain.main()\n\t/app/main.go:12 +0x1d\n")
+
+func TestPanicTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		value interface{}
+		stack []byte
+
+		expect string
+	}{
+		{
+			name: "Value",
+
+			value: "something went wrong",
+			stack: dummyStack,
+
+			expect: "panic: something went wrong                                                     \n" +
+				"goroutine 1 [running]:                                                          \n" +
+				"main.main()                                                                     \n" +
+				"    /app/main.go:12 +0x1d                                                       \n",
+		},
+		{
+			name: "NoStack",
+
+			value: 42,
+
+			expect: "panic: 42                                                                       \n",
+		},
+		{
+			name: "WrappedError",
+
+			value: fmt.Errorf("outer: %w", errors.New("inner")),
+
+			expect: "panic: outer: inner                                                             \n" +
+				"outer                                                                           \n" +
+				"└── inner                                                                       \n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewPanic(testCase.value, testCase.stack)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestPanicJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		value interface{}
+		stack []byte
+
+		expect map[string]interface{}
+	}{
+		{
+			name: "Value",
+
+			value: "something went wrong",
+			stack: dummyStack,
+
+			expect: map[string]interface{}{
+				"kind":           "panic",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "panic: something went wrong",
+				"panic":          "something went wrong",
+				"stack":          string(dummyStack),
+			},
+		},
+		{
+			name: "Error",
+
+			value: errors.New("something went wrong"),
+
+			expect: map[string]interface{}{
+				"kind":           "panic",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "panic: something went wrong",
+				"panic":          "something went wrong",
+				"error": map[string]interface{}{
+					"type": "*errors.errorString",
+					"chain": []map[string]interface{}{
+						{"message": "something went wrong", "type": "*errors.errorString"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewPanic(testCase.value, testCase.stack)
+			require.Equal(t, testCase.expect, message.RenderJSON())
+		})
+	}
+}
+
+func TestPanicRegistered(t *testing.T) {
+	message := quicklog.NewPanicMessage("something went wrong", dummyStack)
+	require.Equal(t, messages.NewPanic("something went wrong", dummyStack), message)
+}
+
+func TestPanicMarkdown(t *testing.T) {
+	message := messages.NewPanic(fmt.Errorf("outer: %w", errors.New("inner")), dummyStack)
+
+	require.Equal(
+		t,
+		"> [!CAUTION]\n"+
+			"> **panic: outer: inner**\n"+
+			">\n"+
+			"> - outer\n"+
+			">   - inner\n"+
+			">\n"+
+			"> <details><summary>Stack trace</summary>\n"+
+			">\n"+
+			"> ```\n"+
+			"> goroutine 1 [running]:\n"+
+			"> main.main()\n"+
+			"> \t/app/main.go:12 +0x1d\n"+
+			"> ```\n"+
+			">\n"+
+			"> </details>\n\n",
+		quicklog.RenderMarkdown(message),
+	)
+}
+
+func TestPanicHTML(t *testing.T) {
+	message := messages.NewPanic("something <went> wrong", dummyStack)
+
+	require.Equal(
+		t,
+		"<div class=\"quicklog-error quicklog-panic\">\n"+
+			"<p class=\"quicklog-error-message\">panic: something &lt;went&gt; wrong</p>\n"+
+			"<details><summary>Stack trace</summary><pre>goroutine 1 [running]:\nmain.main()\n"+
+			"\t/app/main.go:12 +0x1d</pre></details>\n"+
+			"</div>\n",
+		quicklog.RenderHTML(message),
+	)
+}
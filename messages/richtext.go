@@ -0,0 +1,478 @@
+package messages
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// RichText is a paragraph made of styled spans. It is built by chaining calls, each one appending a span to the
+// text:
+//
+//	messages.Text().Plain("Deployed ").Bold(name).Dim(" in 3s")
+//
+// Builders are immutable: each call returns a new text, and leaves the original one untouched.
+type RichText interface {
+	quicklog.Message
+
+	// Plain appends unstyled text.
+	Plain(text string) RichText
+	// Bold appends emphasized text.
+	Bold(text string) RichText
+	// Dim appends text of lesser importance.
+	Dim(text string) RichText
+	// Italic appends italic text.
+	Italic(text string) RichText
+	// Underline appends underlined text.
+	Underline(text string) RichText
+	// Code appends an inline snippet of code, such as a command or a file path.
+	Code(text string) RichText
+	// Color appends text in the given color. The color is either an ANSI code ("9") or a hex value ("#ff0000").
+	Color(text, color string) RichText
+	// Link appends a link to a URL (see NewLink).
+	Link(text, url string) RichText
+}
+
+type richTextStyle string
+
+const (
+	richTextPlain     richTextStyle = "plain"
+	richTextBold      richTextStyle = "bold"
+	richTextDim       richTextStyle = "dim"
+	richTextItalic    richTextStyle = "italic"
+	richTextUnderline richTextStyle = "underline"
+	richTextCode      richTextStyle = "code"
+	richTextColor     richTextStyle = "color"
+	richTextLink      richTextStyle = "link"
+)
+
+var richTextStyles = map[richTextStyle]lipgloss.Style{
+	richTextPlain:     lipgloss.NewStyle().Foreground(lipgloss.Color("15")),
+	richTextBold:      lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true),
+	richTextDim:       lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true),
+	richTextItalic:    lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Italic(true),
+	richTextUnderline: lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Underline(true),
+	richTextCode:      lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+	richTextLink:      linkStyle,
+}
+
+type richTextSpan struct {
+	text  string
+	style richTextStyle
+	// Only set for richTextColor spans.
+	color string
+	// Only set for richTextLink spans.
+	url string
+}
+
+func (span richTextSpan) render(text string) string {
+	if span.style == richTextColor {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(span.color)).Render(text)
+	}
+
+	rendered := richTextStyles[span.style].Render(text)
+	if span.style == richTextLink && quicklog.Hyperlinks {
+		return ansi.SetHyperlink(span.url) + rendered + ansi.ResetHyperlink()
+	}
+
+	return rendered
+}
+
+// richTextFragment is a part of a span, that fits on a single line.
+type richTextFragment struct {
+	text string
+	span int
+}
+
+type richTextMessage struct {
+	spans []richTextSpan
+
+	quicklog.Message
+}
+
+func (text *richTextMessage) append(span richTextSpan) RichText {
+	if span.text == "" {
+		return text
+	}
+
+	return &richTextMessage{spans: append(append([]richTextSpan{}, text.spans...), span)}
+}
+
+func (text *richTextMessage) Plain(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextPlain})
+}
+
+func (text *richTextMessage) Bold(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextBold})
+}
+
+func (text *richTextMessage) Dim(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextDim})
+}
+
+func (text *richTextMessage) Italic(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextItalic})
+}
+
+func (text *richTextMessage) Underline(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextUnderline})
+}
+
+func (text *richTextMessage) Code(value string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextCode})
+}
+
+func (text *richTextMessage) Color(value, color string) RichText {
+	return text.append(richTextSpan{text: value, style: richTextColor, color: color})
+}
+
+func (text *richTextMessage) Link(value, url string) RichText {
+	// Links are displayed with their URL when hyperlinks are not supported, so the URL is part of the span.
+	return text.append(richTextSpan{text: lo.CoalesceOrEmpty(value, url), style: richTextLink, url: url})
+}
+
+// String returns the text, without styles.
+func (text *richTextMessage) String() string {
+	var output string
+
+	for _, span := range text.spans {
+		output += span.text
+	}
+
+	return output
+}
+
+// Return the text of each span, as displayed in the terminal.
+func (text *richTextMessage) terminalSpans() []richTextSpan {
+	return lo.Map(text.spans, func(span richTextSpan, _ int) richTextSpan {
+		if span.style == richTextLink && !quicklog.Hyperlinks {
+			span.text = renderLinkTerminal(span.text, span.url)
+		}
+
+		return span
+	})
+}
+
+// Split the spans in words, separators and line breaks. A word may be made of fragments from multiple spans, for
+// example when punctuation follows a bold word.
+func splitRichTextWords(spans []richTextSpan) [][]richTextFragment {
+	var (
+		words   [][]richTextFragment
+		current []richTextFragment
+		// Type of the current word: 's' for separators, 'w' for words.
+		currentKind rune
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, current)
+		}
+
+		current = nil
+	}
+
+	for i, span := range spans {
+		for _, char := range span.text {
+			kind := lo.Ternary(unicode.IsSpace(char), 's', 'w')
+
+			// Line breaks are words on their own.
+			if char == '\n' {
+				flush()
+
+				words = append(words, []richTextFragment{{text: "\n", span: i}})
+				currentKind = 0
+
+				continue
+			}
+
+			if kind != currentKind {
+				flush()
+			}
+
+			currentKind = kind
+
+			if len(current) > 0 && current[len(current)-1].span == i {
+				current[len(current)-1].text += string(char)
+			} else {
+				current = append(current, richTextFragment{text: string(char), span: i})
+			}
+		}
+	}
+
+	flush()
+
+	return words
+}
+
+func fragmentsWidth(fragments []richTextFragment) int {
+	return lo.SumBy(fragments, func(fragment richTextFragment) int {
+		return ansi.StringWidth(fragment.text)
+	})
+}
+
+// Split a word that is wider than a line, in chunks of at most width columns.
+func breakRichTextWord(word []richTextFragment, width int) [][]richTextFragment {
+	var (
+		chunks       [][]richTextFragment
+		current      []richTextFragment
+		currentWidth int
+	)
+
+	for _, fragment := range word {
+		for _, char := range fragment.text {
+			charWidth := ansi.StringWidth(string(char))
+			if currentWidth+charWidth > width && currentWidth > 0 {
+				chunks = append(chunks, current)
+				current, currentWidth = nil, 0
+			}
+
+			if len(current) > 0 && current[len(current)-1].span == fragment.span {
+				current[len(current)-1].text += string(char)
+			} else {
+				current = append(current, richTextFragment{text: string(char), span: fragment.span})
+			}
+
+			currentWidth += charWidth
+		}
+	}
+
+	return append(chunks, current)
+}
+
+// Wrap the words in lines of at most width columns. Separators are dropped where lines are wrapped.
+func wrapRichTextWords(words [][]richTextFragment, width int) [][]richTextFragment {
+	var (
+		lines        [][]richTextFragment
+		current      []richTextFragment
+		currentWidth int
+		// Separator waiting for the next word, to be dropped if the line wraps.
+		separator []richTextFragment
+		// Separators are kept at the beginning of a paragraph, for indentation.
+		lineStart = true
+	)
+
+	flush := func() {
+		lines = append(lines, current)
+		current, currentWidth, separator = nil, 0, nil
+	}
+
+	for _, word := range words {
+		if word[0].text == "\n" {
+			flush()
+
+			lineStart = true
+
+			continue
+		}
+
+		if unicode.IsSpace([]rune(word[0].text)[0]) {
+			if lineStart {
+				current = append(current, word...)
+				currentWidth += fragmentsWidth(word)
+			} else {
+				separator = word
+			}
+
+			continue
+		}
+
+		lineStart = false
+		wordWidth := fragmentsWidth(word)
+		separatorWidth := fragmentsWidth(separator)
+
+		if currentWidth+separatorWidth+wordWidth <= width {
+			current = append(append(current, separator...), word...)
+			currentWidth += separatorWidth + wordWidth
+			separator = nil
+
+			continue
+		}
+
+		if currentWidth > 0 {
+			flush()
+		}
+
+		separator = nil
+
+		chunks := breakRichTextWord(word, width)
+		for _, chunk := range chunks[:len(chunks)-1] {
+			current = chunk
+			flush()
+		}
+
+		current = chunks[len(chunks)-1]
+		currentWidth = fragmentsWidth(current)
+	}
+
+	if len(current) > 0 {
+		flush()
+	}
+
+	return lines
+}
+
+func (text *richTextMessage) RenderTerminal() string {
+	return text.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+// RenderTerminalWidth wraps the text across its spans, and styles each line separately, so styles and hyperlinks
+// never span multiple lines.
+func (text *richTextMessage) RenderTerminalWidth(width int) string {
+	if len(text.spans) == 0 {
+		return ""
+	}
+
+	spans := text.terminalSpans()
+	width = lo.Max([]int{1, width})
+
+	var output string
+
+	for _, line := range wrapRichTextWords(splitRichTextWords(spans), width) {
+		for _, fragment := range line {
+			output += spans[fragment.span].render(fragment.text)
+		}
+
+		output += strings.Repeat(" ", lo.Max([]int{0, width - fragmentsWidth(line)})) + "\n"
+	}
+
+	return output
+}
+
+func (text *richTextMessage) RenderJSON() map[string]interface{} {
+	if len(text.spans) == 0 {
+		return nil
+	}
+
+	output := map[string]interface{}{
+		"message": text.String(),
+	}
+
+	// Spans are only listed when the text has styles.
+	if lo.EveryBy(text.spans, func(span richTextSpan) bool { return span.style == richTextPlain }) {
+		return quicklog.RenderKindJSON(KindText, output)
+	}
+
+	output["spans"] = lo.Map(text.spans, func(span richTextSpan, _ int) map[string]interface{} {
+		renderedSpan := map[string]interface{}{
+			"text":  span.text,
+			"style": string(span.style),
+		}
+
+		if span.color != "" {
+			renderedSpan["color"] = span.color
+		}
+
+		if span.url != "" {
+			renderedSpan["url"] = span.url
+		}
+
+		return renderedSpan
+	})
+
+	return quicklog.RenderKindJSON(KindText, output)
+}
+
+// Markdown emphasis must not start or end with a space: keep the surrounding spaces outside the markers.
+func wrapMarkdownEmphasis(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	start := strings.Index(text, trimmed)
+
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+// Render inline code. Code is not escaped in Markdown: its fence is longer than any run of backticks in the code, so
+// it is not closed early. Code that starts or ends with a backtick is padded with a space, that CommonMark removes.
+func renderMarkdownCode(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	longest, run := 0, 0
+
+	for _, char := range trimmed {
+		run = lo.Ternary(char == '`', run+1, 0)
+		longest = max(longest, run)
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	padding := lo.Ternary(strings.HasPrefix(trimmed, "`") || strings.HasSuffix(trimmed, "`"), " ", "")
+	start := strings.Index(text, trimmed)
+
+	return text[:start] + fence + padding + trimmed + padding + fence + text[start+len(trimmed):]
+}
+
+func (text *richTextMessage) RenderMarkdown() string {
+	if len(text.spans) == 0 {
+		return ""
+	}
+
+	var output string
+
+	for _, span := range text.spans {
+		switch span.style {
+		case richTextBold:
+			output += wrapMarkdownEmphasis(escapeMarkdown(span.text), "**")
+		case richTextItalic:
+			output += wrapMarkdownEmphasis(escapeMarkdown(span.text), "*")
+		case richTextCode:
+			output += renderMarkdownCode(span.text)
+		case richTextLink:
+			output += renderLinkMarkdown(span.text, span.url)
+		default:
+			output += escapeMarkdown(span.text)
+		}
+	}
+
+	return strings.ReplaceAll(output, "\n", "\\\n") + "\n\n"
+}
+
+func (text *richTextMessage) RenderHTML() string {
+	if len(text.spans) == 0 {
+		return ""
+	}
+
+	var output string
+
+	for _, span := range text.spans {
+		content := html.EscapeString(span.text)
+
+		switch span.style {
+		case richTextPlain:
+			output += content
+		case richTextBold:
+			output += "<strong>" + content + "</strong>"
+		case richTextItalic:
+			output += "<em>" + content + "</em>"
+		case richTextUnderline:
+			output += "<u>" + content + "</u>"
+		case richTextCode:
+			output += "<code>" + content + "</code>"
+		case richTextLink:
+			output += renderLinkHTML(span.text, span.url)
+		case richTextColor:
+			output += `<span data-color="` + html.EscapeString(span.color) + `">` + content + "</span>"
+		case richTextDim:
+			output += `<span class="quicklog-dim">` + content + "</span>"
+		}
+	}
+
+	return `<p class="quicklog-text">` + strings.ReplaceAll(output, "\n", "<br>\n") + "</p>\n"
+}
+
+// Text creates an empty rich text, to be completed by chaining spans. The text wraps across its spans, and is
+// rendered as plain text, along with the list of its spans, in JSON. Terminals without colors support, and the
+// String method, render the text without styles.
+func Text() RichText {
+	return &richTextMessage{}
+}
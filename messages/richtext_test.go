@@ -0,0 +1,232 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func TestRichTextTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		text       quicklog.Message
+		width      int
+		hyperlinks bool
+
+		expect string
+	}{
+		{
+			name: "Spans",
+
+			text:  messages.Text().Plain("Deployed ").Bold("api").Dim(" in 3s"),
+			width: 20,
+
+			expect: "Deployed api in 3s  \n",
+		},
+		{
+			// Words are wrapped across spans, and punctuation stays attached to the word it follows.
+			name: "Wrapped",
+
+			text: messages.Text().
+				Plain("Deployed ").Bold("quicklog-api").Plain(", then ").Code("cmd/demo").Dim(" in 3s"),
+			width: 14,
+
+			expect: "Deployed      \n" +
+				"quicklog-api, \n" +
+				"then cmd/demo \n" +
+				"in 3s         \n",
+		},
+		{
+			name: "LongWord",
+
+			text:  messages.Text().Plain("Path: ").Code("/very/long/path/to/file"),
+			width: 10,
+
+			expect: "Path:     \n" +
+				"/very/long\n" +
+				"/path/to/f\n" +
+				"ile       \n",
+		},
+		{
+			name: "LineBreaks",
+
+			text:  messages.Text().Plain("First line\n").Bold("  indented"),
+			width: 12,
+
+			expect: "First line  \n" +
+				"  indented  \n",
+		},
+		{
+			name: "LinkFallback",
+
+			text:  messages.Text().Plain("See ").Link("logs", "https://logs.io"),
+			width: 30,
+
+			expect: "See logs (https://logs.io)    \n",
+		},
+		{
+			name: "Hyperlink",
+
+			text:       messages.Text().Plain("See ").Link("the logs", "https://logs.io"),
+			width:      8,
+			hyperlinks: true,
+
+			expect: "See \x1b]8;;https://logs.io\x07the\x1b]8;;\x07 \n" +
+				"\x1b]8;;https://logs.io\x07logs\x1b]8;;\x07    \n",
+		},
+		{
+			name: "Empty",
+
+			text:  messages.Text(),
+			width: 10,
+
+			expect: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			withHyperlinks(t, testCase.hyperlinks)
+
+			require.Equal(t, testCase.expect, quicklog.RenderTerminalWidth(testCase.text, testCase.width))
+		})
+	}
+}
+
+func TestRichTextStyles(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	text := messages.Text().Plain("Deployed ").Bold("quicklog").Dim(" in 3s")
+
+	// Each line is styled on its own, so styles do not leak on the next line.
+	require.Equal(
+		t,
+		"\x1b[97mDeployed\x1b[0m   \n"+
+			"\x1b[1;97mquicklog\x1b[0m\x1b[2;97m \x1b[0m\x1b[2;97min\x1b[0m\n"+
+			"\x1b[2;97m3s\x1b[0m         \n",
+		quicklog.RenderTerminalWidth(text, 11),
+	)
+}
+
+func TestRichTextImmutable(t *testing.T) {
+	base := messages.Text().Plain("Deployed ")
+
+	first := base.Bold("api")
+	second := base.Bold("worker")
+
+	require.Equal(t, "Deployed ", base.RenderJSON()["message"])
+	require.Equal(t, "Deployed api", first.RenderJSON()["message"])
+	require.Equal(t, "Deployed worker", second.RenderJSON()["message"])
+}
+
+func TestRichTextJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		text quicklog.Message
+
+		expect map[string]interface{}
+	}{
+		{
+			name: "Spans",
+
+			text: messages.Text().
+				Plain("Deployed ").Bold("api").Color(" to prod", "9").Link(" (logs)", "https://logs.io"),
+
+			expect: map[string]interface{}{
+				"kind":           "text",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Deployed api to prod (logs)",
+				"spans": []map[string]interface{}{
+					{"text": "Deployed ", "style": "plain"},
+					{"text": "api", "style": "bold"},
+					{"text": " to prod", "style": "color", "color": "9"},
+					{"text": " (logs)", "style": "link", "url": "https://logs.io"},
+				},
+			},
+		},
+		{
+			name: "PlainOnly",
+
+			text: messages.Text().Plain("Deployed ").Plain("api"),
+
+			expect: map[string]interface{}{
+				"kind":           "text",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Deployed api",
+			},
+		},
+		{
+			name: "Empty",
+
+			text: messages.Text().Plain(""),
+
+			expect: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, testCase.text.RenderJSON())
+		})
+	}
+}
+
+func TestRichTextMarkdown(t *testing.T) {
+	text := messages.Text().
+		Plain("Deployed ").Bold("api ").Italic("v1.2").Plain(" with ").Code("make deploy").Dim("\n").
+		Link("logs", "https://logs.io")
+
+	require.Equal(
+		t,
+		"Deployed **api** *v1.2* with `make deploy`\\\n[logs](https://logs.io)\n\n",
+		quicklog.RenderMarkdown(text),
+	)
+	require.Equal(
+		t,
+		`<p class="quicklog-text">Deployed <strong>api </strong><em>v1.2</em> with <code>make deploy</code>`+
+			`<span class="quicklog-dim"><br>`+"\n"+`</span><a href="https://logs.io">logs</a></p>`+"\n",
+		quicklog.RenderHTML(text),
+	)
+}
+
+func TestRichTextMarkdownCode(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		code   string
+		expect string
+	}{
+		{name: "Plain", code: "make deploy", expect: "`make deploy`"},
+		{name: "Backtick", code: "echo `date`", expect: "`` echo `date` ``"},
+		{name: "BacktickRun", code: "a``b", expect: "```a``b```"},
+		{name: "SurroundingSpaces", code: " go ", expect: " `go` "},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			text := messages.Text().Code(testCase.code).Plain(" done")
+			require.Equal(t, testCase.expect+" done\n\n", quicklog.RenderMarkdown(text))
+		})
+	}
+}
+
+func TestRichTextUnsafeLink(t *testing.T) {
+	text := messages.Text().
+		Link("Click me", "javascript:alert(1)").Plain(" or ").Link("the wiki", "https://example.com/a_(b)")
+
+	// Links to unsafe URLs are rendered as plain text.
+	require.Equal(t, "Click me or [the wiki](https://example.com/a_%28b%29)\n\n", quicklog.RenderMarkdown(text))
+	require.Equal(
+		t,
+		`<p class="quicklog-text">Click me or <a href="https://example.com/a_(b)">the wiki</a></p>`+"\n",
+		quicklog.RenderHTML(text),
+	)
+}
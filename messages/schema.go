@@ -0,0 +1,307 @@
+package messages
+
+import (
+	"encoding/json"
+
+	"github.com/samber/lo"
+)
+
+//go:generate go -C .. run ./cmd/schema -o schema/quicklog.schema.json
+
+// Kinds of the messages of this package, as set under the quicklog.KindKey key of their JSON rendering.
+const (
+	KindBase    = "base"
+	KindTitle   = "title"
+	KindError   = "error"
+	KindPanic   = "panic"
+	KindLoader  = "loader"
+	KindCode    = "code"
+	KindDiff    = "diff"
+	KindMapDiff = "map_diff"
+	KindLink    = "link"
+	KindText    = "text"
+	KindGroup   = "group"
+	KindTree    = "tree"
+)
+
+// ==============================================================================================================
+// JSON types.
+// ==============================================================================================================
+
+// The types below describe the JSON rendering of each kind of message. They are used to decode renderings, and to
+// generate the published JSON Schema: any change to a rendering must be reflected here.
+
+// MessageJSON is the JSON rendering of a message of any kind.
+type MessageJSON map[string]interface{}
+
+// ChildrenJSON holds the children of a message. It is rendered as a single message when there is only one child,
+// and as a list otherwise.
+type ChildrenJSON []MessageJSON
+
+func (children ChildrenJSON) MarshalJSON() ([]byte, error) {
+	if len(children) == 1 {
+		return json.Marshal(children[0])
+	}
+
+	return json.Marshal([]MessageJSON(children))
+}
+
+func (children *ChildrenJSON) UnmarshalJSON(data []byte) error {
+	var single MessageJSON
+	if err := json.Unmarshal(data, &single); err == nil {
+		*children = lo.Ternary(single == nil, nil, ChildrenJSON{single})
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]MessageJSON)(children))
+}
+
+// MetaJSON holds the keys shared by the renderings of every kind of message.
+type MetaJSON struct {
+	// SchemaVersion is the version of the schema the rendering complies with. It is omitted by nested messages.
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// TraceID is the OpenTelemetry trace the message was logged in, if any.
+	TraceID string `json:"trace_id,omitempty"`
+	// SpanID is the OpenTelemetry span the message was logged in, if any.
+	SpanID string `json:"span_id,omitempty"`
+}
+
+// InlineLinkJSON is a link written inline in a text, with the [text](url) syntax.
+type InlineLinkJSON struct {
+	// Text of the link, as it appears in the message.
+	Text string `json:"text"`
+	// URL the link points to.
+	URL string `json:"url"`
+	// Field is the key of the text the link appears in, such as "message".
+	Field string `json:"field"`
+	// Offset is the position of the link in its text, in bytes.
+	Offset int `json:"offset"`
+}
+
+// BaseJSON is the rendering of NewBase.
+type BaseJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=base"`
+	MetaJSON
+
+	// Message is the text of the message, without the syntax of its links.
+	Message string `json:"message"`
+	// Links of the message, in order of appearance.
+	Links []InlineLinkJSON `json:"links,omitempty"`
+	// Data holds the children of the message.
+	Data ChildrenJSON `json:"data,omitempty"`
+}
+
+// TitleJSON is the rendering of NewTitle.
+type TitleJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=title"`
+	MetaJSON
+
+	// Message is the title, without the syntax of its links.
+	Message string `json:"message"`
+	// Content is the description under the title, without the syntax of its links.
+	Content string `json:"content,omitempty"`
+	// Links of the title and its description, in order of appearance.
+	Links []InlineLinkJSON `json:"links,omitempty"`
+	// Data holds the children of the message.
+	Data ChildrenJSON `json:"data,omitempty"`
+}
+
+// StackFrameJSON is a single frame of a stack trace.
+type StackFrameJSON struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// ErrorChainEntryJSON is a single error of a chain, from the outermost to the innermost.
+type ErrorChainEntryJSON struct {
+	// Message of the error, including the messages of the errors it wraps.
+	Message string `json:"message"`
+	// Type is the Go type of the error.
+	Type string `json:"type"`
+	// Fields attached to the error.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Errors holds the chain of each cause of a joined error. A joined error ends its chain.
+	Errors [][]ErrorChainEntryJSON `json:"errors,omitempty"`
+}
+
+// ErrorDetailsJSON describes an error and the errors it wraps.
+type ErrorDetailsJSON struct {
+	// Type is the Go type of the outermost error.
+	Type string `json:"type"`
+	// Chain of errors, from the outermost to the innermost.
+	Chain []ErrorChainEntryJSON `json:"chain"`
+	// Stack is the deepest stack trace attached to an error of the chain.
+	Stack []StackFrameJSON `json:"stack,omitempty"`
+}
+
+// ErrorJSON is the rendering of NewError.
+type ErrorJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=error"`
+	MetaJSON
+
+	// Message describes the error. It is the message of the error itself if none was provided.
+	Message string `json:"message"`
+	// Error details the error, if any.
+	Error *ErrorDetailsJSON `json:"error,omitempty"`
+}
+
+// PanicJSON is the rendering of NewPanic.
+type PanicJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=panic"`
+	MetaJSON
+
+	Message string `json:"message"`
+	// Panic is the value the program panicked with.
+	Panic string `json:"panic"`
+	// Error details the value, if it is an error.
+	Error *ErrorDetailsJSON `json:"error,omitempty"`
+	// Stack is the stack trace of the panic, as printed by the runtime.
+	Stack string `json:"stack,omitempty"`
+}
+
+// LoaderJSON is a frame of NewLoader.
+type LoaderJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=loader"`
+	MetaJSON
+
+	// Message is the current step of the loader.
+	Message string `json:"message"`
+	// Elapsed is the time since the loader started, in the format of time.Duration.
+	Elapsed string `json:"elapsed"`
+	// ElapsedNanos is the time since the loader started, in nanoseconds.
+	ElapsedNanos int64 `json:"elapsed_nanos"`
+	// OpID is shared by every frame of a loader.
+	OpID string `json:"op_id"`
+	// Status of the loader. Only running loaders may send more frames.
+	Status string `json:"status" jsonschema:"enum=running,enum=success,enum=error"`
+	// Data is the message nested under the loader.
+	Data MessageJSON `json:"data,omitempty"`
+}
+
+// CodeJSON is the rendering of NewCode.
+type CodeJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=code"`
+	MetaJSON
+
+	// Code is the source, without highlighting.
+	Code string `json:"code"`
+	// Language of the source, if known.
+	Language string `json:"language,omitempty"`
+}
+
+// DiffHunkJSON is a group of changes, surrounded by unchanged lines for context. Line numbers start at 1.
+type DiffHunkJSON struct {
+	OldStart int `json:"old_start"`
+	OldLines int `json:"old_lines"`
+	NewStart int `json:"new_start"`
+	NewLines int `json:"new_lines"`
+	// Lines of the hunk, prefixed with "+" when added, "-" when removed, and a space when unchanged.
+	Lines []string `json:"lines"`
+}
+
+// DiffJSON is the rendering of NewDiff.
+type DiffJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=diff"`
+	MetaJSON
+
+	Hunks []DiffHunkJSON `json:"hunks"`
+	// Before is the label of the original text.
+	Before string `json:"before,omitempty"`
+	// After is the label of the new text.
+	After string `json:"after,omitempty"`
+}
+
+// MapChangeJSON is the previous and new value of a changed key.
+type MapChangeJSON struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// MapDiffJSON is the rendering of NewMapDiff. Keys of nested maps are joined with dots.
+type MapDiffJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=map_diff"`
+	MetaJSON
+
+	Added   map[string]interface{}   `json:"added"`
+	Removed map[string]interface{}   `json:"removed"`
+	Changed map[string]MapChangeJSON `json:"changed"`
+	// Before is the label of the original map.
+	Before string `json:"before,omitempty"`
+	// After is the label of the new map.
+	After string `json:"after,omitempty"`
+}
+
+// LinkJSON is the rendering of NewLink.
+type LinkJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=link"`
+	MetaJSON
+
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// TextSpanJSON is a part of a rich text, that shares a single style.
+type TextSpanJSON struct {
+	Text  string `json:"text"`
+	Style string `json:"style" jsonschema:"enum=plain,enum=bold,enum=dim,enum=italic,enum=underline,enum=code,enum=color,enum=link"` //nolint:lll
+	// Color of the span, for the color style.
+	Color string `json:"color,omitempty"`
+	// URL of the span, for the link style.
+	URL string `json:"url,omitempty"`
+}
+
+// TextJSON is the rendering of Text.
+type TextJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=text"`
+	MetaJSON
+
+	// Message is the whole text, without styles.
+	Message string `json:"message"`
+	// Spans of the text. They are only listed when the text has styles.
+	Spans []TextSpanJSON `json:"spans,omitempty"`
+}
+
+// GroupJSON is the rendering of NewGroup. The keys of the header are rendered inline, next to the children.
+type GroupJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=group"`
+	MetaJSON
+
+	// HeaderKind is the kind of the header, if any.
+	HeaderKind string        `json:"header_kind,omitempty"`
+	Children   []MessageJSON `json:"children"`
+}
+
+// TreeNodeJSON is a single element of a tree.
+type TreeNodeJSON struct {
+	Label string `json:"label"`
+	// Detail is the message displayed under the label.
+	Detail   MessageJSON    `json:"detail,omitempty"`
+	Children []TreeNodeJSON `json:"children,omitempty"`
+}
+
+// TreeJSON is the rendering of NewTree.
+type TreeJSON struct {
+	Kind string `json:"kind" jsonschema:"enum=tree"`
+	MetaJSON
+
+	// Message is the label of the root.
+	Message string       `json:"message"`
+	Tree    TreeNodeJSON `json:"tree"`
+}
+
+// KindTypes maps each kind to the type that describes its rendering.
+var KindTypes = map[string]interface{}{
+	KindBase:    BaseJSON{},
+	KindTitle:   TitleJSON{},
+	KindError:   ErrorJSON{},
+	KindPanic:   PanicJSON{},
+	KindLoader:  LoaderJSON{},
+	KindCode:    CodeJSON{},
+	KindDiff:    DiffJSON{},
+	KindMapDiff: MapDiffJSON{},
+	KindLink:    LinkJSON{},
+	KindText:    TextJSON{},
+	KindGroup:   GroupJSON{},
+	KindTree:    TreeJSON{},
+}
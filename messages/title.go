@@ -2,6 +2,7 @@ package messages
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
 
 	"github.com/a-novel-kit/quicklog"
 )
@@ -10,18 +11,19 @@ type titleMessage struct {
 	title       string
 	description string
 
-	child quicklog.Message
+	children []quicklog.Message
 
 	quicklog.Message
 }
 
-func (title *titleMessage) RenderTerminal() string {
+// Render the title in a block, whose content is blockWidth wide. The border takes 1 more column on each side.
+func (title *titleMessage) render(blockWidth, childWidth int) string {
 	if title.title == "" {
 		return ""
 	}
 
 	blockStyle := lipgloss.NewStyle().
-		Width(quicklog.TermWidth).
+		Width(blockWidth).
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("33")).
 		Padding(0, 1)
@@ -29,16 +31,30 @@ func (title *titleMessage) RenderTerminal() string {
 	content := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("33")).
 		Bold(true).
-		Render(title.title)
+		Render(renderLinksTerminal(title.title))
 
 	if title.description != "" {
 		content += "\n" + lipgloss.NewStyle().
 			Foreground(lipgloss.Color("33")).
 			Faint(true).
-			Render(title.description)
+			Render(renderLinksTerminal(title.description))
 	}
 
-	return quicklog.RenderWithChildTerminal(blockStyle.Render(content)+"\n", title.child)
+	rendered := splitHyperlinkLines(blockStyle.Render(content)) + "\n"
+
+	return quicklog.RenderWithChildrenTerminalWidth(rendered, childWidth, title.children...)
+}
+
+func (title *titleMessage) RenderTerminal() string {
+	return title.render(quicklog.TermWidth, quicklog.TermWidth)
+}
+
+func (title *titleMessage) RenderTerminalWidth(width int) string {
+	return title.render(lo.Max([]int{1, width - 2}), width)
+}
+
+func (title *titleMessage) SectionTitle() string {
+	return stripLinks(title.title)
 }
 
 func (title *titleMessage) RenderJSON() map[string]interface{} {
@@ -47,21 +63,58 @@ func (title *titleMessage) RenderJSON() map[string]interface{} {
 	}
 
 	content := map[string]interface{}{
-		"message": title.title,
+		"message": stripLinks(title.title),
+	}
+
+	if title.description != "" {
+		content["content"] = stripLinks(title.description)
 	}
 
+	links := renderLinksJSON(
+		linkedText{key: "message", text: title.title}, linkedText{key: "content", text: title.description},
+	)
+	if len(links) > 0 {
+		content["links"] = links
+	}
+
+	return quicklog.RenderWithChildrenJSON(quicklog.RenderKindJSON(KindTitle, content), title.children...)
+}
+
+func (title *titleMessage) RenderMarkdown() string {
+	if title.title == "" {
+		return ""
+	}
+
+	content := "## " + escapeMarkdownLinks(title.title) + "\n\n"
+
+	if title.description != "" {
+		content += markdownLinksParagraph(title.description)
+	}
+
+	return quicklog.RenderWithChildrenMarkdown(content, title.children...)
+}
+
+func (title *titleMessage) RenderHTML() string {
+	if title.title == "" {
+		return ""
+	}
+
+	content := `<h2 class="quicklog-title">` + escapeHTMLLinks(title.title) + "</h2>\n"
+
 	if title.description != "" {
-		content["content"] = title.description
+		content += htmlLinksParagraph("quicklog-title-description", title.description)
 	}
 
-	return quicklog.RenderWithChildJSON(content, title.child)
+	return quicklog.RenderWithChildrenHTML(content, title.children...)
 }
 
-// NewTitle groups together important logs under a section. Description and child are optional.
-func NewTitle(title string, description string, child quicklog.Message) quicklog.Message {
+// NewTitle groups together important logs under a section. Description and children are optional.
+//
+// The title and description may contain links, using the [text](url) syntax (see NewLink).
+func NewTitle(title string, description string, children ...quicklog.Message) quicklog.Message {
 	return &titleMessage{
 		title:       title,
 		description: description,
-		child:       child,
+		children:    children,
 	}
 }
@@ -81,7 +81,9 @@ func TestTitleJSON(t *testing.T) {
 			title: "Hello, world!",
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
+				"kind":           "title",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
 			},
 		},
 		{
@@ -91,8 +93,10 @@ func TestTitleJSON(t *testing.T) {
 			description: "This is a description.",
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
-				"content": "This is a description.",
+				"kind":           "title",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
+				"content":        "This is a description.",
 			},
 		},
 		{
@@ -102,8 +106,11 @@ func TestTitleJSON(t *testing.T) {
 			child: messages.NewBase("Child message", nil),
 
 			expect: map[string]interface{}{
-				"message": "Hello, world!",
+				"kind":           "title",
+				"schema_version": quicklog.SchemaVersion,
+				"message":        "Hello, world!",
 				"data": map[string]interface{}{
+					"kind":    "base",
 					"message": "Child message",
 				},
 			},
@@ -122,3 +129,72 @@ func TestTitleJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestTitleMarkdown(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		title       string
+		description string
+		child       quicklog.Message
+
+		expectMarkdown string
+		expectHTML     string
+	}{
+		{
+			name: "Title",
+
+			title: "Hello # world",
+
+			expectMarkdown: "## Hello \\# world\n\n",
+			expectHTML:     "<h2 class=\"quicklog-title\">Hello # world</h2>\n",
+		},
+		{
+			name: "NoTitle",
+
+			description: "Description",
+
+			expectMarkdown: "",
+			expectHTML:     "",
+		},
+		{
+			name: "WithDescriptionAndChild",
+
+			title:       "Hello, world!",
+			description: "Description",
+			child:       messages.NewBase("Child message", nil),
+
+			expectMarkdown: "## Hello, world!\n\nDescription\n\nChild message\n\n",
+			expectHTML: "<h2 class=\"quicklog-title\">Hello, world!</h2>\n" +
+				"<p class=\"quicklog-title-description\">Description</p>\n" +
+				"<p class=\"quicklog-base\">Child message</p>\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewTitle(testCase.title, testCase.description, testCase.child)
+			require.Equal(t, testCase.expectMarkdown, quicklog.RenderMarkdown(message))
+			require.Equal(t, testCase.expectHTML, quicklog.RenderHTML(message))
+		})
+	}
+}
+
+func TestTitleChildren(t *testing.T) {
+	message := messages.NewTitle("Hello, world!", "", messages.NewBase("First child"), messages.NewBase("Second child"))
+
+	require.Equal(t, map[string]interface{}{
+		"kind":           "title",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "Hello, world!",
+		"data": []map[string]interface{}{
+			{"kind": "base", "message": "First child"},
+			{"kind": "base", "message": "Second child"},
+		},
+	}, message.RenderJSON())
+	require.Equal(
+		t,
+		"## Hello, world!\n\nFirst child\n\nSecond child\n\n",
+		quicklog.RenderMarkdown(message),
+	)
+}
@@ -0,0 +1,80 @@
+package messages
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Return the identifiers of the span carried by the context, to correlate JSON outputs with traces. It returns nil
+// if the context does not carry a valid span.
+func traceJSON(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"trace_id": spanContext.TraceID().String(),
+		"span_id":  spanContext.SpanID().String(),
+	}
+}
+
+type tracedMessage struct {
+	ctx     context.Context
+	message quicklog.Message
+
+	quicklog.Message
+}
+
+func (traced *tracedMessage) RenderTerminal() string {
+	return traced.message.RenderTerminal()
+}
+
+func (traced *tracedMessage) RenderTerminalWidth(width int) string {
+	return quicklog.RenderTerminalWidth(traced.message, width)
+}
+
+func (traced *tracedMessage) RenderJSON() map[string]interface{} {
+	output := traced.message.RenderJSON()
+	if output == nil {
+		return nil
+	}
+
+	return lo.Assign(output, traceJSON(traced.ctx))
+}
+
+func (traced *tracedMessage) RenderMarkdown() string {
+	return quicklog.RenderMarkdown(traced.message)
+}
+
+func (traced *tracedMessage) RenderHTML() string {
+	return quicklog.RenderHTML(traced.message)
+}
+
+// SectionTitle returns the title of the wrapped message, if it introduces a section.
+func (traced *tracedMessage) SectionTitle() string {
+	if section, ok := traced.message.(quicklog.SectionMessage); ok {
+		return section.SectionTitle()
+	}
+
+	return ""
+}
+
+// NewTraced correlates a message with the OpenTelemetry span carried by the context. The JSON output of the message
+// is enriched with the trace_id and span_id of the span. Other renderings are left unchanged.
+//
+// If the context does not carry a valid span, the message is rendered as is.
+func NewTraced(ctx context.Context, message quicklog.Message) quicklog.Message {
+	return &tracedMessage{
+		ctx:     ctx,
+		message: message,
+	}
+}
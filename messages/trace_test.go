@@ -0,0 +1,72 @@
+package messages_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+// Create a tracer that records its spans in memory, once they end.
+func newTestTracer(t *testing.T) (trace.Tracer, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return provider.Tracer("quicklog-test"), exporter
+}
+
+func TestTraced(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	defer span.End()
+
+	t.Run("JSON", func(t *testing.T) {
+		message := messages.NewTraced(ctx, messages.NewBase("Hello world"))
+
+		require.Equal(t, map[string]interface{}{
+			"kind":           "base",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "Hello world",
+			"trace_id":       span.SpanContext().TraceID().String(),
+			"span_id":        span.SpanContext().SpanID().String(),
+		}, message.RenderJSON())
+	})
+
+	t.Run("NoSpan", func(t *testing.T) {
+		message := messages.NewTraced(context.Background(), messages.NewBase("Hello world"))
+
+		require.Equal(t, map[string]interface{}{
+			"kind":           "base",
+			"schema_version": quicklog.SchemaVersion,
+			"message":        "Hello world",
+		}, message.RenderJSON())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		require.Nil(t, messages.NewTraced(ctx, messages.NewBase("")).RenderJSON())
+	})
+
+	t.Run("OtherRenderings", func(t *testing.T) {
+		inner := messages.NewTitle("Hello world", "description")
+		message := messages.NewTraced(ctx, inner)
+
+		require.Equal(t, inner.RenderTerminal(), message.RenderTerminal())
+		require.Equal(t, quicklog.RenderTerminalWidth(inner, 40), quicklog.RenderTerminalWidth(message, 40))
+		require.Equal(t, quicklog.RenderMarkdown(inner), quicklog.RenderMarkdown(message))
+		require.Equal(t, quicklog.RenderHTML(inner), quicklog.RenderHTML(message))
+		require.Equal(t, "Hello world", message.(quicklog.SectionMessage).SectionTitle())
+	})
+}
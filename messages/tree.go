@@ -0,0 +1,293 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+
+	"github.com/a-novel-kit/quicklog"
+)
+
+// Render a block of text under a tree prefix, within width. The first line starts with prefix, and the following
+// lines start with nextPrefix, that must have the same width, so they are aligned with the text of the first one.
+func renderTreeLines(style lipgloss.Style, text, prefix, nextPrefix string, width int) string {
+	rendered := style.Width(lo.Max([]int{1, width - lipgloss.Width(prefix)})).Render(text)
+
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines {
+		lines[i] = lo.Ternary(i == 0, prefix, nextPrefix) + line
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// TreeNode is a single element of a tree.
+type TreeNode struct {
+	// Label is the text displayed for the node.
+	Label string
+	// Detail is an optional message, displayed under the label.
+	Detail quicklog.Message
+	// Children of the node, displayed in order.
+	Children []TreeNode
+}
+
+// Return the number of nodes under a node, at any depth.
+func (node TreeNode) countDescendants() int {
+	count := len(node.Children)
+	for _, child := range node.Children {
+		count += child.countDescendants()
+	}
+
+	return count
+}
+
+// treeConnectors are the characters used to draw the branches of a tree.
+type treeConnectors struct {
+	// Connectors of a child, depending on whether it is the last one.
+	child, lastChild string
+	// Indentation of the descendants of a child, depending on whether it is the last one.
+	indent, lastIndent string
+}
+
+var (
+	unicodeTreeConnectors = treeConnectors{child: "├── ", lastChild: "└── ", indent: "│   ", lastIndent: "    "}
+	asciiTreeConnectors   = treeConnectors{child: "|-- ", lastChild: "`-- ", indent: "|   ", lastIndent: "    "}
+)
+
+type treeMessage struct {
+	root TreeNode
+
+	config TreeConfig
+
+	quicklog.Message
+}
+
+func (tree *treeMessage) connectors() treeConnectors {
+	return lo.Ternary(tree.config.ASCII, asciiTreeConnectors, unicodeTreeConnectors)
+}
+
+// Render the detail message of a node, aligned under its label.
+func (tree *treeMessage) renderDetail(detail quicklog.Message, indent string, width int) string {
+	rendered := quicklog.RenderTerminalWidth(detail, lo.Max([]int{1, width - lipgloss.Width(indent)}))
+	if rendered == "" {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Render the children of a node, up to the configured limits.
+func (tree *treeMessage) renderChildren(node TreeNode, indent string, depth, width int) string {
+	connectors := tree.connectors()
+	moreStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Faint(true)
+
+	if len(node.Children) == 0 {
+		return ""
+	}
+
+	// Nodes past the maximum depth are summarized.
+	if tree.config.MaxDepth > 0 && depth >= tree.config.MaxDepth {
+		return renderTreeLines(
+			moreStyle,
+			fmt.Sprintf("… %d more", node.countDescendants()),
+			indent+connectors.lastChild,
+			indent+connectors.lastIndent,
+			width,
+		)
+	}
+
+	children := node.Children
+	hidden := 0
+
+	if tree.config.MaxChildren > 0 && len(children) > tree.config.MaxChildren {
+		hidden = len(children) - tree.config.MaxChildren
+		children = children[:tree.config.MaxChildren]
+	}
+
+	var output string
+
+	for i, child := range children {
+		last := i == len(children)-1 && hidden == 0
+
+		output += tree.renderNode(
+			child,
+			indent+lo.Ternary(last, connectors.lastChild, connectors.child),
+			indent+lo.Ternary(last, connectors.lastIndent, connectors.indent),
+			depth+1,
+			width,
+		)
+	}
+
+	if hidden > 0 {
+		output += renderTreeLines(
+			moreStyle,
+			fmt.Sprintf("… %d more", hidden),
+			indent+connectors.lastChild,
+			indent+connectors.lastIndent,
+			width,
+		)
+	}
+
+	return output
+}
+
+// Render a node and its descendants. The label starts with prefix, and the lines under it start with childIndent.
+func (tree *treeMessage) renderNode(node TreeNode, prefix, childIndent string, depth, width int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(depth == 0)
+	connectors := tree.connectors()
+
+	// Wrapped lines of the label, as well as its detail, stay connected to the next children.
+	labelIndent := childIndent + lo.Ternary(len(node.Children) > 0, connectors.indent, connectors.lastIndent)
+
+	output := renderTreeLines(labelStyle, node.Label, prefix, childIndent, width)
+	output += tree.renderDetail(node.Detail, labelIndent, width)
+
+	return output + tree.renderChildren(node, childIndent, depth, width)
+}
+
+func (tree *treeMessage) RenderTerminal() string {
+	return tree.RenderTerminalWidth(quicklog.TermWidth)
+}
+
+func (tree *treeMessage) RenderTerminalWidth(width int) string {
+	if tree.root.Label == "" && len(tree.root.Children) == 0 {
+		return ""
+	}
+
+	// Trees without a root label display their children at the root.
+	if tree.root.Label == "" {
+		return tree.renderChildren(tree.root, "", 0, width)
+	}
+
+	return tree.renderNode(tree.root, "", "", 0, width)
+}
+
+func renderTreeNodeJSON(node TreeNode) map[string]interface{} {
+	output := map[string]interface{}{
+		"label": node.Label,
+	}
+
+	if node.Detail != nil {
+		if detail := quicklog.RenderChildJSON(node.Detail); detail != nil {
+			output["detail"] = detail
+		}
+	}
+
+	if len(node.Children) > 0 {
+		output["children"] = lo.Map(node.Children, func(child TreeNode, _ int) map[string]interface{} {
+			return renderTreeNodeJSON(child)
+		})
+	}
+
+	return output
+}
+
+// The JSON rendering is meant for machines: it always contains the whole tree.
+func (tree *treeMessage) RenderJSON() map[string]interface{} {
+	if tree.root.Label == "" && len(tree.root.Children) == 0 {
+		return nil
+	}
+
+	return quicklog.RenderKindJSON(KindTree, map[string]interface{}{
+		"message": tree.root.Label,
+		"tree":    renderTreeNodeJSON(tree.root),
+	})
+}
+
+func renderTreeNodeMarkdown(node TreeNode, depth int) string {
+	indent := strings.Repeat("  ", depth)
+
+	output := indent + "- " + escapeMarkdown(node.Label) + "\n"
+
+	if detail := quicklog.RenderMarkdown(node.Detail); detail != "" {
+		// Indent the detail, so it belongs to the list item.
+		for _, line := range strings.Split(strings.TrimRight(detail, "\n"), "\n") {
+			output += strings.TrimRight(indent+"  "+line, " ") + "\n"
+		}
+	}
+
+	for _, child := range node.Children {
+		output += renderTreeNodeMarkdown(child, depth+1)
+	}
+
+	return output
+}
+
+func (tree *treeMessage) RenderMarkdown() string {
+	if tree.root.Label == "" && len(tree.root.Children) == 0 {
+		return ""
+	}
+
+	if tree.root.Label == "" {
+		var output string
+		for _, child := range tree.root.Children {
+			output += renderTreeNodeMarkdown(child, 0)
+		}
+
+		return output + "\n"
+	}
+
+	return renderTreeNodeMarkdown(tree.root, 0) + "\n"
+}
+
+func renderTreeNodeHTML(node TreeNode) string {
+	output := "<li>" + html.EscapeString(node.Label) + quicklog.RenderHTML(node.Detail)
+
+	if len(node.Children) > 0 {
+		output += "<ul>" + strings.Join(lo.Map(node.Children, func(child TreeNode, _ int) string {
+			return renderTreeNodeHTML(child)
+		}), "") + "</ul>"
+	}
+
+	return output + "</li>"
+}
+
+func (tree *treeMessage) RenderHTML() string {
+	if tree.root.Label == "" && len(tree.root.Children) == 0 {
+		return ""
+	}
+
+	if tree.root.Label == "" {
+		return `<ul class="quicklog-tree">` + strings.Join(lo.Map(tree.root.Children, func(child TreeNode, _ int) string {
+			return renderTreeNodeHTML(child)
+		}), "") + "</ul>\n"
+	}
+
+	return `<ul class="quicklog-tree">` + renderTreeNodeHTML(tree.root) + "</ul>\n"
+}
+
+type TreeConfig struct {
+	// Optional.
+
+	// MaxDepth is the number of levels displayed in the terminal, under the root. Deeper nodes are summarized.
+	// Zero means no limit.
+	MaxDepth int
+	// MaxChildren is the number of children displayed for each node in the terminal. Remaining children are
+	// summarized. Zero means no limit.
+	MaxChildren int
+	// ASCII draws the branches with ASCII characters, for terminals that do not support box-drawing characters.
+	ASCII bool
+}
+
+var TreeConfigDefault = TreeConfig{}
+
+// NewTree renders a hierarchy of nodes, such as a dependency graph or a directory layout.
+func NewTree(root TreeNode) quicklog.Message {
+	return NewTreeWithConfig(root, &TreeConfigDefault)
+}
+
+// NewTreeWithConfig renders a hierarchy of nodes, with custom limits. Limits only apply to the terminal rendering.
+func NewTreeWithConfig(root TreeNode, config *TreeConfig) quicklog.Message {
+	return &treeMessage{
+		root:   root,
+		config: *config,
+	}
+}
@@ -0,0 +1,184 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+var dummyTree = messages.TreeNode{
+	Label: "app",
+	Children: []messages.TreeNode{
+		{
+			Label:  "cmd",
+			Detail: messages.NewBase("Entry points of the application.", nil),
+			Children: []messages.TreeNode{
+				{Label: "main.go"},
+				{Label: "internal", Children: []messages.TreeNode{{Label: "a.go"}, {Label: "b.go"}}},
+			},
+		},
+		{
+			Label: "a very long label, that does not fit in the terminal, and wraps with a hanging indentation " +
+				"under its connector",
+		},
+		{Label: "go.mod"},
+		{Label: "go.sum"},
+	},
+}
+
+func TestTreeTerminal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		root   messages.TreeNode
+		config *messages.TreeConfig
+
+		expect string
+	}{
+		{
+			name: "Tree",
+
+			root:   dummyTree,
+			config: &messages.TreeConfigDefault,
+
+			expect: "app                                                                             \n" +
+				"├── cmd                                                                         \n" +
+				"│   │   Entry points of the application.                                        \n" +
+				"│   ├── main.go                                                                 \n" +
+				"│   └── internal                                                                \n" +
+				"│       ├── a.go                                                                \n" +
+				"│       └── b.go                                                                \n" +
+				"├── a very long label, that does not fit in the terminal, and wraps with a      \n" +
+				"│   hanging indentation under its connector                                     \n" +
+				"├── go.mod                                                                      \n" +
+				"└── go.sum                                                                      \n",
+		},
+		{
+			name: "Collapsed",
+
+			root:   dummyTree,
+			config: &messages.TreeConfig{MaxDepth: 2, MaxChildren: 1},
+
+			expect: "app                                                                             \n" +
+				"├── cmd                                                                         \n" +
+				"│   │   Entry points of the application.                                        \n" +
+				"│   ├── main.go                                                                 \n" +
+				"│   └── … 1 more                                                                \n" +
+				"└── … 3 more                                                                    \n",
+		},
+		{
+			name: "MaxDepth",
+
+			root:   dummyTree,
+			config: &messages.TreeConfig{MaxDepth: 1},
+
+			expect: "app                                                                             \n" +
+				"├── cmd                                                                         \n" +
+				"│   │   Entry points of the application.                                        \n" +
+				"│   └── … 4 more                                                                \n" +
+				"├── a very long label, that does not fit in the terminal, and wraps with a      \n" +
+				"│   hanging indentation under its connector                                     \n" +
+				"├── go.mod                                                                      \n" +
+				"└── go.sum                                                                      \n",
+		},
+		{
+			name: "ASCII",
+
+			root: messages.TreeNode{
+				Label:    "app",
+				Children: []messages.TreeNode{{Label: "cmd", Children: []messages.TreeNode{{Label: "main.go"}}}, {Label: "go.mod"}},
+			},
+			config: &messages.TreeConfig{ASCII: true},
+
+			expect: "app                                                                             \n" +
+				"|-- cmd                                                                         \n" +
+				"|   `-- main.go                                                                 \n" +
+				"`-- go.mod                                                                      \n",
+		},
+		{
+			name: "NoRootLabel",
+
+			root:   messages.TreeNode{Children: []messages.TreeNode{{Label: "a"}, {Label: "b"}}},
+			config: &messages.TreeConfigDefault,
+
+			expect: "├── a                                                                           \n" +
+				"└── b                                                                           \n",
+		},
+		{
+			name: "Empty",
+
+			config: &messages.TreeConfigDefault,
+
+			expect: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := messages.NewTreeWithConfig(testCase.root, testCase.config)
+			require.Equal(t, testCase.expect, message.RenderTerminal())
+		})
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	// Limits do not apply to the JSON rendering.
+	message := messages.NewTreeWithConfig(dummyTree, &messages.TreeConfig{MaxDepth: 1, MaxChildren: 1})
+
+	require.Equal(t, map[string]interface{}{
+		"kind":           "tree",
+		"schema_version": quicklog.SchemaVersion,
+		"message":        "app",
+		"tree": map[string]interface{}{
+			"label": "app",
+			"children": []map[string]interface{}{
+				{
+					"label":  "cmd",
+					"detail": map[string]interface{}{"kind": "base", "message": "Entry points of the application."},
+					"children": []map[string]interface{}{
+						{"label": "main.go"},
+						{
+							"label":    "internal",
+							"children": []map[string]interface{}{{"label": "a.go"}, {"label": "b.go"}},
+						},
+					},
+				},
+				{
+					"label": "a very long label, that does not fit in the terminal, and wraps with a hanging " +
+						"indentation under its connector",
+				},
+				{"label": "go.mod"},
+				{"label": "go.sum"},
+			},
+		},
+	}, message.RenderJSON())
+
+	require.Nil(t, messages.NewTree(messages.TreeNode{}).RenderJSON())
+}
+
+func TestTreeMarkdown(t *testing.T) {
+	root := messages.TreeNode{
+		Label: "app",
+		Children: []messages.TreeNode{
+			{Label: "cmd", Detail: messages.NewBase("Entry *points*.", nil), Children: []messages.TreeNode{{Label: "main.go"}}},
+			{Label: "go.mod"},
+		},
+	}
+
+	require.Equal(
+		t,
+		"- app\n  - cmd\n    Entry \\*points\\*.\n    - main.go\n  - go.mod\n\n",
+		quicklog.RenderMarkdown(messages.NewTree(root)),
+	)
+	require.Equal(
+		t,
+		"<ul class=\"quicklog-tree\"><li>app<ul>"+
+			"<li>cmd<p class=\"quicklog-base\">Entry *points*.</p>\n<ul><li>main.go</li></ul></li>"+
+			"<li>go.mod</li>"+
+			"</ul></li></ul>\n",
+		quicklog.RenderHTML(messages.NewTree(root)),
+	)
+}
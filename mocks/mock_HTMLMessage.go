@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package quicklogmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockHTMLMessage is an autogenerated mock type for the HTMLMessage type
+type MockHTMLMessage struct {
+	mock.Mock
+}
+
+type MockHTMLMessage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHTMLMessage) EXPECT() *MockHTMLMessage_Expecter {
+	return &MockHTMLMessage_Expecter{mock: &_m.Mock}
+}
+
+// RenderHTML provides a mock function with given fields:
+func (_m *MockHTMLMessage) RenderHTML() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderHTML")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockHTMLMessage_RenderHTML_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderHTML'
+type MockHTMLMessage_RenderHTML_Call struct {
+	*mock.Call
+}
+
+// RenderHTML is a helper method to define mock.On call
+func (_e *MockHTMLMessage_Expecter) RenderHTML() *MockHTMLMessage_RenderHTML_Call {
+	return &MockHTMLMessage_RenderHTML_Call{Call: _e.mock.On("RenderHTML")}
+}
+
+func (_c *MockHTMLMessage_RenderHTML_Call) Run(run func()) *MockHTMLMessage_RenderHTML_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockHTMLMessage_RenderHTML_Call) Return(_a0 string) *MockHTMLMessage_RenderHTML_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHTMLMessage_RenderHTML_Call) RunAndReturn(run func() string) *MockHTMLMessage_RenderHTML_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHTMLMessage creates a new instance of MockHTMLMessage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHTMLMessage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHTMLMessage {
+	mock := &MockHTMLMessage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
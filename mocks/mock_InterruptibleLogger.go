@@ -0,0 +1,150 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package quicklogmocks
+
+import (
+	quicklog "github.com/a-novel-kit/quicklog"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInterruptibleLogger is an autogenerated mock type for the InterruptibleLogger type
+type MockInterruptibleLogger struct {
+	mock.Mock
+}
+
+type MockInterruptibleLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterruptibleLogger) EXPECT() *MockInterruptibleLogger_Expecter {
+	return &MockInterruptibleLogger_Expecter{mock: &_m.Mock}
+}
+
+// Interrupt provides a mock function with given fields: err
+func (_m *MockInterruptibleLogger) Interrupt(err error) {
+	_m.Called(err)
+}
+
+// MockInterruptibleLogger_Interrupt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Interrupt'
+type MockInterruptibleLogger_Interrupt_Call struct {
+	*mock.Call
+}
+
+// Interrupt is a helper method to define mock.On call
+//   - err error
+func (_e *MockInterruptibleLogger_Expecter) Interrupt(err interface{}) *MockInterruptibleLogger_Interrupt_Call {
+	return &MockInterruptibleLogger_Interrupt_Call{Call: _e.mock.On("Interrupt", err)}
+}
+
+func (_c *MockInterruptibleLogger_Interrupt_Call) Run(run func(err error)) *MockInterruptibleLogger_Interrupt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(error))
+	})
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_Interrupt_Call) Return() *MockInterruptibleLogger_Interrupt_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_Interrupt_Call) RunAndReturn(run func(error)) *MockInterruptibleLogger_Interrupt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Log provides a mock function with given fields: level, message
+func (_m *MockInterruptibleLogger) Log(level quicklog.Level, message quicklog.Message) {
+	_m.Called(level, message)
+}
+
+// MockInterruptibleLogger_Log_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Log'
+type MockInterruptibleLogger_Log_Call struct {
+	*mock.Call
+}
+
+// Log is a helper method to define mock.On call
+//   - level quicklog.Level
+//   - message quicklog.Message
+func (_e *MockInterruptibleLogger_Expecter) Log(level interface{}, message interface{}) *MockInterruptibleLogger_Log_Call {
+	return &MockInterruptibleLogger_Log_Call{Call: _e.mock.On("Log", level, message)}
+}
+
+func (_c *MockInterruptibleLogger_Log_Call) Run(run func(level quicklog.Level, message quicklog.Message)) *MockInterruptibleLogger_Log_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.Level), args[1].(quicklog.Message))
+	})
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_Log_Call) Return() *MockInterruptibleLogger_Log_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_Log_Call) RunAndReturn(run func(quicklog.Level, quicklog.Message)) *MockInterruptibleLogger_Log_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogAnimated provides a mock function with given fields: message
+func (_m *MockInterruptibleLogger) LogAnimated(message quicklog.AnimatedMessage) func() {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogAnimated")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(quicklog.AnimatedMessage) func()); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockInterruptibleLogger_LogAnimated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogAnimated'
+type MockInterruptibleLogger_LogAnimated_Call struct {
+	*mock.Call
+}
+
+// LogAnimated is a helper method to define mock.On call
+//   - message quicklog.AnimatedMessage
+func (_e *MockInterruptibleLogger_Expecter) LogAnimated(message interface{}) *MockInterruptibleLogger_LogAnimated_Call {
+	return &MockInterruptibleLogger_LogAnimated_Call{Call: _e.mock.On("LogAnimated", message)}
+}
+
+func (_c *MockInterruptibleLogger_LogAnimated_Call) Run(run func(message quicklog.AnimatedMessage)) *MockInterruptibleLogger_LogAnimated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(quicklog.AnimatedMessage))
+	})
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_LogAnimated_Call) Return(cleaner func()) *MockInterruptibleLogger_LogAnimated_Call {
+	_c.Call.Return(cleaner)
+	return _c
+}
+
+func (_c *MockInterruptibleLogger_LogAnimated_Call) RunAndReturn(run func(quicklog.AnimatedMessage) func()) *MockInterruptibleLogger_LogAnimated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterruptibleLogger creates a new instance of MockInterruptibleLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterruptibleLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterruptibleLogger {
+	mock := &MockInterruptibleLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
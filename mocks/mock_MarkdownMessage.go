@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package quicklogmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockMarkdownMessage is an autogenerated mock type for the MarkdownMessage type
+type MockMarkdownMessage struct {
+	mock.Mock
+}
+
+type MockMarkdownMessage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarkdownMessage) EXPECT() *MockMarkdownMessage_Expecter {
+	return &MockMarkdownMessage_Expecter{mock: &_m.Mock}
+}
+
+// RenderMarkdown provides a mock function with given fields:
+func (_m *MockMarkdownMessage) RenderMarkdown() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderMarkdown")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockMarkdownMessage_RenderMarkdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderMarkdown'
+type MockMarkdownMessage_RenderMarkdown_Call struct {
+	*mock.Call
+}
+
+// RenderMarkdown is a helper method to define mock.On call
+func (_e *MockMarkdownMessage_Expecter) RenderMarkdown() *MockMarkdownMessage_RenderMarkdown_Call {
+	return &MockMarkdownMessage_RenderMarkdown_Call{Call: _e.mock.On("RenderMarkdown")}
+}
+
+func (_c *MockMarkdownMessage_RenderMarkdown_Call) Run(run func()) *MockMarkdownMessage_RenderMarkdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMarkdownMessage_RenderMarkdown_Call) Return(_a0 string) *MockMarkdownMessage_RenderMarkdown_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMarkdownMessage_RenderMarkdown_Call) RunAndReturn(run func() string) *MockMarkdownMessage_RenderMarkdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarkdownMessage creates a new instance of MockMarkdownMessage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarkdownMessage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarkdownMessage {
+	mock := &MockMarkdownMessage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package quicklogmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockSectionMessage is an autogenerated mock type for the SectionMessage type
+type MockSectionMessage struct {
+	mock.Mock
+}
+
+type MockSectionMessage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSectionMessage) EXPECT() *MockSectionMessage_Expecter {
+	return &MockSectionMessage_Expecter{mock: &_m.Mock}
+}
+
+// SectionTitle provides a mock function with given fields:
+func (_m *MockSectionMessage) SectionTitle() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SectionTitle")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockSectionMessage_SectionTitle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SectionTitle'
+type MockSectionMessage_SectionTitle_Call struct {
+	*mock.Call
+}
+
+// SectionTitle is a helper method to define mock.On call
+func (_e *MockSectionMessage_Expecter) SectionTitle() *MockSectionMessage_SectionTitle_Call {
+	return &MockSectionMessage_SectionTitle_Call{Call: _e.mock.On("SectionTitle")}
+}
+
+func (_c *MockSectionMessage_SectionTitle_Call) Run(run func()) *MockSectionMessage_SectionTitle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSectionMessage_SectionTitle_Call) Return(_a0 string) *MockSectionMessage_SectionTitle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSectionMessage_SectionTitle_Call) RunAndReturn(run func() string) *MockSectionMessage_SectionTitle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSectionMessage creates a new instance of MockSectionMessage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSectionMessage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSectionMessage {
+	mock := &MockSectionMessage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package quicklogmocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockWidthAwareMessage is an autogenerated mock type for the WidthAwareMessage type
+type MockWidthAwareMessage struct {
+	mock.Mock
+}
+
+type MockWidthAwareMessage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWidthAwareMessage) EXPECT() *MockWidthAwareMessage_Expecter {
+	return &MockWidthAwareMessage_Expecter{mock: &_m.Mock}
+}
+
+// RenderTerminalWidth provides a mock function with given fields: width
+func (_m *MockWidthAwareMessage) RenderTerminalWidth(width int) string {
+	ret := _m.Called(width)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderTerminalWidth")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(width)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockWidthAwareMessage_RenderTerminalWidth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderTerminalWidth'
+type MockWidthAwareMessage_RenderTerminalWidth_Call struct {
+	*mock.Call
+}
+
+// RenderTerminalWidth is a helper method to define mock.On call
+//   - width int
+func (_e *MockWidthAwareMessage_Expecter) RenderTerminalWidth(width interface{}) *MockWidthAwareMessage_RenderTerminalWidth_Call {
+	return &MockWidthAwareMessage_RenderTerminalWidth_Call{Call: _e.mock.On("RenderTerminalWidth", width)}
+}
+
+func (_c *MockWidthAwareMessage_RenderTerminalWidth_Call) Run(run func(width int)) *MockWidthAwareMessage_RenderTerminalWidth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockWidthAwareMessage_RenderTerminalWidth_Call) Return(_a0 string) *MockWidthAwareMessage_RenderTerminalWidth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWidthAwareMessage_RenderTerminalWidth_Call) RunAndReturn(run func(int) string) *MockWidthAwareMessage_RenderTerminalWidth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWidthAwareMessage creates a new instance of MockWidthAwareMessage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWidthAwareMessage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWidthAwareMessage {
+	mock := &MockWidthAwareMessage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
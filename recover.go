@@ -0,0 +1,83 @@
+package quicklog
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/samber/lo"
+)
+
+// NewPanicMessage creates the message logged by RecoverAndLog. It is set by the messages package, which provides
+// a dedicated rendering for panics.
+var NewPanicMessage = func(value interface{}, stack []byte) Message {
+	return &rawPanicMessage{value: value, stack: stack}
+}
+
+// rawPanicMessage is a plain rendering of a panic, used when the messages package is not loaded.
+type rawPanicMessage struct {
+	value interface{}
+	stack []byte
+
+	Message
+}
+
+func (message *rawPanicMessage) RenderTerminal() string {
+	return fmt.Sprintf("panic: %v\n\n%s", message.value, message.stack)
+}
+
+func (message *rawPanicMessage) RenderJSON() map[string]interface{} {
+	// Same kind as the rendering of the messages package, which it stands in for.
+	return RenderKindJSON("panic", map[string]interface{}{
+		"message": fmt.Sprintf("panic: %v", message.value),
+		"panic":   fmt.Sprint(message.value),
+		"stack":   string(message.stack),
+	})
+}
+
+type RecoverConfig struct {
+	// ExitCode is the status the program exits with, once the panic is logged. Defaults to 2, like an unrecovered
+	// panic.
+	ExitCode int
+	// RePanic resumes panicking once the panic is logged, instead of exiting.
+	RePanic bool
+}
+
+var RecoverConfigDefault = RecoverConfig{
+	ExitCode: 2,
+}
+
+func handlePanic(logger Logger, value interface{}, config *RecoverConfig) {
+	stack := debug.Stack()
+
+	// Clean the terminal before printing the panic, so it is not mixed with a half-rendered animation.
+	if interruptible, ok := logger.(InterruptibleLogger); ok {
+		interruptible.Interrupt(fmt.Errorf("panic: %v", value))
+	}
+
+	logger.Log(LevelError, NewPanicMessage(value, stack))
+
+	if config.RePanic {
+		panic(value)
+	}
+
+	Exit(lo.CoalesceOrEmpty(config.ExitCode, RecoverConfigDefault.ExitCode))
+}
+
+// RecoverAndLog recovers from a panic, and logs it using the given logger, before exiting the program with Exit.
+// It must be called with defer.
+//
+//	defer quicklog.RecoverAndLog(logger)
+func RecoverAndLog(logger Logger) {
+	// Recover only works when called directly by the deferred function.
+	if value := recover(); value != nil {
+		handlePanic(logger, value, &RecoverConfigDefault)
+	}
+}
+
+// RecoverAndLogWithConfig is similar to RecoverAndLog, with a custom behavior once the panic is logged. It must be
+// called with defer.
+func RecoverAndLogWithConfig(logger Logger, config *RecoverConfig) {
+	if value := recover(); value != nil {
+		handlePanic(logger, value, config)
+	}
+}
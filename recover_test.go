@@ -0,0 +1,131 @@
+package quicklog_test
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	testutils "github.com/a-novel-kit/test-utils"
+
+	"github.com/a-novel-kit/quicklog"
+	"github.com/a-novel-kit/quicklog/loggers"
+	"github.com/a-novel-kit/quicklog/messages"
+)
+
+func requireExitCode(t *testing.T, res *testutils.CMDResult, code int) {
+	t.Helper()
+
+	var exitErr *exec.ExitError
+
+	require.ErrorAs(t, res.Err, &exitErr)
+	require.Equal(t, code, exitErr.ExitCode())
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLog(logger)
+
+			panic("something went wrong")
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			requireExitCode(t, res, 2)
+			require.Regexp(t, regexp.MustCompile(`^panic: something went wrong\s+\ngoroutine \d+ \[running]:`), res.STDErr)
+			require.Contains(t, res.STDErr, "TestRecoverAndLog")
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestRecoverAndLogNoPanic(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLog(logger)
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.True(t, res.Success)
+			require.Empty(t, res.STDErr)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestRecoverAndLogExitCode(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLogWithConfig(logger, &quicklog.RecoverConfig{ExitCode: 42})
+
+			panic(errors.New("something went wrong"))
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			requireExitCode(t, res, 42)
+			require.Regexp(t, regexp.MustCompile(`^panic: something went wrong\s+\n`), res.STDErr)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestRecoverAndLogDefaultExitCode(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLogWithConfig(logger, &quicklog.RecoverConfig{})
+
+			panic("something went wrong")
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			// The program does not report success after a panic.
+			require.False(t, res.Success)
+			requireExitCode(t, res, 2)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestRecoverAndLogRePanic(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLogWithConfig(logger, &quicklog.RecoverConfig{RePanic: true})
+
+			panic("something went wrong")
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.False(t, res.Success)
+			require.Regexp(t, regexp.MustCompile(`^panic: something went wrong\s+\n`), res.STDErr)
+			// The runtime prints the panic again, once re-raised.
+			require.Contains(t, res.STDErr, "[recovered")
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
+
+func TestRecoverAndLogCloseAnimated(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			logger := loggers.NewTerminal()
+			defer quicklog.RecoverAndLog(logger)
+
+			loader := messages.NewLoader("running", &messages.LoaderConfigDefault)
+			logger.LogAnimated(loader)
+
+			time.Sleep(10 * time.Millisecond)
+			panic("something went wrong")
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			requireExitCode(t, res, 2)
+			// The loader is closed in an error state, before the panic is logged.
+			require.Regexp(t, regexp.MustCompile(`✗ panic: something went wrong\s+.+\n$`), res.STDOut)
+			require.Regexp(t, regexp.MustCompile(`^panic: something went wrong\s+\n`), res.STDErr)
+		},
+		Env: []string{"CI=true", "GITHUB_ACTIONS=", "GITLAB_CI="},
+	})
+}
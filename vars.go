@@ -4,7 +4,9 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/mattn/go-isatty"
 	"github.com/samber/lo"
 )
 
@@ -30,3 +32,73 @@ func getTermWidth() int {
 //
 // It may be overridden by setting the TermWidthEnv environment variable.
 var TermWidth = lo.CoalesceOrEmpty(getTermWidth(), 80)
+
+// CIEnv is the name of the environment variable that indicates a CI environment, when set to "true".
+const CIEnv = "CI"
+
+// CI indicates whether the program runs in a CI environment, where outputs are usually stored as plain text, and
+// should remain concise. It is set from the CIEnv environment variable.
+var CI = os.Getenv(CIEnv) == "true"
+
+// HyperlinksEnv is the name of the environment variable that can be used to override the Hyperlinks value. It
+// accepts any boolean value supported by strconv.ParseBool.
+const HyperlinksEnv = "TERM_HYPERLINKS"
+
+// Values of the TERM_PROGRAM environment variable, for terminals known to support hyperlinks.
+var hyperlinkTermPrograms = []string{"iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby"}
+
+// Prefixes of the TERM environment variable, for terminals known to support hyperlinks.
+var hyperlinkTerms = []string{"xterm-kitty", "xterm-ghostty", "alacritty", "foot", "wezterm"}
+
+// Guess whether the terminal supports hyperlinks, from the variables it sets.
+func detectHyperlinks() bool {
+	// CI logs are usually stored as plain text, where the escape sequences are not interpreted.
+	if CI {
+		return false
+	}
+
+	if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		return false
+	}
+
+	if lo.Contains(hyperlinkTermPrograms, os.Getenv("TERM_PROGRAM")) {
+		return true
+	}
+
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" {
+		return true
+	}
+
+	// VTE based terminals (GNOME Terminal, Tilix...) support hyperlinks since version 0.50.
+	if vteVersion, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vteVersion >= 5000 {
+		return true
+	}
+
+	term := os.Getenv("TERM")
+
+	return lo.SomeBy(hyperlinkTerms, func(prefix string) bool {
+		return strings.HasPrefix(term, prefix)
+	})
+}
+
+func getHyperlinks() bool {
+	envHyperlinks := os.Getenv(HyperlinksEnv)
+	if envHyperlinks == "" {
+		return detectHyperlinks()
+	}
+
+	parsedHyperlinks, err := strconv.ParseBool(envHyperlinks)
+	if err != nil {
+		log.Printf("Failed to parse TERM_HYPERLINKS environment variable. Detecting support instead: %s\n", err)
+		return detectHyperlinks()
+	}
+
+	return parsedHyperlinks
+}
+
+// Hyperlinks indicates whether links are rendered as clickable hyperlinks (OSC 8) in the terminal. When disabled,
+// links are rendered as "text (url)".
+//
+// Support is guessed from the environment, and is always disabled in CI. It may be overridden by setting the
+// HyperlinksEnv environment variable.
+var Hyperlinks = getHyperlinks()
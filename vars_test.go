@@ -45,3 +45,66 @@ func TestTermWidthOverrideInvalid(t *testing.T) {
 		Env: []string{"TERM_WIDTH=foobar"},
 	})
 }
+
+func TestHyperlinks(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		env []string
+
+		expect bool
+	}{
+		{
+			name:   "Override",
+			env:    []string{"TERM_HYPERLINKS=true", "CI=true"},
+			expect: true,
+		},
+		{
+			name:   "OverrideDisabled",
+			env:    []string{"TERM_HYPERLINKS=false", "TERM_PROGRAM=iTerm.app"},
+			expect: false,
+		},
+		{
+			name:   "CI",
+			env:    []string{"TERM_HYPERLINKS=", "CI=true", "TERM_PROGRAM=iTerm.app"},
+			expect: false,
+		},
+		{
+			// Test output is not a terminal.
+			name:   "NotATerminal",
+			env:    []string{"TERM_HYPERLINKS=", "CI=", "TERM_PROGRAM=iTerm.app"},
+			expect: false,
+		},
+		{
+			name:   "OverrideInvalid",
+			env:    []string{"TERM_HYPERLINKS=foobar", "CI=true"},
+			expect: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testutils.RunCMD(t, &testutils.CMDConfig{
+				CmdFn: func(t *testing.T) {
+					require.Equal(t, testCase.expect, quicklog.Hyperlinks)
+				},
+				MainFn: func(t *testing.T, res *testutils.CMDResult) {
+					require.True(t, res.Success, res.STDErr)
+				},
+				Env: testCase.env,
+			})
+		})
+	}
+}
+
+func TestCI(t *testing.T) {
+	testutils.RunCMD(t, &testutils.CMDConfig{
+		CmdFn: func(t *testing.T) {
+			require.True(t, quicklog.CI)
+		},
+		MainFn: func(t *testing.T, res *testutils.CMDResult) {
+			require.True(t, res.Success, res.STDErr)
+		},
+		Env: []string{"CI=true"},
+	})
+}